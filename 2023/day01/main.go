@@ -1,11 +1,12 @@
-package main
+package day01
 
 import (
-	"bufio"
-	"log"
-	"os"
+	"context"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 var digitAsLetters = map[string]string{
@@ -24,69 +25,76 @@ func isDigit(r rune) bool {
 	return r >= '0' && r <= '9'
 }
 
-func getFirstDigitFromString(line string) string {
+func getFirstDigitFromString(line string) (string, error) {
 	for i, r := range []rune(line) {
 		if isDigit(r) {
-			return string(r)
+			return string(r), nil
 		}
 		for letters, digit := range digitAsLetters {
 			if strings.HasPrefix(line[i:], letters) {
-				return digit
+				return digit, nil
 			}
 		}
 	}
-	log.Fatalf("Unable to get first digit from provided line '%s'", line)
-	return "0"
+	return "", aoc.Unexpected(line, "a line with at least one digit")
 }
 
-func getLastDigitFromString(line string) string {
+func getLastDigitFromString(line string) (string, error) {
 	for i := len(line) - 1; i >= 0; i-- {
 		r := rune(line[i])
 		if isDigit(r) {
-			return string(r)
+			return string(r), nil
 		}
 		for letters, digit := range digitAsLetters {
 			if strings.HasSuffix(line[:i+1], letters) {
-				return digit
+				return digit, nil
 			}
 		}
 	}
-	log.Fatalf("Unable to get last digit from provided line '%s'", line)
-	return "0"
+	return "", aoc.Unexpected(line, "a line with at least one digit")
 }
 
-func getDigitsFromString(line string) int64 {
-	firstDigit := getFirstDigitFromString(line)
-	lastDigit := getLastDigitFromString(line)
-	number, errParsing := strconv.ParseInt(firstDigit+lastDigit, 10, 64)
-	if errParsing != nil {
-		log.Fatalf("Unable to parse digits provided '%s' & '%s': %v", firstDigit, lastDigit, errParsing)
+func getDigitsFromString(line string) (int64, error) {
+	firstDigit, errFirstDigit := getFirstDigitFromString(line)
+	if errFirstDigit != nil {
+		return 0, errFirstDigit
+	}
+	lastDigit, errLastDigit := getLastDigitFromString(line)
+	if errLastDigit != nil {
+		return 0, errLastDigit
 	}
-	return number
+	return strconv.ParseInt(firstDigit+lastDigit, 10, 64)
 }
 
-func getSumOfDigits(file *os.File) int64 {
+func getSumOfDigits(input io.Reader) (int64, error) {
 	var finalSum int64
-	scanner := bufio.NewScanner(file)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
-		finalSum += getDigitsFromString(scanner.Text())
+		digits, errParsing := getDigitsFromString(scanner.Text())
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		finalSum += digits
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return finalSum
+	return finalSum, nil
 }
 
-func main() {
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
-	}
-	defer inputFile.Close()
-
-	finalSum := getSumOfDigits(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   1,
+	Title: "Trebuchet?!",
+	Input: aoc.LinesMatching(`[a-z0-9]+`, "a line of letters and digits"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getSumOfDigits(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final sum: %d", finalSum)
+func init() {
+	aoc.Register(Solver)
 }
@@ -0,0 +1,15 @@
+package day01
+
+import (
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
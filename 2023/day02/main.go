@@ -1,12 +1,12 @@
-package main
+package day02
 
 import (
-	"bufio"
-	"log"
-	"os"
+	"context"
+	"io"
 	"regexp"
-	"strconv"
 	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Bag struct {
@@ -17,19 +17,16 @@ type Bag struct {
 
 var drawsLineRegex = regexp.MustCompile(`(\d*) (red|blue|green)`)
 
-func parseGameDraws(drawsLine string) ([]Bag, Bag) {
+func parseGameDraws(drawsLine string) ([]Bag, Bag, error) {
 	var draws []Bag
 	var minimumBagForGame Bag
 	for _, drawStr := range strings.Split(drawsLine, "; ") {
 		cubesMatches := drawsLineRegex.FindAllStringSubmatch(drawStr, -1)
 		var draw Bag
 		for _, cubeMatch := range cubesMatches {
-			if len(cubeMatch) != 3 {
-				log.Fatalf("Unable to parse cube match '%s', getting results length of %d", cubeMatch, len(cubeMatch))
-			}
-			cubeCount, errParsingCount := strconv.ParseInt(cubeMatch[1], 10, 64)
+			cubeCount, errParsingCount := aoc.ParseInt(cubeMatch[1], 64)
 			if errParsingCount != nil {
-				log.Fatalf("Unable to parse cube count '%s': %v", cubeMatch[1], errParsingCount)
+				return nil, Bag{}, errParsingCount
 			}
 			cubeColor := cubeMatch[2]
 			if cubeColor == "red" {
@@ -39,7 +36,7 @@ func parseGameDraws(drawsLine string) ([]Bag, Bag) {
 			} else if cubeColor == "blue" {
 				draw.blue += cubeCount
 			} else {
-				log.Fatalf("Unkown cube color '%s'", cubeColor)
+				return nil, Bag{}, aoc.Unexpected(cubeColor, "red, green or blue")
 			}
 		}
 		draws = append(draws, draw)
@@ -53,48 +50,57 @@ func parseGameDraws(drawsLine string) ([]Bag, Bag) {
 			minimumBagForGame.blue = draw.blue
 		}
 	}
-	return draws, minimumBagForGame
+	return draws, minimumBagForGame, nil
 }
 
 var gameLineRegex = regexp.MustCompile(`^Game (\d*): (.*)$`)
 
-func parseGameLine(line string) (int64, []Bag, Bag) {
+func parseGameLine(line string) (int64, []Bag, Bag, error) {
 	results := gameLineRegex.FindStringSubmatch(line)
 	if len(results) != 3 {
-		log.Fatalf("Unable to parse game line '%s', getting results length of %d", line, len(results))
+		return 0, nil, Bag{}, aoc.Unexpected(line, "'Game <id>: <draws>'")
 	}
-	gameId, errParsingId := strconv.ParseInt(results[1], 10, 64)
+	gameId, errParsingId := aoc.ParseInt(results[1], 64)
 	if errParsingId != nil {
-		log.Fatalf("Unable to parse game id '%s': %v", results[1], errParsingId)
+		return 0, nil, Bag{}, errParsingId
 	}
-	draws, minimumBagForGame := parseGameDraws(results[2])
-	return gameId, draws, minimumBagForGame
+	draws, minimumBagForGame, errParsingDraws := parseGameDraws(results[2])
+	if errParsingDraws != nil {
+		return 0, nil, Bag{}, errParsingDraws
+	}
+	return gameId, draws, minimumBagForGame, nil
 }
 
-func getSumOfGamePowerCubes(file *os.File) int64 {
+func getSumOfGamePowerCubes(input io.Reader) (int64, error) {
 	var finalSum int64
-	scanner := bufio.NewScanner(file)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
 		line := scanner.Text()
-		_, _, minimumBagForGame := parseGameLine(line)
+		_, _, minimumBagForGame, errParsing := parseGameLine(line)
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
 		finalSum += minimumBagForGame.red * minimumBagForGame.green * minimumBagForGame.blue
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return finalSum
+	return finalSum, nil
 }
 
-func main() {
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
-	}
-	defer inputFile.Close()
-
-	finalSum := getSumOfGamePowerCubes(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   2,
+	Title: "Cube Conundrum",
+	Input: aoc.LinesMatching(`Game \d+: \d+ (red|green|blue)((, |; )\d+ (red|green|blue))*`, "'Game <id>: <draws>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getSumOfGamePowerCubes(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final sum: %d", finalSum)
+func init() {
+	aoc.Register(Solver)
 }
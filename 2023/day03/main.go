@@ -1,14 +1,12 @@
-package main
+package day03
 
 import (
-	"bufio"
-	"log"
-	"os"
-	"strconv"
-	"time"
-)
+	"context"
+	"fmt"
+	"io"
 
-const matrixLength = 140
+	"github.com/antitoine/advent-of-code/aoc"
+)
 
 type Cell struct {
 	linkedNumber *int64
@@ -23,6 +21,7 @@ type Coords struct {
 
 type Matrix struct {
 	rows          []Row
+	width         int
 	detectedGears []Coords
 }
 
@@ -31,65 +30,76 @@ type DetectedNumber struct {
 	affectedCellsIdx []int
 }
 
-func (d *DetectedNumber) getNumber() *int64 {
+func (d *DetectedNumber) getNumber() (*int64, error) {
 	if d.numberStr == "" {
-		return nil
+		return nil, nil
 	}
-	number, errParsingNumber := strconv.ParseInt(d.numberStr, 10, 64)
+	number, errParsingNumber := aoc.ParseInt(d.numberStr, 64)
 	if errParsingNumber != nil {
-		log.Fatalf("Unable to parse number '%s': %v", d.numberStr, errParsingNumber)
+		return nil, errParsingNumber
 	}
-	return &number
+	return &number, nil
 }
 
-func (d *DetectedNumber) affectedNumber(row Row) {
-	nb := d.getNumber()
-	if nb == nil {
-		return
+func (d *DetectedNumber) affectedNumber(row Row) error {
+	nb, errParsingNumber := d.getNumber()
+	if errParsingNumber != nil || nb == nil {
+		return errParsingNumber
 	}
 	for _, cellIdx := range d.affectedCellsIdx {
 		row[cellIdx].linkedNumber = nb
 	}
 	d.numberStr = ""
 	d.affectedCellsIdx = nil
+	return nil
 }
 
-func parseMatrix(file *os.File) Matrix {
-	scanner := bufio.NewScanner(file)
+func parseMatrix(input io.Reader) (Matrix, error) {
+	scanner := aoc.NewScanner(input)
 
-	matrix := Matrix{
-		rows: make([]Row, matrixLength),
-	}
+	var matrix Matrix
 	for rowIdx := 0; scanner.Scan(); rowIdx++ {
 		line := scanner.Text()
-		row := make(Row, matrixLength)
+		if rowIdx == 0 {
+			matrix.width = len(line)
+		}
+		if len(line) != matrix.width {
+			return Matrix{}, scanner.Unexpected(fmt.Sprintf("a line of %d cells", matrix.width))
+		}
+		row := make(Row, matrix.width)
 		detectedNumber := &DetectedNumber{}
 		for cellIdx, char := range line {
 			var cell Cell
+			var errParsingNumber error
 			if char != '.' {
 				if char >= '0' && char <= '9' {
 					detectedNumber.numberStr += string(char)
 					detectedNumber.affectedCellsIdx = append(detectedNumber.affectedCellsIdx, cellIdx)
 				} else {
-					detectedNumber.affectedNumber(row)
+					errParsingNumber = detectedNumber.affectedNumber(row)
 				}
 				if char == '*' {
 					matrix.detectedGears = append(matrix.detectedGears, Coords{rowIdx, cellIdx})
 				}
 			} else {
-				detectedNumber.affectedNumber(row)
+				errParsingNumber = detectedNumber.affectedNumber(row)
+			}
+			if errParsingNumber != nil {
+				return Matrix{}, scanner.Wrap(errParsingNumber)
 			}
 			row[cellIdx] = cell
 		}
-		detectedNumber.affectedNumber(row)
-		matrix.rows[rowIdx] = row
+		if errParsingNumber := detectedNumber.affectedNumber(row); errParsingNumber != nil {
+			return Matrix{}, scanner.Wrap(errParsingNumber)
+		}
+		matrix.rows = append(matrix.rows, row)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return Matrix{}, errScanningFile
 	}
 
-	return matrix
+	return matrix, nil
 }
 
 type Ratio struct {
@@ -114,9 +124,12 @@ func (ratio *Ratio) getNumberToSum() int64 {
 	return 0
 }
 
-func getSumOfNumbersAttachedToSymbols(file *os.File) int64 {
+func getSumOfNumbersAttachedToSymbols(input io.Reader) (int64, error) {
 	var finalSum int64
-	matrix := parseMatrix(file)
+	matrix, errParsing := parseMatrix(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	for _, coords := range matrix.detectedGears {
 		ratio := &Ratio{
 			linkedNumbers: make(map[*int64]struct{}),
@@ -126,41 +139,42 @@ func getSumOfNumbersAttachedToSymbols(file *os.File) int64 {
 				ratio.attachCell(matrix.rows[coords.rowIdx-1][coords.cellIdx-1])
 			}
 			ratio.attachCell(matrix.rows[coords.rowIdx-1][coords.cellIdx])
-			if coords.cellIdx+1 < matrixLength {
+			if coords.cellIdx+1 < matrix.width {
 				ratio.attachCell(matrix.rows[coords.rowIdx-1][coords.cellIdx+1])
 			}
 		}
 		if coords.cellIdx-1 >= 0 {
 			ratio.attachCell(matrix.rows[coords.rowIdx][coords.cellIdx-1])
 		}
-		if coords.cellIdx+1 < matrixLength {
+		if coords.cellIdx+1 < matrix.width {
 			ratio.attachCell(matrix.rows[coords.rowIdx][coords.cellIdx+1])
 		}
-		if coords.rowIdx+1 < matrixLength {
+		if coords.rowIdx+1 < len(matrix.rows) {
 			if coords.cellIdx-1 >= 0 {
 				ratio.attachCell(matrix.rows[coords.rowIdx+1][coords.cellIdx-1])
 			}
 			ratio.attachCell(matrix.rows[coords.rowIdx+1][coords.cellIdx])
-			if coords.cellIdx+1 < matrixLength {
+			if coords.cellIdx+1 < matrix.width {
 				ratio.attachCell(matrix.rows[coords.rowIdx+1][coords.cellIdx+1])
 			}
 		}
 		finalSum += ratio.getNumberToSum()
 	}
 
-	return finalSum
+	return finalSum, nil
 }
 
-func main() {
-	startTime := time.Now()
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
-	}
-	defer inputFile.Close()
-
-	finalSum := getSumOfNumbersAttachedToSymbols(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   3,
+	Title: "Gear Ratios",
+	Input: aoc.Grid{Alphabet: ".0123456789#$%&*+-/=@"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getSumOfNumbersAttachedToSymbols(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final sum: %d", finalSum)
-	log.Printf("Execution duration: %s", time.Since(startTime))
+func init() {
+	aoc.Register(Solver)
 }
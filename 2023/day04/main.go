@@ -1,47 +1,49 @@
-package main
+package day04
 
 import (
-	"bufio"
+	"context"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"sort"
-	"strconv"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 var cardLineRegex = regexp.MustCompile(`^Card\s*(\d*): ([\s\d]*) \| ([\s\d]*)$`)
 var numbersRegex = regexp.MustCompile(`\s*(\d*)\s*`)
 
-func numbersStrToInts(numbersStr string) []int64 {
+func numbersStrToInts(numbersStr string) ([]int64, error) {
 	var numbers []int64
 	for _, numberStr := range numbersRegex.FindAllStringSubmatch(numbersStr, -1) {
-		if len(numberStr) != 2 {
-			log.Fatalf("Unable to parse number '%s', getting results length of %d", numbersStr, len(numberStr))
-		}
-		numberInt, errParsingNumber := strconv.ParseInt(numberStr[1], 10, 64)
+		numberInt, errParsingNumber := aoc.ParseInt(numberStr[1], 64)
 		if errParsingNumber != nil {
-			log.Fatalf("Unable to parse number '%s': %v", numberStr[1], errParsingNumber)
+			return nil, errParsingNumber
 		}
 		numbers = append(numbers, numberInt)
 	}
 	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
-	return numbers
+	return numbers, nil
 }
 
-func getSumOfWinningCards(input io.Reader) int64 {
+func getSumOfWinningCards(input io.Reader) (int64, error) {
 	var finalSum int64
 	var cardsFactor []int64
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	for cardIdx := 0; scanner.Scan(); cardIdx++ {
 		line := scanner.Text()
 
 		results := cardLineRegex.FindStringSubmatch(line)
 		if len(results) != 4 {
-			log.Fatalf("Unable to parse card line '%s', getting results length of %d", line, len(results))
+			return 0, scanner.Unexpected("'Card <id>: <numbers> | <numbers>'")
+		}
+		winningNumbers, errParsingWinning := numbersStrToInts(results[2])
+		if errParsingWinning != nil {
+			return 0, scanner.Wrap(errParsingWinning)
+		}
+		gettingNumbers, errParsingGetting := numbersStrToInts(results[3])
+		if errParsingGetting != nil {
+			return 0, scanner.Wrap(errParsingGetting)
 		}
-		winningNumbers := numbersStrToInts(results[2])
-		gettingNumbers := numbersStrToInts(results[3])
 
 		winningIndex := 0
 		gettingIndex := 0
@@ -54,10 +56,8 @@ func getSumOfWinningCards(input io.Reader) int64 {
 				gettingIndex++
 			} else if winningNumbers[winningIndex] < gettingNumbers[gettingIndex] {
 				winningIndex++
-			} else if winningNumbers[winningIndex] > gettingNumbers[gettingIndex] {
-				gettingIndex++
 			} else {
-				log.Fatalf("Unkown case: winningIndex=%d, gettingIndex=%d", winningIndex, gettingIndex)
+				gettingIndex++
 			}
 		}
 		if cardIdx >= len(cardsFactor) {
@@ -76,20 +76,23 @@ func getSumOfWinningCards(input io.Reader) int64 {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return finalSum
+	return finalSum, nil
 }
 
-func main() {
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
-	}
-	defer inputFile.Close()
-
-	finalSum := getSumOfWinningCards(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   4,
+	Title: "Scratchcards",
+	Input: aoc.LinesMatching(`Card +\d+:( +\d+)+ \|( +\d+)+`, "'Card <id>: <numbers> | <numbers>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getSumOfWinningCards(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final sum: %d", finalSum)
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,21 +1,15 @@
-package main
+package day04
 
 import (
-	"strings"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-func TestGetSumOfWinningCards(t *testing.T) {
-	input := `Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
-Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19
-Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1
-Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83
-Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36
-Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11
-`
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
 
-	finalSum := getSumOfWinningCards(strings.NewReader(input))
-	if finalSum != 30 {
-		t.Errorf("Expected finalSum to be 30, got %d", finalSum)
-	}
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
 }
@@ -1,172 +1,152 @@
-package main
+package day05
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
-	"sort"
-	"strconv"
-)
-
-type Range struct {
-	from int64
-	len  int64
-}
 
-type RangeMap struct {
-	from int64
-	to   int64
-	len  int64
-}
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/intervals"
+)
 
 type Map struct {
 	from    string
 	to      string
-	mapping []RangeMap
-	nextMap *Map
+	mapping intervals.PiecewiseMap
 }
 
 var numbersRegex = regexp.MustCompile(`\s*(\d*)\s*`)
 
-func numbersStrToInts(numbersStr string) []int64 {
+func numbersStrToInts(numbersStr string) ([]int64, error) {
 	var numbers []int64
 	for _, numberStr := range numbersRegex.FindAllStringSubmatch(numbersStr, -1) {
-		if len(numberStr) != 2 {
-			log.Fatalf("Unable to parse number '%s', getting results length of %d", numbersStr, len(numberStr))
-		}
-		numberInt, errParsingNumber := strconv.ParseInt(numberStr[1], 10, 64)
+		numberInt, errParsingNumber := aoc.ParseInt(numberStr[1], 64)
 		if errParsingNumber != nil {
-			log.Fatalf("Unable to parse number '%s': %v", numberStr[1], errParsingNumber)
+			return nil, errParsingNumber
 		}
 		numbers = append(numbers, numberInt)
 	}
-	return numbers
+	return numbers, nil
 }
 
 var seedsLineRegex = regexp.MustCompile(`^seeds: (.*)$`)
 
-func parseSeeds(line string) []Range {
+func parseSeeds(line string) (intervals.IntervalSet, error) {
 	results := seedsLineRegex.FindStringSubmatch(line)
 	if len(results) != 2 {
-		log.Fatalf("Unable to parse seeds line '%s', getting results length of %d", line, len(results))
+		return intervals.IntervalSet{}, aoc.Unexpected(line, "'seeds: <numbers>'")
+	}
+	numbers, errParsingNumbers := numbersStrToInts(results[1])
+	if errParsingNumbers != nil {
+		return intervals.IntervalSet{}, errParsingNumbers
 	}
-	numbers := numbersStrToInts(results[1])
 	if len(numbers)%2 != 0 {
-		log.Fatalf("Unable to parse seeds line '%s', getting unexpected length of %d after parsing", line, len(numbers))
+		return intervals.IntervalSet{}, aoc.Unexpected(results[1], "pairs of seed start and length")
 	}
-	var seeds []Range
+	var seeds []intervals.Interval
 	for i := 0; i < len(numbers); i += 2 {
-		seeds = append(seeds, Range{
-			from: numbers[i],
-			len:  numbers[i+1],
-		})
+		seeds = append(seeds, intervals.WithLength(numbers[i], numbers[i+1]))
 	}
-	return seeds
+	return intervals.NewSet(seeds...), nil
 }
 
 var mappingLineRegex = regexp.MustCompile(`^([^-]*)-to-([^ ]*) map:$`)
 
-func parseMapping(line string) (string, string) {
+func parseMapping(line string) (string, string, error) {
 	results := mappingLineRegex.FindStringSubmatch(line)
 	if len(results) != 3 {
-		log.Fatalf("Unable to parse mapping line '%s', getting results length of %d", line, len(results))
+		return "", "", aoc.Unexpected(line, "'<from>-to-<to> map:'")
 	}
-	return results[1], results[2]
+	return results[1], results[2], nil
 }
 
-func parseInput(input io.Reader) ([]Range, *Map) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (intervals.IntervalSet, []Map, error) {
+	scanner := aoc.NewScanner(input)
 
 	// Seeds
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the first line of the input correctly: %v", scanner.Err())
+		return intervals.IntervalSet{}, nil, scanner.Missing("'seeds: <numbers>'")
+	}
+	seeds, errParsingSeeds := parseSeeds(scanner.Text())
+	if errParsingSeeds != nil {
+		return intervals.IntervalSet{}, nil, scanner.Wrap(errParsingSeeds)
 	}
-	seeds := parseSeeds(scanner.Text())
 
 	scanner.Scan() // Empty line
 
 	// Mapping
-	var firstMap *Map
-	var lastMap *Map
+	var maps []Map
 	for scanner.Scan() {
-		from, to := parseMapping(scanner.Text())
-		newMap := &Map{
-			from: from,
-			to:   to,
-		}
-		if firstMap == nil {
-			firstMap = newMap
-		}
-		if lastMap != nil {
-			lastMap.nextMap = newMap
+		from, to, errParsingMapping := parseMapping(scanner.Text())
+		if errParsingMapping != nil {
+			return intervals.IntervalSet{}, nil, scanner.Wrap(errParsingMapping)
 		}
+		var pieces []intervals.Piece
 		for scanner.Scan() {
 			mappingRangeStr := scanner.Text()
 			if mappingRangeStr == "" {
 				break
 			}
-			mappingRange := numbersStrToInts(mappingRangeStr)
-			if len(mappingRange) != 3 {
-				log.Fatalf("Unable to parse mapping range '%s', getting results length of %d", mappingRangeStr, len(mappingRange))
+			mappingRange, errParsingRange := numbersStrToInts(mappingRangeStr)
+			if errParsingRange != nil {
+				return intervals.IntervalSet{}, nil, scanner.Wrap(errParsingRange)
 			}
-			newRangeMap := RangeMap{
-				from: mappingRange[1],
-				to:   mappingRange[0],
-				len:  mappingRange[2],
+			if len(mappingRange) != 3 {
+				return intervals.IntervalSet{}, nil, scanner.Unexpected("'<destination> <source> <length>'")
 			}
-			newMap.mapping = append(newMap.mapping, newRangeMap)
+			pieces = append(pieces, intervals.Piece{
+				Source: intervals.WithLength(mappingRange[1], mappingRange[2]),
+				Offset: mappingRange[0] - mappingRange[1],
+			})
+		}
+		mapping, errMapping := intervals.NewPiecewiseMap(pieces...)
+		if errMapping != nil {
+			return intervals.IntervalSet{}, nil, fmt.Errorf("%s-to-%s map: %w", from, to, errMapping)
 		}
-		sort.Slice(newMap.mapping, func(i, j int) bool { return newMap.mapping[i].from < newMap.mapping[j].from })
-		lastMap = newMap
+		maps = append(maps, Map{from: from, to: to, mapping: mapping})
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input correctly: %v", errScanningFile)
+		return intervals.IntervalSet{}, nil, errScanningFile
+	}
+	if len(maps) == 0 {
+		return intervals.IntervalSet{}, nil, scanner.Missing("'<from>-to-<to> map:'")
 	}
 
-	return seeds, firstMap
+	return seeds, maps, nil
 }
 
-func getLowestLocation(input io.Reader) int64 {
-	seeds, firstMap := parseInput(input)
-
-	lowestLocation := int64(-1)
-	for _, seedRange := range seeds {
-		for seed := seedRange.from; seed < seedRange.from+seedRange.len; seed++ {
-			currentMap := firstMap
-			currentIdx := seed
-			for {
-				for _, mapping := range currentMap.mapping {
-					if currentIdx >= mapping.from && currentIdx < mapping.from+mapping.len {
-						currentIdx = (currentIdx - mapping.from) + mapping.to
-						break
-					}
-				}
-				if currentMap.nextMap != nil {
-					currentMap = currentMap.nextMap
-				} else {
-					break
-				}
-			}
-			if lowestLocation == -1 || currentIdx < lowestLocation {
-				lowestLocation = currentIdx
-			}
-		}
+func getLowestLocation(input io.Reader) (int64, error) {
+	seeds, maps, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
 
-	return lowestLocation
-}
+	// Chaining the maps gives the location of every seed at once
+	var seedToLocation intervals.PiecewiseMap
+	for _, m := range maps {
+		seedToLocation = seedToLocation.Then(m.mapping)
+	}
 
-func main() {
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
+	lowestLocation, found := seedToLocation.Image(seeds).Min()
+	if !found {
+		return -1, nil
 	}
-	defer inputFile.Close()
+	return lowestLocation, nil
+}
 
-	lowestLocation := getLowestLocation(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   5,
+	Title: "If You Give A Seed A Fertilizer",
+	Input: aoc.LinesMatching(`seeds:( \d+)+||[a-z]+-to-[a-z]+ map:|\d+ \d+ \d+`, "the seeds, a map name, a mapping or an empty line"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getLowestLocation(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final lowest location: %d", lowestLocation)
+func init() {
+	aoc.Register(Solver)
 }
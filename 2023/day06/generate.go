@@ -0,0 +1,27 @@
+package day06
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// generateInput writes a sheet of size races at most, whose time and record
+// distance columns are read as a single race. Some records can't be beaten.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	races := 1 + rng.Intn(size)
+	var times, distances strings.Builder
+	times.WriteString("Time:    ")
+	distances.WriteString("Distance:")
+	for i := 0; i < races; i++ {
+		t := 1 + rng.Intn(40)
+		time, distance := strconv.Itoa(t), strconv.Itoa(rng.Intn(t*t/4+3))
+		width := max(len(time), len(distance)) + 2
+		fmt.Fprintf(&times, "%*s", width, time)
+		fmt.Fprintf(&distances, "%*s", width, distance)
+	}
+	_, errWriting := fmt.Fprintf(w, "%s\n%s\n", times.String(), distances.String())
+	return errWriting
+}
@@ -1,50 +1,53 @@
-package main
+package day06
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
-	"log"
-	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func numberStrToInt(numberStr string) int64 {
-	numberWithoutSpaces := strings.ReplaceAll(numberStr, " ", "")
-	numberInt, errParsingNumber := strconv.ParseInt(strings.ReplaceAll(numberWithoutSpaces, " ", ""), 10, 64)
+func numberStrToInt(numberStr string) (int64, error) {
+	numberInt, errParsingNumber := aoc.ParseInt(strings.ReplaceAll(numberStr, " ", ""), 64)
 	if errParsingNumber != nil {
-		log.Fatalf("Unable to parse number '%s': %v", numberWithoutSpaces, errParsingNumber)
+		return 0, aoc.Unexpected(strings.TrimSpace(numberStr), "a number split by spaces")
 	}
-	return numberInt
+	return numberInt, nil
 }
 
-func parseInput(input io.Reader) (int64, int64) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (int64, int64, error) {
+	scanner := aoc.NewScanner(input)
 
-	// Seeds
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the first line of the input correctly: %v", scanner.Err())
+		return 0, 0, scanner.Missing("'Time: <numbers>'")
 	}
 	timeStr := scanner.Text()
 	timeStrSplit := strings.Split(timeStr, ":")
 	if len(timeStrSplit) != 2 {
-		log.Fatalf("Unable to parse the first line of the input correctly after the split: %s", timeStr)
+		return 0, 0, scanner.Unexpected("'Time: <numbers>'")
+	}
+	t, errParsingTime := numberStrToInt(timeStrSplit[1])
+	if errParsingTime != nil {
+		return 0, 0, scanner.Wrap(errParsingTime)
 	}
-	t := numberStrToInt(timeStrSplit[1])
 
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the second line of the input correctly: %v", scanner.Err())
+		return 0, 0, scanner.Missing("'Distance: <numbers>'")
 	}
 
 	distanceStr := scanner.Text()
 	distanceStrSplit := strings.Split(distanceStr, ":")
 	if len(distanceStrSplit) != 2 {
-		log.Fatalf("Unable to parse the second line of the input correctly after the split: %s", distanceStr)
+		return 0, 0, scanner.Unexpected("'Distance: <numbers>'")
+	}
+	distance, errParsingDistance := numberStrToInt(distanceStrSplit[1])
+	if errParsingDistance != nil {
+		return 0, 0, scanner.Wrap(errParsingDistance)
 	}
-	distance := numberStrToInt(distanceStrSplit[1])
 
-	return t, distance
+	return t, distance, nil
 }
 
 func getDistanceTraveledForTime(timeHolding int64, totalTime int64) int64 {
@@ -69,25 +72,66 @@ func getMaximumTimeHoldingForDistance(distance int64, totalTime int64) int64 {
 	return -1
 }
 
-func getResult(input io.Reader) int64 {
-	t, d := parseInput(input)
-	maximumTimeHolding := getMaximumTimeHoldingForDistance(d, t)
+func getResult(input io.Reader) (int64, error) {
+	t, d, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	minimumTimeHolding := getMinimumTimeHoldingForDistance(d, t)
+	if minimumTimeHolding < 0 {
+		return 0, nil
+	}
+	maximumTimeHolding := getMaximumTimeHoldingForDistance(d, t)
 	possibilities := maximumTimeHolding - minimumTimeHolding + 1
 
-	return possibilities
+	return possibilities, nil
 }
 
-func main() {
-	start := time.Now()
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
+// getResultNaive counts every holding time beating the record, as the
+// reference of getResult.
+func getResultNaive(input io.Reader) (int64, error) {
+	t, d, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
-	defer inputFile.Close()
+	var possibilities int64
+	for timeHolding := int64(0); timeHolding <= t; timeHolding++ {
+		if getDistanceTraveledForTime(timeHolding, t) > d {
+			possibilities++
+		}
+	}
+	return possibilities, nil
+}
+
+var (
+	timeLineSpec     = aoc.LinesMatching(`Time:[ \d]*\d[ \d]*`, "'Time: <numbers>'")
+	distanceLineSpec = aoc.LinesMatching(`Distance:[ \d]*\d[ \d]*`, "'Distance: <numbers>'")
+)
 
-	result := getResult(inputFile)
+// inputSpec requires a line of times followed by a line of distances.
+var inputSpec = aoc.InputSpecFunc(func(lines []string, first int) []*aoc.ParseError {
+	if len(lines) != 2 {
+		return []*aoc.ParseError{{Line: first, Expected: fmt.Sprintf("2 lines, got %d", len(lines))}}
+	}
+	return append(timeLineSpec.Check(lines[:1], first), distanceLineSpec.Check(lines[1:], first+1)...)
+})
+
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   6,
+	Title: "Wait For It",
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+	Oracle2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultNaive(input)
+		return aoc.Int(result), err
+	},
+	Input:    inputSpec,
+	Generate: generateInput,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,17 +1,19 @@
-package main
+package day06
 
 import (
-	"strings"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-func TestGetSumOfWinningCards(t *testing.T) {
-	input := `Time:      7  15   30
-Distance:  9  40  200
-`
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
+
+func TestDifferential(t *testing.T) {
+	aoctest.Differential(t, Solver, nil, 1, 2, 3)
+}
 
-	result := getResult(strings.NewReader(input))
-	if result != 71503 {
-		t.Errorf("Expected result to be 71503, got %d", result)
-	}
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
 }
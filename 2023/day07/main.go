@@ -1,14 +1,15 @@
-package main
+package day07
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"sort"
 	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Card = int8
@@ -58,8 +59,7 @@ func CardString(card Card) string {
 	case CardA:
 		return "A"
 	default:
-		log.Fatalf("Invalid card: %d", card)
-		return "Invalid"
+		return "?"
 	}
 }
 
@@ -92,7 +92,6 @@ func CombinationString(combo Combination) string {
 	case CombinationHighCard:
 		return "HighCard"
 	default:
-		log.Fatalf("Invalid combination: %d", combo)
 		return "Invalid"
 	}
 }
@@ -115,7 +114,12 @@ func (h Hand) Score() int64 {
 	return int64(h.combo)*10000000000 + int64(h.cards[0])*100000000 + int64(h.cards[1])*1000000 + int64(h.cards[2])*10000 + int64(h.cards[3])*100 + int64(h.cards[4])
 }
 
-func getCardsCombination(cards []Card) Combination {
+var (
+	errInvalidHand = errors.New("a hand has 5 cards")
+	errTiedHands   = errors.New("two hands have the same cards")
+)
+
+func getCardsCombination(cards []Card) (Combination, error) {
 	cardsCount := make(map[Card]int8)
 	for _, card := range cards {
 		cardsCount[card]++
@@ -128,13 +132,13 @@ func getCardsCombination(cards []Card) Combination {
 			continue
 		}
 		if count == 5 {
-			return CombinationFiveOfAKind
+			return CombinationFiveOfAKind, nil
 		}
 		if count == 4 {
 			if nbJokers > 0 {
-				return CombinationFiveOfAKind
+				return CombinationFiveOfAKind, nil
 			}
-			return CombinationFourOfAKind
+			return CombinationFourOfAKind, nil
 		}
 		if count == 3 {
 			threeIdenticalCards = true
@@ -145,153 +149,155 @@ func getCardsCombination(cards []Card) Combination {
 	}
 	if nbJokers == 0 {
 		if threeIdenticalCards && twoIdenticalCards > 0 {
-			return CombinationFullHouse
+			return CombinationFullHouse, nil
 		} else if threeIdenticalCards {
-			return CombinationThreeOfAKind
+			return CombinationThreeOfAKind, nil
 		} else if twoIdenticalCards == 2 {
-			return CombinationTwoPair
+			return CombinationTwoPair, nil
 		} else if twoIdenticalCards == 1 {
-			return CombinationOnePair
+			return CombinationOnePair, nil
 		} else if twoIdenticalCards == 0 {
-			return CombinationHighCard
-		} else {
-			log.Fatalf("Invalid combination with 0 jokers: %v", cardsCount)
+			return CombinationHighCard, nil
 		}
 	} else if nbJokers == 1 {
 		if threeIdenticalCards {
-			return CombinationFourOfAKind
+			return CombinationFourOfAKind, nil
 		} else if twoIdenticalCards == 2 {
-			return CombinationFullHouse
+			return CombinationFullHouse, nil
 		} else if twoIdenticalCards == 1 {
-			return CombinationThreeOfAKind
+			return CombinationThreeOfAKind, nil
 		} else if twoIdenticalCards == 0 {
-			return CombinationOnePair
-		} else {
-			log.Fatalf("Invalid combination with 1 jokers: %v", cardsCount)
+			return CombinationOnePair, nil
 		}
 	} else if nbJokers == 2 {
 		if threeIdenticalCards {
-			return CombinationFiveOfAKind
-		} else if twoIdenticalCards == 2 {
-			log.Fatalf("Invalid combination with 2 jokers: %v", cardsCount)
+			return CombinationFiveOfAKind, nil
 		} else if twoIdenticalCards == 1 {
-			return CombinationFourOfAKind
+			return CombinationFourOfAKind, nil
 		} else if twoIdenticalCards == 0 {
-			return CombinationThreeOfAKind
-		} else {
-			log.Fatalf("Invalid combination with 2 jokers: %v", cardsCount)
+			return CombinationThreeOfAKind, nil
 		}
 	} else if nbJokers == 3 {
 		if twoIdenticalCards == 1 {
-			return CombinationFiveOfAKind
+			return CombinationFiveOfAKind, nil
 		} else {
-			return CombinationFourOfAKind
+			return CombinationFourOfAKind, nil
 		}
 	} else if nbJokers == 4 || nbJokers == 5 {
-		return CombinationFiveOfAKind
-	}
-
-	log.Fatalf("Invalid combination: %v", cardsCount)
-	return -1
-}
-
-func parseNumber(numberStr string) int64 {
-	number, errParsing := strconv.ParseInt(numberStr, 10, 64)
-	if errParsing != nil {
-		log.Fatalf("Unable to parse number: %s", numberStr)
+		return CombinationFiveOfAKind, nil
 	}
-	return number
+	return 0, errInvalidHand
 }
 
-func parseCard(cardStr rune) Card {
+func parseCard(cardStr rune) (Card, error) {
 	switch cardStr {
 	case '2':
-		return Card2
+		return Card2, nil
 	case '3':
-		return Card3
+		return Card3, nil
 	case '4':
-		return Card4
+		return Card4, nil
 	case '5':
-		return Card5
+		return Card5, nil
 	case '6':
-		return Card6
+		return Card6, nil
 	case '7':
-		return Card7
+		return Card7, nil
 	case '8':
-		return Card8
+		return Card8, nil
 	case '9':
-		return Card9
+		return Card9, nil
 	case 'T':
-		return CardT
+		return CardT, nil
 	case 'J':
-		return CardJ
+		return CardJ, nil
 	case 'Q':
-		return CardQ
+		return CardQ, nil
 	case 'K':
-		return CardK
+		return CardK, nil
 	case 'A':
-		return CardA
-	default:
-		log.Fatalf("Unable to parse card: %v", cardStr)
+		return CardA, nil
 	}
-	return -1
+	return -1, aoc.Unexpected(string(cardStr), "a card among 23456789TJQKA")
 }
 
 var handRegex = regexp.MustCompile(`([2-9TJQKA]{5}) ([0-9]+)`)
 
-func parseHand(handStr string) Hand {
+func parseHand(handStr string) (Hand, error) {
 	matches := handRegex.FindStringSubmatch(handStr)
 	if len(matches) != 3 {
-		log.Fatalf("Unable to parse hand: %s", handStr)
+		return Hand{}, aoc.Unexpected(handStr, "'<5 cards> <bid>'")
 	}
 	hand := Hand{
 		cards: make([]Card, 5),
 	}
 	cards := matches[1]
 	for i, cardStr := range cards {
-		hand.cards[i] = parseCard(cardStr)
+		card, errParsingCard := parseCard(cardStr)
+		if errParsingCard != nil {
+			return Hand{}, errParsingCard
+		}
+		hand.cards[i] = card
+	}
+	bid, errParsingBid := aoc.ParseInt(matches[2], 64)
+	if errParsingBid != nil {
+		return Hand{}, errParsingBid
+	}
+	hand.Bid = bid
+	combo, errCombining := getCardsCombination(hand.cards)
+	if errCombining != nil {
+		return Hand{}, errCombining
 	}
-	hand.Bid = parseNumber(matches[2])
-	hand.combo = getCardsCombination(hand.cards)
-	return hand
+	hand.combo = combo
+	return hand, nil
 }
 
-func parseInput(input io.Reader) []Hand {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Hand, error) {
+	scanner := aoc.NewScanner(input)
 	var hands []Hand
 	for scanner.Scan() {
 		line := scanner.Text()
-		hands = append(hands, parseHand(line))
+		hand, errParsing := parseHand(line)
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		hands = append(hands, hand)
 	}
-	return hands
+	return hands, scanner.Err()
 }
 
-func getResult(input io.Reader) int64 {
-	hands := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	hands, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	sort.SliceStable(hands, func(i, j int) bool {
-		if hands[i].Score() == hands[j].Score() {
-			log.Fatalf("Two hands with the same score: %s / %s", hands[i].String(), hands[j].String())
-		}
 		return hands[i].Score() < hands[j].Score()
 	})
+	for i := 1; i < len(hands); i++ {
+		if hands[i].Score() == hands[i-1].Score() {
+			return 0, fmt.Errorf("%w: %s / %s", errTiedHands, hands[i-1], hands[i])
+		}
+	}
 	var results int64
 	for i, hand := range hands {
 		//log.Printf("Hand %d: %d / %s", i+1, hand.Score(), hand.String())
 		results += hand.Bid * int64(i+1)
 	}
-	return results
+	return results, nil
 }
 
-func main() {
-	start := time.Now()
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
-	}
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   7,
+	Title: "Camel Cards",
+	Input: aoc.LinesMatching(`[2-9TJQKA]{5} \d+`, "'<5 cards> <bid>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,19 +1,15 @@
-package main
+package day07
 
 import (
-	"strings"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-func TestGetSumOfWinningCards(t *testing.T) {
-	input := `32T3K 765
-T55J5 684
-KK677 28
-KTJJT 220
-QQQJA 483`
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
 
-	result := getResult(strings.NewReader(input))
-	if result != 5905 {
-		t.Errorf("Expected result to be 5905, got %d", result)
-	}
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
 }
@@ -1,32 +1,40 @@
-package main
+package day08
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/numtheory"
 )
 
 type GoRight = bool
 
-func parseDirections(directionStr string) []GoRight {
+func parseDirections(directionStr string) ([]GoRight, error) {
+	if directionStr == "" {
+		return nil, aoc.Unexpected(directionStr, "L and R directions")
+	}
 	directions := make([]GoRight, len(directionStr))
 	for i, direction := range directionStr {
+		if direction != 'L' && direction != 'R' {
+			return nil, &aoc.ParseError{Column: i + 1, Text: string(direction), Expected: "L or R"}
+		}
 		directions[i] = direction == 'R'
 	}
-	return directions
+	return directions, nil
 }
 
-var nodeRegex = regexp.MustCompile(`^([1-9A-Z]+) = \(([1-9A-Z]+), ([1-9A-Z]+)\)$`)
+var nodeRegex = regexp.MustCompile(`^([1-9A-Z]{3}) = \(([1-9A-Z]{3}), ([1-9A-Z]{3})\)$`)
 
-func parseNodes(nodesStr string) (string, string, string) {
+func parseNodes(nodesStr string) (string, string, string, error) {
 	matches := nodeRegex.FindStringSubmatch(nodesStr)
 	if len(matches) != 4 {
-		log.Fatalf("Invalid node string: %s", nodesStr)
+		return "", "", "", aoc.Unexpected(nodesStr, "'<node> = (<left>, <right>)'")
 	}
-	return matches[1], matches[2], matches[3]
+	return matches[1], matches[2], matches[3], nil
 }
 
 type Node struct {
@@ -36,21 +44,41 @@ type Node struct {
 	right      *Node
 }
 
-func parseInput(input io.Reader) ([]GoRight, map[string]*Node, []string) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]GoRight, map[string]*Node, []string, error) {
+	scanner := aoc.NewScanner(input)
 	if !scanner.Scan() {
-		log.Fatalf("Unable to read first line")
+		return nil, nil, nil, scanner.Missing("directions")
+	}
+	directions, errParsingDirections := parseDirections(scanner.Text())
+	if errParsingDirections != nil {
+		return nil, nil, nil, scanner.Wrap(errParsingDirections)
 	}
-	directions := parseDirections(scanner.Text())
 	if !scanner.Scan() {
-		log.Fatalf("Unable to read second line")
+		return nil, nil, nil, scanner.Missing("an empty line")
 	}
 	nodes := make(map[string]*Node)
+	// undefined are the nodes referenced before their own line, located at
+	// their first reference.
+	undefined := make(map[string]*aoc.ParseError)
+	reference := func(name string, column int) {
+		if _, found := nodes[name]; !found {
+			undefined[name] = &aoc.ParseError{Line: scanner.Line(), Column: column, Text: name, Expected: "a node defined on its own line"}
+		}
+	}
 	var startingNodes []string
 	for scanner.Scan() {
-		name, leftName, rightName := parseNodes(scanner.Text())
+		name, leftName, rightName, errParsingNodes := parseNodes(scanner.Text())
+		if errParsingNodes != nil {
+			return nil, nil, nil, scanner.Wrap(errParsingNodes)
+		}
 		prefixName := name[0:2]
 		endingName := name[2:]
+		if _, found := nodes[name]; found && undefined[name] == nil {
+			return nil, nil, nil, scanner.Wrap(aoc.Unexpected(name, "a node defined once"))
+		}
+		delete(undefined, name)
+		reference(leftName, len("AAA = (")+1)
+		reference(rightName, len("AAA = (BBB, ")+1)
 		currentNode, currentNodeFound := nodes[name]
 		if !currentNodeFound {
 			currentNode = &Node{prefixName: prefixName, endingName: endingName}
@@ -76,72 +104,89 @@ func parseInput(input io.Reader) ([]GoRight, map[string]*Node, []string) {
 		currentNode.left = nodes[leftName]
 		currentNode.right = nodes[rightName]
 	}
-	return directions, nodes, startingNodes
-}
-
-type Track struct {
-	currentNode   *Node
-	requireNbStep *int64
-}
-
-func greatestCommonDivisor(a, b int64) int64 {
-	for b != 0 {
-		t := b
-		b = a % b
-		a = t
+	if errScanning := scanner.Err(); errScanning != nil {
+		return nil, nil, nil, errScanning
+	}
+	var firstUndefined *aoc.ParseError
+	for _, err := range undefined {
+		if firstUndefined == nil || err.Line < firstUndefined.Line || err.Line == firstUndefined.Line && err.Column < firstUndefined.Column {
+			firstUndefined = err
+		}
+	}
+	if firstUndefined != nil {
+		return nil, nil, nil, firstUndefined
 	}
-	return a
+	return directions, nodes, startingNodes, nil
 }
 
-func leastCommonMultiple(a, b int64) int64 {
-	return a * b / greatestCommonDivisor(a, b)
-}
+var (
+	errNeverArrives = errors.New("a track never arrives on a node ending with Z")
+	errNoStart      = errors.New("no node ending with A to start from")
+)
 
-func getResult(input io.Reader) int64 {
-	directions, nodes, startingNodes := parseInput(input)
-	tracksNotCompleted := make([]*Node, len(startingNodes))
-	for i, startingNode := range startingNodes {
-		if node, ok := nodes[startingNode]; !ok {
-			log.Fatalf("Unable to find starting node %s", startingNode)
+// arrivals follows the directions from a node until it has arrived twice on a
+// node ending with Z, and returns the steps of these two arrivals.
+func arrivals(directions []GoRight, node *Node, nbNodes int) (int64, int64, error) {
+	var found []int64
+	limit := int64(2 * len(directions) * nbNodes)
+	for step := int64(0); step < limit; step++ {
+		if directions[step%int64(len(directions))] {
+			node = node.right
 		} else {
-			tracksNotCompleted[i] = node
+			node = node.left
 		}
-	}
-	var minRequiredSteps []int64
-	var step int64
-	for step = 0; len(tracksNotCompleted) > 0; step++ {
-		goRight := directions[step%int64(len(directions))]
-		for i := 0; i < len(tracksNotCompleted); {
-			if goRight {
-				tracksNotCompleted[i] = tracksNotCompleted[i].right
-			} else {
-				tracksNotCompleted[i] = tracksNotCompleted[i].left
-			}
-			if tracksNotCompleted[i].endingName == "Z" {
-				minRequiredSteps = append(minRequiredSteps, step+1)
-				tracksNotCompleted = append(tracksNotCompleted[:i], tracksNotCompleted[i+1:]...)
-				continue
+		if node.endingName == "Z" {
+			found = append(found, step+1)
+			if len(found) == 2 {
+				return found[0], found[1], nil
 			}
-			i++
 		}
 	}
-	minRequiredStep := int64(1)
-	for _, steps := range minRequiredSteps {
-		minRequiredStep = leastCommonMultiple(minRequiredStep, steps)
-	}
-	return minRequiredStep
+	return 0, 0, errNeverArrives
 }
 
-func main() {
-	start := time.Now()
-	inputFile, errOpeningFile := os.Open("./input.txt")
-	if errOpeningFile != nil {
-		log.Fatalf("Unable to open input file: %v", errOpeningFile)
+func getResult(input io.Reader) (int64, error) {
+	directions, nodes, startingNodes, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
-	defer inputFile.Close()
+	if len(startingNodes) == 0 {
+		return 0, errNoStart
+	}
+	// Each track arrives on its node ending with Z periodically, so the steps
+	// where all the tracks have arrived solve a system of congruences.
+	var congruences []numtheory.Congruence
+	var lastFirstArrival int64
+	for _, startingNode := range startingNodes {
+		first, second, errWalking := arrivals(directions, nodes[startingNode], len(nodes))
+		if errWalking != nil {
+			return 0, fmt.Errorf("from %s: %w", startingNode, errWalking)
+		}
+		congruences = append(congruences, numtheory.Congruence{Residue: first, Modulus: second - first})
+		lastFirstArrival = max(lastFirstArrival, first)
+	}
+	solution, errSolving := numtheory.CRT(congruences...)
+	if errSolving != nil {
+		return 0, errSolving
+	}
+	// The arrivals only repeat after the first one of every track.
+	return solution.AtLeast(lastFirstArrival), nil
+}
 
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   8,
+	Title: "Haunted Wasteland",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`[LR]+`, "L and R directions"),
+		aoc.LinesMatching(`[1-9A-Z]{3} = \([1-9A-Z]{3}, [1-9A-Z]{3}\)`, "'<node> = (<left>, <right>)'"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
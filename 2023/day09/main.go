@@ -1,45 +1,48 @@
-package main
+package day09
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 var numbersRegex = regexp.MustCompile(`\s*(-?\d*)\s*`)
 
-func numbersStrToInts(numbersStr string) []int64 {
+func numbersStrToInts(numbersStr string) ([]int64, error) {
 	var numbers []int64
 	for _, numberStr := range numbersRegex.FindAllStringSubmatch(numbersStr, -1) {
-		if len(numberStr) != 2 {
-			log.Fatalf("Unable to parse number '%s', getting results length of %d", numbersStr, len(numberStr))
-		}
-		numberInt, errParsingNumber := strconv.ParseInt(numberStr[1], 10, 64)
+		numberInt, errParsingNumber := aoc.ParseInt(numberStr[1], 64)
 		if errParsingNumber != nil {
-			log.Fatalf("Unable to parse number '%s': %v", numberStr[1], errParsingNumber)
+			return nil, errParsingNumber
 		}
 		numbers = append(numbers, numberInt)
 	}
-	return numbers
+	return numbers, nil
 }
 
-func parseInput(input io.Reader) [][]int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([][]int64, error) {
+	scanner := aoc.NewScanner(input)
 	var linesNumbers [][]int64
 	for scanner.Scan() {
-		linesNumbers = append(linesNumbers, numbersStrToInts(scanner.Text()))
+		if scanner.Text() == "" {
+			return nil, scanner.Unexpected("a history of numbers")
+		}
+		numbers, errParsing := numbersStrToInts(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		linesNumbers = append(linesNumbers, numbers)
 	}
-	return linesNumbers
+	return linesNumbers, scanner.Err()
 }
 
 func derivative(numbers []int64) ([]int64, bool) {
 	if len(numbers) == 0 {
-		log.Fatalf("Unable to calculate derivative of empty numbers")
-		return []int64{}, false
+		return nil, true
 	}
 	onlyZeros := true
 	derivativeNumbers := make([]int64, len(numbers)-1)
@@ -54,7 +57,6 @@ func derivative(numbers []int64) ([]int64, bool) {
 
 func extrapolateForward(numbers []int64) int64 {
 	if len(numbers) == 0 {
-		log.Fatalf("Unable to extrapolate empty numbers")
 		return 0
 	}
 	derivativeNumbers, onlyZeros := derivative(numbers)
@@ -67,7 +69,6 @@ func extrapolateForward(numbers []int64) int64 {
 
 func extrapolateBackward(numbers []int64) int64 {
 	if len(numbers) == 0 {
-		log.Fatalf("Unable to extrapolate empty numbers")
 		return 0
 	}
 	derivativeNumbers, onlyZeros := derivative(numbers)
@@ -78,20 +79,28 @@ func extrapolateBackward(numbers []int64) int64 {
 	}
 }
 
-func getResultPart1(input io.Reader) int64 {
+func getResultPart1(input io.Reader) (int64, error) {
+	linesNumbers, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var result int64
-	for _, numbers := range parseInput(input) {
+	for _, numbers := range linesNumbers {
 		result += extrapolateForward(numbers)
 	}
-	return result
+	return result, nil
 }
 
-func getResultPart2(input io.Reader) int64 {
+func getResultPart2(input io.Reader) (int64, error) {
+	linesNumbers, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var result int64
-	for _, numbers := range parseInput(input) {
+	for _, numbers := range linesNumbers {
 		result += extrapolateBackward(numbers)
 	}
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -102,13 +111,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultPart2(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   9,
+	Title: "Mirage Maintenance",
+	Input: aoc.LinesMatching(`-?\d+( -?\d+)*`, "a history of numbers"),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart1(input)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart2(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,45 +1,22 @@
-package main
+package day09
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
-
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		input := `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45
-`
 
-		result := getResultPart1(strings.NewReader(input))
-		if result != 114 {
-			t.Errorf("Expected result to be 114, got %d", result)
-		}
-	})
-	t.Run("part2", func(t *testing.T) {
-		input := `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-		result := getResultPart2(strings.NewReader(input))
-		if result != 2 {
-			t.Errorf("Expected result to be 2, got %d", result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("part1", func(b *testing.B) {
 		b.Run("small", func(b *testing.B) {
-			input := `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45
-`
-
+			input := aoctest.ReadInput(b, "example")
 			for n := 0; n < b.N; n++ {
-				getResultPart1(strings.NewReader(input))
+				getResultPart1(bytes.NewReader(input))
 			}
 		})
 
@@ -54,13 +31,9 @@ func BenchmarkGetResult(b *testing.B) {
 	})
 	b.Run("part2", func(b *testing.B) {
 		b.Run("small", func(b *testing.B) {
-			input := `0 3 6 9 12 15
-1 3 6 10 15 21
-10 13 16 21 30 45
-`
-
+			input := aoctest.ReadInput(b, "example")
 			for n := 0; n < b.N; n++ {
-				getResultPart2(strings.NewReader(input))
+				getResultPart2(bytes.NewReader(input))
 			}
 		})
 
@@ -74,3 +47,7 @@ func BenchmarkGetResult(b *testing.B) {
 		})
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -0,0 +1,181 @@
+package day10
+
+import (
+	"image"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+var (
+	north = image.Pt(0, -1)
+	south = image.Pt(0, 1)
+	east  = image.Pt(1, 0)
+	west  = image.Pt(-1, 0)
+)
+
+// pipeSymbols gives the pipe joining two directions.
+var pipeSymbols = map[[2]image.Point]byte{
+	{north, south}: '|',
+	{east, west}:   '-',
+	{north, east}:  'L',
+	{north, west}:  'J',
+	{south, west}:  '7',
+	{south, east}:  'F',
+}
+
+func pipeSymbol(a, b image.Point) byte {
+	if symbol, ok := pipeSymbols[[2]image.Point{a, b}]; ok {
+		return symbol
+	}
+	return pipeSymbols[[2]image.Point{b, a}]
+}
+
+// generateInput writes a maze whose loop is the border of a random region of
+// size×size cells, the tiles being the corners of the cells. The region is
+// grown a cell at a time, keeping it without hole nor cells touching by a
+// corner only, so that its border is a single loop. The tiles out of the loop
+// are random pipes, but the ones next to the start.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	region := map[image.Point]bool{image.Pt(rng.Intn(size), rng.Intn(size)): true}
+	bounds := image.Rect(0, 0, size, size)
+	area := size*size/3 + rng.Intn(size*size/3+1)
+	for attempt := 0; attempt < 4*size*size && len(region) < area; attempt++ {
+		var candidates []image.Point
+		for cell := range region {
+			for _, direction := range []image.Point{north, south, east, west} {
+				if next := cell.Add(direction); next.In(bounds) && !region[next] {
+					candidates = append(candidates, next)
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		// The candidates come from a map, sort them for the seed to decide.
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Y != candidates[j].Y {
+				return candidates[i].Y < candidates[j].Y
+			}
+			return candidates[i].X < candidates[j].X
+		})
+		cell := candidates[rng.Intn(len(candidates))]
+		region[cell] = true
+		if hasPinch(region, cell) || hasHole(region, bounds) {
+			delete(region, cell)
+		}
+	}
+
+	// Walk the border with the region on the right, from the top left corner
+	// of its top left cell.
+	start := image.Pt(size, size)
+	for cell := range region {
+		if cell.Y < start.Y || cell.Y == start.Y && cell.X < start.X {
+			start = cell
+		}
+	}
+	var loop []image.Point
+	var directions []image.Point
+	corner, direction := start, east
+	for {
+		loop = append(loop, corner)
+		directions = append(directions, direction)
+		corner = corner.Add(direction)
+		if corner == start {
+			break
+		}
+		// The cells around the corner, ahead on the left and on the right.
+		left, right := aheadCells(corner, direction)
+		switch {
+		case region[left]:
+			direction = turnLeft(direction)
+		case !region[right]:
+			direction = turnRight(direction)
+		}
+	}
+
+	tiles := make([][]byte, size+1)
+	for y := range tiles {
+		tiles[y] = make([]byte, size+1)
+		for x := range tiles[y] {
+			tiles[y][x] = "|-LJ7F."[rng.Intn(7)]
+		}
+	}
+	for i, corner := range loop {
+		previous := directions[(i+len(loop)-1)%len(loop)]
+		tiles[corner.Y][corner.X] = pipeSymbol(previous.Mul(-1), directions[i])
+	}
+	startTile := loop[rng.Intn(len(loop))]
+	onLoop := make(map[image.Point]bool, len(loop))
+	for _, corner := range loop {
+		onLoop[corner] = true
+	}
+	for _, direction := range []image.Point{north, south, east, west} {
+		if next := startTile.Add(direction); next.In(image.Rect(0, 0, size+1, size+1)) && !onLoop[next] {
+			tiles[next.Y][next.X] = '.'
+		}
+	}
+	tiles[startTile.Y][startTile.X] = 'S'
+
+	for _, row := range tiles {
+		if _, errWriting := w.Write(append(row, '\n')); errWriting != nil {
+			return errWriting
+		}
+	}
+	return nil
+}
+
+// aheadCells returns the cells ahead of a corner reached going in the
+// direction, on the left and on the right.
+func aheadCells(corner, direction image.Point) (image.Point, image.Point) {
+	switch direction {
+	case east:
+		return corner.Add(north), corner
+	case south:
+		return corner, corner.Add(west)
+	case west:
+		return corner.Add(west), corner.Add(image.Pt(-1, -1))
+	default:
+		return corner.Add(image.Pt(-1, -1)), corner.Add(north)
+	}
+}
+
+func turnLeft(direction image.Point) image.Point {
+	return image.Pt(direction.Y, -direction.X)
+}
+
+func turnRight(direction image.Point) image.Point {
+	return image.Pt(-direction.Y, direction.X)
+}
+
+// hasPinch reports whether a cell of the region touches another one of the
+// region by a corner only, around the given cell.
+func hasPinch(region map[image.Point]bool, cell image.Point) bool {
+	for _, diagonal := range []image.Point{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} {
+		if region[cell.Add(diagonal)] &&
+			!region[cell.Add(image.Pt(diagonal.X, 0))] &&
+			!region[cell.Add(image.Pt(0, diagonal.Y))] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHole reports whether some cells out of the region can't reach the outside
+// of the bounds.
+func hasHole(region map[image.Point]bool, bounds image.Rectangle) bool {
+	outside := bounds.Inset(-1)
+	seen := map[image.Point]bool{outside.Min: true}
+	queue := []image.Point{outside.Min}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, direction := range []image.Point{north, south, east, west} {
+			if next := cell.Add(direction); next.In(outside) && !region[next] && !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(seen)+len(region) != outside.Dx()*outside.Dy()
+}
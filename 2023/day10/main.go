@@ -1,11 +1,14 @@
-package main
+package day10
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 const mapSize = 140
@@ -30,13 +33,16 @@ func (n *Node) isConnectedToNode(otherNode *Node) bool {
 	return false
 }
 
-func parseInput(input io.Reader) ([][]*Node, *Node) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([][]*Node, *Node, error) {
+	scanner := aoc.NewScanner(input)
 	var nodes []*Node
 	var graph [][]*Node
 	var startingNode *Node
 	for i := 0; scanner.Scan(); i++ {
 		line := scanner.Text()
+		if i > 0 && len(line) != len(graph[0]) {
+			return nil, nil, scanner.Unexpected(fmt.Sprintf("a line of %d tiles", len(graph[0])))
+		}
 		lineNodes := make([]*Node, len(line))
 		for j, symbol := range line {
 			node := &Node{
@@ -50,7 +56,7 @@ func parseInput(input io.Reader) ([][]*Node, *Node) {
 		graph = append(graph, lineNodes)
 	}
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
 	for _, node := range nodes {
@@ -100,14 +106,17 @@ func parseInput(input io.Reader) ([][]*Node, *Node) {
 				continue
 			}
 		case 'S':
+			if startingNode != nil {
+				return nil, nil, &aoc.ParseError{Line: node.i + 1, Column: node.j + 1, Text: "S", Expected: "a single starting position"}
+			}
 			startingNode = node
 		default:
-			log.Fatalf("Unknown symbol: %v", string(node.symbol))
+			return nil, nil, &aoc.ParseError{Line: node.i + 1, Column: node.j + 1, Text: string(node.symbol), Expected: "a tile among |-LJ7F.S"}
 		}
 	}
 
 	if startingNode == nil {
-		log.Fatalf("No starting node found")
+		return nil, nil, scanner.Missing("a starting position S")
 	} else {
 		fromNorth := false
 		fromSouth := false
@@ -130,7 +139,7 @@ func parseInput(input io.Reader) ([][]*Node, *Node) {
 			fromEast = true
 		}
 		if len(startingNode.connectedNodes) != 2 {
-			log.Fatalf("Starting node has %d connected nodes", len(startingNode.connectedNodes))
+			return nil, nil, &aoc.ParseError{Line: startingNode.i + 1, Column: startingNode.j + 1, Text: "S", Expected: "a starting position connected to two pipes"}
 		}
 
 		if fromNorth && fromSouth && !fromEast && !fromWest {
@@ -146,24 +155,31 @@ func parseInput(input io.Reader) ([][]*Node, *Node) {
 		} else if !fromNorth && fromSouth && fromEast && !fromWest {
 			startingNode.symbol = 'F'
 		} else {
-			log.Fatalf("Unable to determine starting node symbol: %#v", startingNode)
+			return nil, nil, &aoc.ParseError{Line: startingNode.i + 1, Column: startingNode.j + 1, Text: "S", Expected: "a starting position connected to two pipes"}
 		}
 	}
 
-	return graph, startingNode
+	return graph, startingNode, nil
 }
 
-func printGraph(graph [][]*Node) {
+func graphSnapshot(graph [][]*Node) aoc.GridSnapshot {
+	snapshot := aoc.GridSnapshot{Name: "graph"}
 	for _, line := range graph {
 		lineStr := ""
 		for _, node := range line {
 			lineStr += string(node.symbol)
 		}
-		log.Printf("%s", lineStr)
+		snapshot.Rows = append(snapshot.Rows, lineStr)
 	}
+	return snapshot
 }
 
-func navigate(startingNode *Node) int64 {
+var (
+	errBrokenLoop = errors.New("the pipes from the starting position don't form a loop")
+	errOddLoop    = errors.New("the loop has an odd number of pipes")
+)
+
+func navigate(startingNode *Node) (int64, error) {
 	prevNode := startingNode
 	currentNode := startingNode.connectedNodes[0]
 	currentNode.isLoopNode = true
@@ -173,31 +189,42 @@ func navigate(startingNode *Node) int64 {
 		for c = 0; c < len(currentNode.connectedNodes) && currentNode.connectedNodes[c] == prevNode; c++ {
 		}
 		if c == len(currentNode.connectedNodes) {
-			log.Fatalf("Unable to find next node from %v", currentNode)
+			return 0, errBrokenLoop
 		}
 		prevNode = currentNode
 		currentNode = currentNode.connectedNodes[c]
 		currentNode.isLoopNode = true
 	}
-	return step
+	return step, nil
 }
 
-func getResultPart1(input io.Reader) int64 {
-	graph, startingNode := parseInput(input)
-	printGraph(graph)
-	log.Printf("Starting node at i=%d j=%d", startingNode.i, startingNode.j)
-	step := navigate(startingNode)
+func getResultPart1(ctx context.Context, input io.Reader) (int64, error) {
+	graph, startingNode, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	aoc.Emit(ctx, graphSnapshot(graph))
+	aoc.Emit(ctx, aoc.Value{Name: "starting node", Value: fmt.Sprintf("i=%d j=%d", startingNode.i, startingNode.j)})
+	step, errNavigating := navigate(startingNode)
+	if errNavigating != nil {
+		return 0, errNavigating
+	}
 	if step%2 != 0 {
-		log.Fatalf("Step is not even: %d", step)
+		return 0, errOddLoop
 	}
-	return step / 2
+	return step / 2, nil
 }
 
-func getResultPart2(input io.Reader) int64 {
-	graph, startingNode := parseInput(input)
-	printGraph(graph)
-	log.Printf("Starting node at i=%d j=%d", startingNode.i, startingNode.j)
-	navigate(startingNode)
+func getResultPart2(ctx context.Context, input io.Reader) (int64, error) {
+	graph, startingNode, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	aoc.Emit(ctx, graphSnapshot(graph))
+	aoc.Emit(ctx, aoc.Value{Name: "starting node", Value: fmt.Sprintf("i=%d j=%d", startingNode.i, startingNode.j)})
+	if _, errNavigating := navigate(startingNode); errNavigating != nil {
+		return 0, errNavigating
+	}
 	var nodesInsideLoop int64
 	for i, line := range graph {
 		if i == 0 || i == len(graph)-1 {
@@ -218,7 +245,7 @@ func getResultPart2(input io.Reader) int64 {
 							expJ--
 						}
 						if expJ < 0 {
-							log.Fatalf("Unable to find node before J")
+							return 0, errBrokenLoop
 						}
 						if graph[i][expJ].symbol == 'F' {
 							loopIntersection++
@@ -229,7 +256,7 @@ func getResultPart2(input io.Reader) int64 {
 							expJ--
 						}
 						if expJ < 0 {
-							log.Fatalf("Unable to find node before J")
+							return 0, errBrokenLoop
 						}
 						if graph[i][expJ].symbol == 'L' {
 							loopIntersection++
@@ -242,7 +269,7 @@ func getResultPart2(input io.Reader) int64 {
 			}
 		}
 	}
-	return nodesInsideLoop
+	return nodesInsideLoop, nil
 }
 
 func loadFile() *os.File {
@@ -253,13 +280,22 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultPart2(inputFile)
+var Solver = aoc.Solver{
+	Year:     2023,
+	Day:      10,
+	Title:    "Pipe Maze",
+	Input:    aoc.Grid{Alphabet: "|-LJ7F.S", Counts: map[rune]int{'S': 1}},
+	Generate: generateInput,
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart1(ctx, input)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart2(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,12 +1,13 @@
-package main
+package day11
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"math"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Point struct {
@@ -14,8 +15,8 @@ type Point struct {
 	y int
 }
 
-func parseInput(input io.Reader) ([]Point, []int, []int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Point, []int, []int, error) {
+	scanner := aoc.NewScanner(input)
 	var galaxies []Point
 	var emptyX []int
 	var emptyYChecking []bool
@@ -26,10 +27,14 @@ func parseInput(input io.Reader) ([]Point, []int, []int) {
 			if len(emptyYChecking) <= y {
 				emptyYChecking = append(emptyYChecking, true)
 			}
-			if symbol == '#' {
+			switch symbol {
+			case '#':
 				galaxies = append(galaxies, Point{x: x, y: y})
 				isEmpty = false
 				emptyYChecking[y] = false
+			case '.':
+			default:
+				return nil, nil, nil, &aoc.ParseError{Line: scanner.Line(), Column: y + 1, Text: string(symbol), Expected: "'.' or '#'"}
 			}
 		}
 		if isEmpty {
@@ -44,10 +49,10 @@ func parseInput(input io.Reader) ([]Point, []int, []int) {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, nil, errScanningFile
 	}
 
-	return galaxies, emptyX, emptyY
+	return galaxies, emptyX, emptyY, nil
 }
 
 func getDistance(galaxyA, galaxyB Point, emptyX []int, emptyY []int, emptyFactor float64) int64 {
@@ -66,8 +71,11 @@ func getDistance(galaxyA, galaxyB Point, emptyX []int, emptyY []int, emptyFactor
 	return int64(xDistance + yDistance)
 }
 
-func getResult(input io.Reader, emptyFactor float64) int64 {
-	galaxies, emptyX, emptyY := parseInput(input)
+func getResult(input io.Reader, emptyFactor float64) (int64, error) {
+	galaxies, emptyX, emptyY, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var distances int64
 	for i, galaxyA := range galaxies {
 		for _, galaxyB := range galaxies[i:] {
@@ -77,7 +85,7 @@ func getResult(input io.Reader, emptyFactor float64) int64 {
 			distances += getDistance(galaxyA, galaxyB, emptyX, emptyY, emptyFactor)
 		}
 	}
-	return distances
+	return distances, nil
 }
 
 func loadFile() *os.File {
@@ -88,14 +96,30 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
+// getExpandedResult returns the result for the expansion factor of a part,
+// which the examples may override.
+func getExpandedResult(ctx context.Context, input io.Reader, emptyFactor int) (aoc.Answer, error) {
+	emptyFactor, errParam := aoc.ParamPositiveInt(ctx, "expansion", emptyFactor)
+	if errParam != nil {
+		return aoc.Answer{}, errParam
+	}
+	result, err := getResult(input, float64(emptyFactor))
+	return aoc.Int(result), err
+}
 
-	emptyFactor := float64(1000000)
-	result := getResult(inputFile, emptyFactor)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   11,
+	Title: "Cosmic Expansion",
+	Input: aoc.Grid{Alphabet: ".#"},
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		return getExpandedResult(ctx, input, 2)
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		return getExpandedResult(ctx, input, 1000000)
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
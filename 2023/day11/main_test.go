@@ -1,65 +1,21 @@
-package main
+package day11
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
-
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		input := `...#......
-.......#..
-#.........
-..........
-......#...
-.#........
-.........#
-..........
-.......#..
-#...#.....
-`
 
-		result := getResult(strings.NewReader(input), 2.0)
-		if result != 374 {
-			t.Errorf("Expected result to be 374, got %d", result)
-		}
-	})
-	t.Run("part2", func(t *testing.T) {
-		input := `...#......
-.......#..
-#.........
-..........
-......#...
-.#........
-.........#
-..........
-.......#..
-#...#.....
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-		result := getResult(strings.NewReader(input), 100)
-		if result != 8410 {
-			t.Errorf("Expected result to be 8410, got %d", result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
-		input := `...#......
-.......#..
-#.........
-..........
-......#...
-.#........
-.........#
-..........
-.......#..
-#...#.....
-`
-
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(input), 2.0)
+			getResult(bytes.NewReader(input), 2.0)
 		}
 	})
 
@@ -72,3 +28,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
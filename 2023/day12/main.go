@@ -1,13 +1,14 @@
-package main
+package day12
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type isSpringDamaged = *bool
@@ -17,24 +18,27 @@ type Line struct {
 	damagedSpringsCounters []int64
 }
 
-func parseInput(input io.Reader) []Line {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Line, error) {
+	scanner := aoc.NewScanner(input)
 	var lines []Line
 	for scanner.Scan() {
 		line := scanner.Text()
 		lineSplit := strings.Split(line, " ")
 		if len(lineSplit) != 2 {
-			log.Fatalf("Invalid input line: %s", line)
+			return nil, scanner.Unexpected("'<springs> <counters>'")
 		}
 
 		states := strings.Split(lineSplit[0], "")
 		initSprings := make([]isSpringDamaged, len(states))
 		for i, state := range states {
-			if state == "?" {
+			switch state {
+			case "?":
 				initSprings[i] = nil
-			} else {
+			case "#", ".":
 				isDamaged := state == "#"
 				initSprings[i] = &isDamaged
+			default:
+				return nil, &aoc.ParseError{Line: scanner.Line(), Column: i + 1, Text: state, Expected: "a spring among '.', '#' or '?'"}
 			}
 		}
 		springs := make([]isSpringDamaged, 4+(len(initSprings)*5))
@@ -46,9 +50,9 @@ func parseInput(input io.Reader) []Line {
 		numbers := strings.Split(lineSplit[1], ",")
 		initCounters := make([]int64, len(numbers))
 		for i, number := range numbers {
-			counter, errParsing := strconv.ParseInt(number, 10, 64)
+			counter, errParsing := aoc.ParseInt(number, 64)
 			if errParsing != nil {
-				log.Fatalf("Unable to parse input line provided '%s' for counter number '%s': %v", line, number, errParsing)
+				return nil, scanner.Wrap(errParsing)
 			}
 			initCounters[i] = counter
 		}
@@ -67,10 +71,10 @@ func parseInput(input io.Reader) []Line {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return lines
+	return lines, nil
 }
 
 func (l Line) String() string {
@@ -93,9 +97,9 @@ func (l Line) String() string {
 	return result.String()
 }
 
-var stateToArrangements = make(map[string]int64)
-
-func getNumberOfArrangements(line Line) int64 {
+// getNumberOfArrangements counts the arrangements of the line, caching them
+// by line state in stateToArrangements.
+func getNumberOfArrangements(line Line, stateToArrangements map[string]int64) int64 {
 	if arrangements, found := stateToArrangements[line.String()]; found {
 		return arrangements
 	}
@@ -113,7 +117,7 @@ func getNumberOfArrangements(line Line) int64 {
 	}
 	var numberOfArrangements int64
 	if i < len(isSpringsDamaged) && isSpringsDamaged[i] == nil {
-		numberOfArrangements += getNumberOfArrangements(Line{isSpringsDamaged[i+1:], damagedSpringsCounters})
+		numberOfArrangements += getNumberOfArrangements(Line{isSpringsDamaged[i+1:], damagedSpringsCounters}, stateToArrangements)
 	}
 	damagedSpringsCounter := damagedSpringsCounters[0]
 	for ; i < len(isSpringsDamaged) && damagedSpringsCounter > 0; i++ {
@@ -136,7 +140,7 @@ func getNumberOfArrangements(line Line) int64 {
 					numberOfArrangements++
 				}
 			} else if len(damagedSpringsCounters) > 1 {
-				nextArrangements := getNumberOfArrangements(Line{isSpringsDamaged[i+1:], damagedSpringsCounters[1:]})
+				nextArrangements := getNumberOfArrangements(Line{isSpringsDamaged[i+1:], damagedSpringsCounters[1:]}, stateToArrangements)
 				if nextArrangements > 0 {
 					numberOfArrangements += nextArrangements
 				}
@@ -148,14 +152,18 @@ func getNumberOfArrangements(line Line) int64 {
 	return numberOfArrangements
 }
 
-func getResult(input io.Reader) int64 {
-	lines := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	lines, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	stateToArrangements := make(map[string]int64)
 	var result int64
 	for _, line := range lines {
-		result += getNumberOfArrangements(line)
+		result += getNumberOfArrangements(line, stateToArrangements)
 		//log.Printf("------------------")
 	}
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -166,13 +174,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   12,
+	Title: "Hot Springs",
+	Input: aoc.LinesMatching(`[.#?]+ \d+(,\d+)*`, "'<springs> <counters>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,11 +1,14 @@
-package main
+package day13
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 func transpose(grid [][]int64) [][]int64 {
@@ -55,58 +58,82 @@ func getReflectionScoreForLines(grid [][]int64, maxDiff int64) int64 {
 	return 0
 }
 
-func getReflectionScore(grid [][]int64) int64 {
-	horizontalReflectionScore := getReflectionScoreForLines(grid, 1)
+func getReflectionScore(grid [][]int64, smudges int64) (int64, bool) {
+	horizontalReflectionScore := getReflectionScoreForLines(grid, smudges)
 	if horizontalReflectionScore > 0 {
-		return horizontalReflectionScore * 100
+		return horizontalReflectionScore * 100, true
 	}
-	verticalReflectionScore := getReflectionScoreForLines(transpose(grid), 1)
+	verticalReflectionScore := getReflectionScoreForLines(transpose(grid), smudges)
 	if verticalReflectionScore > 0 {
-		return verticalReflectionScore
+		return verticalReflectionScore, true
 	}
-	log.Fatalf("Unable to find a reflection score for the grid: %v", grid)
-	return 0
+	return 0, false
 }
 
-func parseInput(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader, smudges int64) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	reflectionScore := int64(0)
 	var grid [][]int64
+	gridLine, nbPatterns := 0, 0
+	addReflectionScore := func() error {
+		nbPatterns++
+		score, found := getReflectionScore(grid, smudges)
+		if !found {
+			return &aoc.ParseError{Line: gridLine, Column: 1, Expected: "a pattern with a line of reflection", Err: errors.New("no reflection found")}
+		}
+		reflectionScore += score
+		return nil
+	}
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		if line == "" {
 			if len(grid) > 0 {
-				reflectionScore += getReflectionScore(grid)
+				if errReflection := addReflectionScore(); errReflection != nil {
+					return 0, errReflection
+				}
 				grid = [][]int64{}
 			}
 			continue
 		}
 
+		if len(grid) == 0 {
+			gridLine = scanner.Line()
+		} else if len(line) != len(grid[0]) {
+			return 0, scanner.Unexpected(fmt.Sprintf("a line of %d characters", len(grid[0])))
+		}
 		var row []int64
-		for _, char := range line {
-			if char == '#' {
+		for i, char := range line {
+			switch char {
+			case '#':
 				row = append(row, 1)
-			} else {
+			case '.':
 				row = append(row, 0)
+			default:
+				return 0, &aoc.ParseError{Line: scanner.Line(), Column: i + 1, Text: string(char), Expected: "'.' or '#'"}
 			}
 		}
 		grid = append(grid, row)
 	}
 
-	if len(grid) > 0 {
-		reflectionScore += getReflectionScore(grid)
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
 	}
 
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+	if len(grid) > 0 {
+		if errReflection := addReflectionScore(); errReflection != nil {
+			return 0, errReflection
+		}
+	}
+	if nbPatterns == 0 {
+		return 0, scanner.Missing("a pattern")
 	}
 
-	return reflectionScore
+	return reflectionScore, nil
 }
 
-func getResult(input io.Reader) int64 {
-	return parseInput(input)
+func getResult(input io.Reader, smudges int64) (int64, error) {
+	return parseInput(input, smudges)
 }
 
 func loadFile() *os.File {
@@ -117,13 +144,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   13,
+	Title: "Point of Incidence",
+	Input: aoc.Blocks(aoc.Grid{Alphabet: ".#"}),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 0)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 1)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,55 +1,21 @@
-package main
+package day13
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
-
-func TestGetResults(t *testing.T) {
-	input := `#.##..##.
-..#.##.#.
-##......#
-##......#
-..#.##.#.
-..##..##.
-#.#.##.#.
 
-#...##..#
-#....#..#
-..##..###
-#####.##.
-#####.##.
-..##..###
-#....#..#
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-	result := getResult(strings.NewReader(input))
-	if result != 400 {
-		t.Errorf("Expected result to be 400, got %d", result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
-		input := `#.##..##.
-..#.##.#.
-##......#
-##......#
-..#.##.#.
-..##..##.
-#.#.##.#.
-
-#...##..#
-#....#..#
-..##..###
-#####.##.
-#####.##.
-..##..###
-#....#..#
-`
-
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(input))
+			getResult(bytes.NewReader(input), 1)
 		}
 	})
 
@@ -58,7 +24,11 @@ func BenchmarkGetResult(b *testing.B) {
 		defer inputFile.Close()
 
 		for n := 0; n < b.N; n++ {
-			getResult(inputFile)
+			getResult(inputFile, 1)
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
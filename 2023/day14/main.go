@@ -1,63 +1,62 @@
-package main
+package day14
 
 import (
-	"bufio"
+	"context"
+	"image"
 	"io"
 	"log"
 	"os"
-	"time"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/cycle"
+	"github.com/antitoine/advent-of-code/aoc/grid"
 )
 
-type Place string
+type Place = rune
 
 const (
-	Empty          Place = "."
-	CubeShapedRock Place = "#"
-	RoundedRock    Place = "O"
+	Empty          Place = '.'
+	CubeShapedRock Place = '#'
+	RoundedRock    Place = 'O'
 )
 
-type Platform [][]Place
+type Platform struct {
+	grid.Grid[Place]
+}
 
-func (p Platform) String() string {
-	var result string
-	for _, row := range p {
-		for _, place := range row {
-			result += string(place)
-		}
-		result += "\n"
-	}
-	return result
+// Snapshot returns the platform as a grid snapshot of the given name.
+func (p Platform) Snapshot(name string) aoc.GridSnapshot {
+	return aoc.GridSnapshot{Name: name, Rows: strings.Split(strings.TrimSuffix(p.String(), "\n"), "\n")}
 }
 
-func parseInput(input io.Reader) Platform {
-	scanner := bufio.NewScanner(input)
-	var platform Platform
-	for scanner.Scan() {
-		line := scanner.Text()
-		var row []Place
-		for _, char := range line {
-			row = append(row, Place(char))
+func parseInput(input io.Reader) (Platform, error) {
+	g, errParsing := grid.Parse(input, func(_ image.Point, char rune) (Place, error) {
+		if char != Empty && char != CubeShapedRock && char != RoundedRock {
+			return 0, aoc.Unexpected(string(char), "a place among '.', '#' or 'O'")
 		}
-		platform = append(platform, row)
+		return char, nil
+	})
+	if errParsing != nil {
+		return Platform{}, errParsing
 	}
-
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+	if g.Height() == 0 {
+		return Platform{}, &aoc.ParseError{Line: 1, Err: io.ErrUnexpectedEOF, Expected: "a row of places"}
 	}
-
-	return platform
+	return Platform{g}, nil
 }
 
+// tiltingTheLever rolls the rounded rocks of the platform north, in place.
 func tiltingTheLever(platform Platform) Platform {
-	for columnIdx := 0; columnIdx < len(platform[0]); columnIdx++ {
+	for x := 0; x < platform.Width(); x++ {
 		var nextBlockingRow int
-		for rowIdx := 0; rowIdx < len(platform); rowIdx++ {
-			switch platform[rowIdx][columnIdx] {
+		for y := 0; y < platform.Height(); y++ {
+			switch platform.At(image.Pt(x, y)) {
 			case CubeShapedRock:
-				nextBlockingRow = rowIdx + 1
+				nextBlockingRow = y + 1
 			case RoundedRock:
-				platform[rowIdx][columnIdx] = Empty
-				platform[nextBlockingRow][columnIdx] = RoundedRock
+				platform.Set(image.Pt(x, y), Empty)
+				platform.Set(image.Pt(x, nextBlockingRow), RoundedRock)
 				nextBlockingRow++
 			}
 		}
@@ -67,79 +66,56 @@ func tiltingTheLever(platform Platform) Platform {
 
 // rotate returns the same platform rotated 90 degrees clockwise
 func rotate(platform Platform) Platform {
-	var rotatedPlatform Platform
-	for columnIdx := 0; columnIdx < len(platform[0]); columnIdx++ {
-		var row []Place
-		for rowIdx := len(platform) - 1; rowIdx >= 0; rowIdx-- {
-			row = append(row, platform[rowIdx][columnIdx])
-		}
-		rotatedPlatform = append(rotatedPlatform, row)
-	}
-	return rotatedPlatform
+	return Platform{platform.RotateClockwise()}
 }
 
-var loadMemory = make(map[string]int)
-
 func computeLoad(platform Platform) int {
-	if load, ok := loadMemory[platform.String()]; ok {
-		return load
-	}
 	var load int
-	for rowIdx := 0; rowIdx < len(platform); rowIdx++ {
-		for _, place := range platform[rowIdx] {
-			if place == RoundedRock {
-				load += len(platform) - rowIdx
-			}
+	platform.Each(func(p image.Point, place Place) {
+		if place == RoundedRock {
+			load += platform.Height() - p.Y
 		}
-	}
-	loadMemory[platform.String()] = load
+	})
 	return load
 }
 
-var cycleMemory = make(map[string]Platform)
+func (p Platform) Clone() Platform {
+	return Platform{p.Grid.Clone()}
+}
 
-func cycle(initPlatform Platform) Platform {
-	if platform, ok := cycleMemory[initPlatform.String()]; ok {
-		return platform
-	}
-	platform := tiltingTheLever(initPlatform)    // tilt north
-	platform = tiltingTheLever(rotate(platform)) // tilt west
-	platform = tiltingTheLever(rotate(platform)) // tilt south
-	platform = tiltingTheLever(rotate(platform)) // tilt east
-	platform = rotate(platform)                  // get initial position
-	cycleMemory[initPlatform.String()] = platform
+// spinCycle returns the platform after tilting it north, west, south and
+// east, leaving the given one unchanged.
+func spinCycle(initPlatform Platform) Platform {
+	platform := tiltingTheLever(initPlatform.Clone()) // tilt north
+	platform = tiltingTheLever(rotate(platform))      // tilt west
+	platform = tiltingTheLever(rotate(platform))      // tilt south
+	platform = tiltingTheLever(rotate(platform))      // tilt east
+	platform = rotate(platform)                       // get initial position
 	return platform
 }
 
-var platformIdxMemory = make(map[string]int)
-
-func getResult(input io.Reader) int {
-	initPlatform := parseInput(input)
-
-	platform := initPlatform
-	var cycleStart int
-	var cycleEnd int
+const spinCycles = 1000000000
 
-	for i := 0; i < 1000000000; i++ {
-		platform = cycle(platform)
-
-		if existingIdx, found := platformIdxMemory[platform.String()]; found {
-			cycleStart = existingIdx
-			cycleEnd = i
-			break
-		}
-		platformIdxMemory[platform.String()] = i
+func getResult(ctx context.Context, input io.Reader) (int, error) {
+	initPlatform, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
-	cycleLength := cycleEnd - cycleStart
 
-	remainingIterations := 1000000000 - cycleEnd - 1
-	remainingCycles := remainingIterations % cycleLength
-	for i := 0; i < remainingCycles; i++ {
-		platform = cycle(platform)
+	spins := cycle.Find(initPlatform, spinCycle, Platform.String)
+	aoc.Emit(ctx, aoc.CycleDetected{Name: "spin cycles", Tail: spins.Tail, Period: spins.Period})
+	if aoc.Observing(ctx) {
+		// Every distinct platform is seen before the spins start repeating.
+		spun := initPlatform
+		for i := 0; i < spins.Tail+spins.Period; i++ {
+			aoc.Emit(ctx, spun.Snapshot("spin cycles"))
+			spun = spinCycle(spun)
+		}
 	}
+	platform := spins.At(spinCycles)
 
-	log.Printf("Final platform:\n%s", platform)
-	return computeLoad(platform)
+	aoc.Emit(ctx, platform.Snapshot("final platform"))
+	return computeLoad(platform), nil
 }
 
 func loadFile() *os.File {
@@ -150,13 +126,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   14,
+	Title: "Parabolic Reflector Dish",
+	Input: aoc.Grid{Alphabet: ".#O"},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
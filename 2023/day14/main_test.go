@@ -1,45 +1,22 @@
-package main
+package day14
 
 import (
-	"strings"
+	"bytes"
+	"context"
 	"testing"
-)
 
-func TestGetResults(t *testing.T) {
-	input := `O....#....
-O.OO#....#
-.....##...
-OO.#O....O
-.O.....O#.
-O.#..O.#.#
-..O..#O..O
-.......O..
-#....###..
-#OO..#....
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-	result := getResult(strings.NewReader(input))
-	if result != 64 {
-		t.Errorf("Expected result to be 64, got %d", result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
-		input := `O....#....
-O.OO#....#
-.....##...
-OO.#O....O
-.O.....O#.
-O.#..O.#.#
-..O..#O..O
-.......O..
-#....###..
-#OO..#....
-`
-
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(input))
+			getResult(context.Background(), bytes.NewReader(input))
 		}
 	})
 
@@ -48,7 +25,11 @@ O.#..O.#.#
 		defer inputFile.Close()
 
 		for n := 0; n < b.N; n++ {
-			getResult(inputFile)
+			getResult(context.Background(), inputFile)
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
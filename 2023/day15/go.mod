@@ -1,3 +1,7 @@
 module github.com/antitoine/advent-of-code/2023/day15
 
-go 1.21.3
+go 1.25.4
+
+require github.com/antitoine/advent-of-code/aoc v0.0.0
+
+replace github.com/antitoine/advent-of-code/aoc => ../../aoc
@@ -1,13 +1,13 @@
-package main
+package day15
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 func ascii(char rune) int64 {
@@ -44,8 +44,8 @@ type Operation struct {
 	Action  Action
 }
 
-func parseInput(input io.Reader) []Operation {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Operation, error) {
+	scanner := aoc.NewScanner(input)
 
 	var result []Operation
 	for scanner.Scan() {
@@ -63,14 +63,12 @@ func parseInput(input io.Reader) []Operation {
 			} else {
 				parts := strings.Split(step, "=")
 				if len(parts) != 2 {
-					log.Fatalf("Unable to parse step: %s", step)
-					return nil
+					return nil, scanner.Wrap(aoc.Unexpected(step, "'<label>-' or '<label>=<focal length>'"))
 				}
 				key := parts[0]
-				value, errParsingInt := strconv.ParseInt(parts[1], 10, 64)
+				value, errParsingInt := aoc.ParseInt(parts[1], 64)
 				if errParsingInt != nil {
-					log.Fatalf("Unable to parse value: %s", parts[1])
-					return nil
+					return nil, scanner.Wrap(errParsingInt)
 				}
 				result = append(result, Operation{
 					Key:     key,
@@ -83,10 +81,10 @@ func parseInput(input io.Reader) []Operation {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return result
+	return result, nil
 }
 
 type Slot struct {
@@ -136,14 +134,17 @@ func (b *Box) FocusPower(boxIdx int64) int64 {
 	return result
 }
 
-func getResult(input io.Reader) int64 {
+func getResult(input io.Reader) (int64, error) {
 	box := make([]Box, 256)
 	for i := 0; i < 256; i++ {
 		box[i] = Box{
 			SlotByKey: make(map[string]*Slot),
 		}
 	}
-	operations := parseInput(input)
+	operations, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	for _, operation := range operations {
 		if operation.Action == OperationSet {
 			box[operation.KeyHash].Set(operation.Key, operation.Value)
@@ -156,7 +157,7 @@ func getResult(input io.Reader) int64 {
 	for i := int64(0); i < 256; i++ {
 		focusPower += box[i].FocusPower(i)
 	}
-	return focusPower
+	return focusPower, nil
 }
 
 func loadFile() *os.File {
@@ -167,13 +168,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   15,
+	Title: "Lens Library",
+	Input: aoc.LinesMatching(`[a-z]+(-|=\d+)(,[a-z]+(-|=\d+))*`, "steps as '<label>-' or '<label>=<focal length>' separated by commas"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
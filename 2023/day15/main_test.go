@@ -1,27 +1,21 @@
-package main
+package day15
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
 
-func TestGetResults(t *testing.T) {
-	input := `rn=1,cm-,qp=3,cm=2,qp-,pc=4,ot=9,ab=5,pc-,pc=6,ot=7
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-	result := getResult(strings.NewReader(input))
-	if result != 145 {
-		t.Errorf("Expected result to be 145, got %d", result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
-		input := `rn=1,cm-,qp=3,cm=2,qp-,pc=4,ot=9,ab=5,pc-,pc=6,ot=7
-`
-
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(input))
+			getResult(bytes.NewReader(input))
 		}
 	})
 
@@ -34,3 +28,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -1,12 +1,15 @@
-package main
+package day16
 
 import (
-	"bufio"
-	"fmt"
+	"context"
+	"image"
 	"io"
 	"log"
 	"os"
-	"time"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
 )
 
 type Cell struct {
@@ -77,68 +80,41 @@ func (c *Cell) NextDirection(direction Direction) []Direction {
 			return []Direction{down}
 		}
 	}
-	log.Fatalf("Unknown symbol: %v", c.symbol)
+	// The other tiles are refused by parseInput.
 	return nil
 }
 
-type Graph [][]*Cell
-
-func (g Graph) GetCell(position Position) *Cell {
-	return g[position.row][position.column]
+type Graph struct {
+	grid.Grid[*Cell]
 }
 
 func (g Graph) Reset() {
-	for _, row := range g {
-		for _, cell := range row {
-			cell.Reset()
-		}
-	}
+	g.Each(func(_ image.Point, cell *Cell) {
+		cell.Reset()
+	})
 }
 
 func (g Graph) SymbolGraph() string {
-	var result string
-	for _, row := range g {
-		for _, cell := range row {
-			result += string(cell.symbol)
-		}
-		result += "\n"
-	}
-	return result
+	return g.Format(func(cell *Cell) string {
+		return string(cell.symbol)
+	})
 }
 
 func (g Graph) EnergizedGraph() string {
-	var result string
-	for _, row := range g {
-		for _, cell := range row {
-			if cell.energized {
-				result += "#"
-			} else {
-				result += "."
-			}
+	return g.Format(func(cell *Cell) string {
+		if cell.energized {
+			return "#"
 		}
-		result += "\n"
-	}
-	return result
+		return "."
+	})
 }
 
-type Position struct {
-	row    int
-	column int
+// EnergizedSnapshot returns the energized graph as a grid snapshot.
+func (g Graph) EnergizedSnapshot() aoc.GridSnapshot {
+	return aoc.GridSnapshot{Name: "energized", Rows: strings.Split(strings.TrimSuffix(g.EnergizedGraph(), "\n"), "\n")}
 }
 
-func (p Position) Next(direction Direction) Position {
-	switch direction {
-	case up:
-		return Position{p.row - 1, p.column}
-	case down:
-		return Position{p.row + 1, p.column}
-	case left:
-		return Position{p.row, p.column - 1}
-	case right:
-		return Position{p.row, p.column + 1}
-	}
-	panic(fmt.Sprintf("Unknown direction: %v", direction))
-}
+type Position = image.Point
 
 type Direction int
 
@@ -149,34 +125,28 @@ const (
 	right Direction = iota
 )
 
-func parseInput(input io.Reader) Graph {
-	scanner := bufio.NewScanner(input)
+var directionOffsets = [...]image.Point{
+	up:    {0, -1},
+	down:  {0, 1},
+	left:  {-1, 0},
+	right: {1, 0},
+}
 
-	var graph Graph
-	for scanner.Scan() {
-		line := scanner.Text()
-		row := make([]*Cell, len(line))
-		for column, char := range line {
-			row[column] = &Cell{symbol: char}
+func parseInput(input io.Reader) (Graph, error) {
+	g, errParsing := grid.Parse(input, func(_ image.Point, char rune) (*Cell, error) {
+		if !strings.ContainsRune(`./\|-`, char) {
+			return nil, aoc.Unexpected(string(char), `a tile among '.', '/', '\', '|' or '-'`)
 		}
-		graph = append(graph, row)
-	}
-
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
-	}
-
-	return graph
+		return &Cell{symbol: char}, nil
+	})
+	return Graph{g}, errParsing
 }
 
 func getCountOfEnergizedCells(graph Graph, position Position, direction Direction) int64 {
-	if position.row < 0 || position.row >= len(graph) {
+	cell, inside := graph.Get(position)
+	if !inside {
 		return 0
 	}
-	if position.column < 0 || position.column >= len(graph[position.row]) {
-		return 0
-	}
-	cell := graph.GetCell(position)
 	if cell.IsVisited(direction) {
 		return 0
 	}
@@ -186,40 +156,46 @@ func getCountOfEnergizedCells(graph Graph, position Position, direction Directio
 	}
 	cell.Visit(direction)
 	for _, nextDirection := range cell.NextDirection(direction) {
-		result += getCountOfEnergizedCells(graph, position.Next(nextDirection), nextDirection)
+		result += getCountOfEnergizedCells(graph, position.Add(directionOffsets[nextDirection]), nextDirection)
 	}
 	return result
 }
 
-func getResult(input io.Reader) int64 {
-	graph := parseInput(input)
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
+	graph, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	//log.Printf("Initial graph:\n%s", graph.SymbolGraph())
 	maxEnergizedCells := int64(-1)
-	for rowIdx := 0; rowIdx < len(graph); rowIdx++ {
-		for columnIdx := 0; columnIdx < len(graph[rowIdx]); columnIdx++ {
+	for rowIdx := 0; rowIdx < graph.Height(); rowIdx++ {
+		for columnIdx := 0; columnIdx < graph.Width(); columnIdx++ {
 			var directions []Direction
 			if rowIdx == 0 {
 				directions = append(directions, down)
 			}
-			if rowIdx == len(graph)-1 {
+			if rowIdx == graph.Height()-1 {
 				directions = append(directions, up)
 			}
 			if columnIdx == 0 {
 				directions = append(directions, right)
 			}
-			if columnIdx == len(graph[rowIdx])-1 {
+			if columnIdx == graph.Width()-1 {
 				directions = append(directions, left)
 			}
 			for _, direction := range directions {
-				energizedCells := getCountOfEnergizedCells(graph, Position{rowIdx, columnIdx}, direction)
+				energizedCells := getCountOfEnergizedCells(graph, image.Pt(columnIdx, rowIdx), direction)
 				if energizedCells > maxEnergizedCells {
 					maxEnergizedCells = energizedCells
+					if aoc.Observing(ctx) {
+						aoc.Emit(ctx, graph.EnergizedSnapshot())
+					}
 				}
 				graph.Reset()
 			}
 		}
 	}
-	return maxEnergizedCells
+	return maxEnergizedCells, nil
 }
 
 func loadFile() *os.File {
@@ -230,13 +206,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   16,
+	Title: "The Floor Will Be Lava",
+	Input: aoc.Grid{Alphabet: `./\|-`},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
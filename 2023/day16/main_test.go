@@ -1,43 +1,34 @@
-package main
+package day16
 
 import (
-	"strings"
+	"bytes"
+	"context"
 	"testing"
-)
-
-const testingInput = `.|...\....
-|.-.\.....
-.....|-...
-........|.
-..........
-.........\
-..../.\\..
-.-.-/..|..
-.|....-|.\
-..//.|....
-`
 
-const testingExpectedResult = 51
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput))
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput))
+			getResult(context.Background(), bytes.NewReader(input))
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			getResult(inputFile)
+			getResult(context.Background(), inputFile)
 			inputFile.Close()
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -1,35 +1,29 @@
-package main
+package day17
 
 import (
-	"bufio"
-	"container/heap"
+	"context"
+	"errors"
 	"fmt"
+	"image"
 	"io"
 	"log"
 	"os"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
+	"github.com/antitoine/advent-of-code/aoc/search"
 )
 
-type Grid [][]uint8
+type Grid struct {
+	grid.Grid[uint8]
+}
 
 func (g Grid) IsAllowed(position Position) bool {
-	return position.i >= 0 && position.i < len(g) && position.j >= 0 && position.j < len(g[0])
+	return g.In(position)
 }
 
 func (g Grid) GetHeatLoss(position Position) uint8 {
-	return g[position.i][position.j]
-}
-
-func (g Grid) String() string {
-	var result string
-	for _, row := range g {
-		for _, heatLoss := range row {
-			result += fmt.Sprintf("%d", heatLoss)
-		}
-		result += "\n"
-	}
-	return result
+	return g.At(position)
 }
 
 type Direction int
@@ -52,8 +46,7 @@ func (d Direction) TurnLeft() Direction {
 	case West:
 		return South
 	}
-	log.Fatalf("Unknown direction: %d", d)
-	return d
+	panic(fmt.Sprintf("Unknown direction: %d", d))
 }
 
 func (d Direction) TurnRight() Direction {
@@ -67,173 +60,81 @@ func (d Direction) TurnRight() Direction {
 	case West:
 		return North
 	}
-	log.Fatalf("Unknown direction: %d", d)
-	return d
+	panic(fmt.Sprintf("Unknown direction: %d", d))
 }
 
-type Position struct {
-	i, j int
-}
+type Position = image.Point
 
-func (p Position) Move(direction Direction) Position {
+func move(p Position, direction Direction) Position {
 	switch direction {
 	case North:
-		return Position{p.i - 1, p.j}
+		return p.Add(image.Pt(0, -1))
 	case South:
-		return Position{p.i + 1, p.j}
+		return p.Add(image.Pt(0, 1))
 	case East:
-		return Position{p.i, p.j + 1}
+		return p.Add(image.Pt(1, 0))
 	case West:
-		return Position{p.i, p.j - 1}
+		return p.Add(image.Pt(-1, 0))
 	}
-	log.Fatalf("Unknown direction: %d", direction)
-	return p
+	panic(fmt.Sprintf("Unknown direction: %d", direction))
 }
 
-type Step struct {
+// Crucible is the state of the crucible entering a block.
+type Crucible struct {
 	position              Position
-	heatLoss              uint64
 	direction             Direction
 	currentStraightLength int
-	queueIndex            int
-}
-
-func (s *Step) Move(grid Grid, direction Direction, resetStraightLength bool) (*Step, bool) {
-	newPosition := s.position.Move(direction)
-	if grid.IsAllowed(newPosition) {
-		step := &Step{
-			position:  newPosition,
-			heatLoss:  s.heatLoss + uint64(grid.GetHeatLoss(newPosition)),
-			direction: direction,
-		}
-		if resetStraightLength {
-			step.currentStraightLength = 1
-		} else {
-			step.currentStraightLength = s.currentStraightLength + 1
-		}
-		return step, true
-	}
-	return nil, false
-}
-
-type StepQueue []*Step
-
-func (pq StepQueue) Len() int           { return len(pq) }
-func (pq StepQueue) Less(i, j int) bool { return pq[i].heatLoss < pq[j].heatLoss }
-func (pq StepQueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
-
-func (pq *StepQueue) Push(x any) {
-	n := len(*pq)
-	item := x.(*Step)
-	item.queueIndex = n
-	*pq = append(*pq, item)
-}
-
-func (pq *StepQueue) Pop() any {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil
-	item.queueIndex = -1
-	*pq = old[0 : n-1]
-	return item
-}
-
-type Visit struct {
-	position              Position
-	direction             Direction
-	currentStraightLength int
-}
-
-type VisitedSet map[Visit]bool
-
-func (v VisitedSet) IsVisited(step *Step) bool {
-	visit := Visit{
-		position:              step.position,
-		direction:             step.direction,
-		currentStraightLength: step.currentStraightLength,
-	}
-	_, exists := v[visit]
-	v[visit] = true
-	return exists
 }
 
 const minStraightDistance = 4
 const maxStraightDistance = 10
 
-func getMinimumHeatLoss(grid Grid) uint64 {
-	stepQueue := StepQueue{}
-	heap.Init(&stepQueue)
-	heap.Push(&stepQueue, &Step{position: Position{0, 0}, direction: East, currentStraightLength: 0})
-	heap.Push(&stepQueue, &Step{position: Position{0, 0}, direction: South, currentStraightLength: 0})
-
-	visits := make(VisitedSet)
-
-	for stepQueue.Len() > 0 {
-		step := heap.Pop(&stepQueue).(*Step)
-
-		if visits.IsVisited(step) {
-			continue
-		}
-
-		if step.position.i == len(grid)-1 && step.position.j == len(grid[0])-1 {
-			if step.currentStraightLength >= minStraightDistance {
-				return step.heatLoss
+var errNoPath = errors.New("unable to find a path to the factory")
+
+func getMinimumHeatLoss(grid Grid) (uint64, error) {
+	factory := image.Pt(grid.Width()-1, grid.Height()-1)
+	result, errSearching := search.Dijkstra(search.Problem[Crucible]{
+		Starts: []Crucible{{direction: East}, {direction: South}},
+		Neighbours: func(crucible Crucible, visit func(Crucible, int)) {
+			tryMove := func(direction Direction, straightLength int) {
+				if next := move(crucible.position, direction); grid.IsAllowed(next) {
+					visit(Crucible{next, direction, straightLength}, int(grid.GetHeatLoss(next)))
+				}
 			}
-			continue
-		}
-
-		// If we could move in the same direction, do it
-		if step.currentStraightLength < maxStraightDistance {
-			if newStep, allowed := step.Move(grid, step.direction, false); allowed {
-				heap.Push(&stepQueue, newStep)
-			}
-		}
-
-		if step.currentStraightLength >= minStraightDistance {
-			// Try turning left
-			if newStep, allowed := step.Move(grid, step.direction.TurnLeft(), true); allowed {
-				heap.Push(&stepQueue, newStep)
+			// If we could move in the same direction, do it
+			if crucible.currentStraightLength < maxStraightDistance {
+				tryMove(crucible.direction, crucible.currentStraightLength+1)
 			}
-
-			// Try turning right
-			if newStep, allowed := step.Move(grid, step.direction.TurnRight(), true); allowed {
-				heap.Push(&stepQueue, newStep)
+			if crucible.currentStraightLength >= minStraightDistance {
+				tryMove(crucible.direction.TurnLeft(), 1)
+				tryMove(crucible.direction.TurnRight(), 1)
 			}
-		}
+		},
+		Goal: func(crucible Crucible) bool {
+			return crucible.position == factory && crucible.currentStraightLength >= minStraightDistance
+		},
+	})
+	if errors.Is(errSearching, search.ErrNoPath) {
+		return 0, errNoPath
 	}
-
-	log.Fatalf("Unable to find a path")
-
-	return 0
+	return uint64(result.Cost), errSearching
 }
 
-func parseInput(input io.Reader) Grid {
-	scanner := bufio.NewScanner(input)
-
-	var grid Grid
-	for scanner.Scan() {
-		line := scanner.Text()
-		row := make([]uint8, len(line))
-		for column, char := range line {
-			heatLoss, errParsing := strconv.ParseUint(string(char), 10, 8)
-			if errParsing != nil {
-				log.Fatalf("Unable to parse uint8 from character '%s': %v", string(char), errParsing)
-			}
-			row[column] = uint8(heatLoss)
+func parseInput(input io.Reader) (Grid, error) {
+	g, errParsing := grid.Parse(input, func(_ image.Point, char rune) (uint8, error) {
+		if char < '0' || char > '9' {
+			return 0, aoc.Unexpected(string(char), "a heat loss digit")
 		}
-		grid = append(grid, row)
-	}
-
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
-	}
-
-	return grid
+		return uint8(char - '0'), nil
+	})
+	return Grid{g}, errParsing
 }
 
-func getResult(input io.Reader) uint64 {
-	grid := parseInput(input)
+func getResult(input io.Reader) (uint64, error) {
+	grid, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	//log.Printf("Grid:\n%s", grid)
 	return getMinimumHeatLoss(grid)
 }
@@ -246,13 +147,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   17,
+	Title: "Clumsy Crucible",
+	Input: aoc.Grid{Alphabet: "0123456789"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Uint(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
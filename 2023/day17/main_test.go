@@ -1,38 +1,21 @@
-package main
+package day17
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
-
-const testingInput = `2413432311323
-3215453535623
-3255245654254
-3446585845452
-4546657867536
-1438598798454
-4457876987766
-3637877979653
-4654967986887
-4564679986453
-1224686865563
-2546548887735
-4322674655533
-`
 
-const testingExpectedResult = 94
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput))
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput))
+			getResult(bytes.NewReader(input))
 		}
 	})
 
@@ -44,3 +27,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
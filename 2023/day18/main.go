@@ -1,13 +1,15 @@
-package main
+package day18
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"regexp"
 	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Direction int
@@ -34,21 +36,20 @@ func (p Position) Move(direction Direction) Position {
 	case Right:
 		return Position{p.i, p.j + 1}
 	}
-	log.Fatalf("Unknown direction: %d", direction)
-	return p
+	panic(fmt.Sprintf("Unknown direction: %d", direction))
 }
 
 var lineRegex = regexp.MustCompile(`^([UDLR]) (\d+) \(#([0-9a-f]{5})([0-9a-f])\)$`)
 
-func parseLine(line string) (Direction, int64) {
+func parseLine(line string) (Direction, int64, error) {
 	matches := lineRegex.FindStringSubmatch(line)
 	if matches == nil || len(matches) != 5 {
-		log.Fatalf("Unable to parse line: %s", line)
+		return 0, 0, aoc.Unexpected(line, "'<U|D|L|R> <meters> (#<color>)'")
 	}
 
 	distance, errParsingDistance := strconv.ParseInt(matches[3], 16, 64)
 	if errParsingDistance != nil {
-		log.Fatalf("Unable to parse distance: %s", matches[2])
+		return 0, 0, &aoc.ParseError{Text: matches[3], Expected: "a hexadecimal distance", Err: errParsingDistance}
 	}
 
 	// 0 means R, 1 means D, 2 means L, and 3 means U.
@@ -63,20 +64,25 @@ func parseLine(line string) (Direction, int64) {
 		direction = Left
 	case "3":
 		direction = Up
+	default:
+		return 0, 0, &aoc.ParseError{Column: len(line) - 1, Text: directionStr, Expected: "a direction digit among 0, 1, 2 or 3"}
 	}
 
-	return direction, distance
+	return direction, distance, nil
 }
 
-func parseInput(input io.Reader) ([]Position, int64) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Position, int64, error) {
+	scanner := aoc.NewScanner(input)
 
 	var vertices []Position
 	currentPosition := Position{0, 0}
 	vertices = append(vertices, currentPosition)
 	var boundaryPoints int64
 	for scanner.Scan() {
-		direction, distance := parseLine(scanner.Text())
+		direction, distance, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, 0, scanner.Wrap(errParsing)
+		}
 		boundaryPoints += distance
 		for i := int64(0); i < distance; i++ {
 			currentPosition = currentPosition.Move(direction)
@@ -85,14 +91,17 @@ func parseInput(input io.Reader) ([]Position, int64) {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, 0, errScanningFile
 	}
 
-	return vertices, boundaryPoints
+	return vertices, boundaryPoints, nil
 }
 
-func getResult(input io.Reader) int64 {
-	vertices, boundaryPoints := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	vertices, boundaryPoints, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	// Compute area using the shoelace formula
 	// https://en.wikipedia.org/wiki/Shoelace_formula
@@ -106,7 +115,7 @@ func getResult(input io.Reader) int64 {
 
 	// Computed points
 	// https://en.wikipedia.org/wiki/Pick%27s_theorem
-	return area - (boundaryPoints / 2) + 1 + boundaryPoints
+	return area - (boundaryPoints / 2) + 1 + boundaryPoints, nil
 }
 
 func loadFile() *os.File {
@@ -117,13 +126,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   18,
+	Title: "Lavaduct Lagoon",
+	Input: aoc.LinesMatching(`[UDLR] \d+ \(#[0-9a-f]{6}\)`, "'<U|D|L|R> <meters> (#<color>)'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,39 +1,21 @@
-package main
+package day18
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
-
-const testingInput = `R 6 (#70c710)
-D 5 (#0dc571)
-L 2 (#5713f0)
-D 2 (#d2c081)
-R 2 (#59c680)
-D 2 (#411b91)
-L 5 (#8ceee2)
-U 2 (#caa173)
-L 1 (#1b58a2)
-U 2 (#caa171)
-R 2 (#7807d2)
-U 3 (#a77fa3)
-L 2 (#015232)
-U 2 (#7a21e3)
-`
 
-const testingExpectedResult = 952408144115
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput))
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput))
+			getResult(bytes.NewReader(input))
 		}
 	})
 
@@ -45,3 +27,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
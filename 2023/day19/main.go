@@ -1,15 +1,17 @@
-package main
+package day19
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"slices"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/intervals"
 )
 
 type Condition struct {
@@ -22,49 +24,22 @@ func (c Condition) String() string {
 	return c.key + c.operator + strconv.FormatInt(c.value, 10)
 }
 
+// Apply splits the range into the ratings satisfying the condition and the
+// ones which don't.
 func (c Condition) Apply(currentRange ApprovedInstructionRange) (ApprovedInstructionRange, ApprovedInstructionRange) {
 	ifTrueRange := currentRange
 	ifFalseRange := currentRange
-	switch c.key {
-	case "x":
-		switch c.operator {
-		case "<":
-			// x<value
-			ifTrueRange.x.max = c.value - 1
-			// x>=value
-			ifFalseRange.x.min = c.value
-		case ">":
-			ifTrueRange.x.min = c.value + 1
-			ifFalseRange.x.max = c.value
-		}
-	case "m":
-		switch c.operator {
-		case "<":
-			ifTrueRange.m.max = c.value - 1
-			ifFalseRange.m.min = c.value
-		case ">":
-			ifTrueRange.m.min = c.value + 1
-			ifFalseRange.m.max = c.value
-		}
-	case "a":
-		switch c.operator {
-		case "<":
-			ifTrueRange.a.max = c.value - 1
-			ifFalseRange.a.min = c.value
-		case ">":
-			ifTrueRange.a.min = c.value + 1
-			ifFalseRange.a.max = c.value
-		}
-	case "s":
-		switch c.operator {
-		case "<":
-			ifTrueRange.s.max = c.value - 1
-			ifFalseRange.s.min = c.value
-		case ">":
-			ifTrueRange.s.min = c.value + 1
-			ifFalseRange.s.max = c.value
-		}
+	var ifTrue, ifFalse intervals.Interval
+	switch c.operator {
+	case "<":
+		ifTrue = intervals.New(approvedInstructionRangeMin, c.value)
+		ifFalse = intervals.Closed(c.value, approvedInstructionRangeMax)
+	case ">":
+		ifTrue = intervals.Closed(c.value+1, approvedInstructionRangeMax)
+		ifFalse = intervals.Closed(approvedInstructionRangeMin, c.value)
 	}
+	*ifTrueRange.rating(c.key) = currentRange.rating(c.key).Intersect(ifTrue)
+	*ifFalseRange.rating(c.key) = currentRange.rating(c.key).Intersect(ifFalse)
 	return ifTrueRange, ifFalseRange
 }
 
@@ -76,25 +51,33 @@ type Rule struct {
 
 var conditionRegex = regexp.MustCompile(`^([xmas])([<>])(\d+)$`)
 
-func NewRule(conditionStr string, ifTrueStr string, ifFalseStr string, otherWorkflows RawWorkflows) *Rule {
+func NewRule(workflow RawWorkflow, conditionStr string, ifTrueStr string, ifFalseStr string, otherWorkflows RawWorkflows) (*Rule, error) {
 	conditionParts := conditionRegex.FindStringSubmatch(conditionStr)
 	if len(conditionParts) != 4 {
-		log.Fatalf("Unable to parse condition: %s", conditionStr)
+		return nil, workflow.Unexpected(conditionStr, "a condition '<x|m|a|s><<|>><value>'")
 	}
-	value, errParsingValue := strconv.ParseInt(conditionParts[3], 10, 64)
+	value, errParsingValue := aoc.ParseInt(conditionParts[3], 64)
 	if errParsingValue != nil {
-		log.Fatalf("Unable to parse condition value in: %s (%v)", conditionStr, errParsingValue)
+		return nil, workflow.Wrap(errParsingValue)
 	}
 	condition := Condition{
 		key:      conditionParts[1],
 		operator: conditionParts[2],
 		value:    value,
 	}
+	ifTrue, errParsingIfTrue := NewStep(workflow, ifTrueStr, otherWorkflows)
+	if errParsingIfTrue != nil {
+		return nil, errParsingIfTrue
+	}
+	ifFalse, errParsingIfFalse := NewStep(workflow, ifFalseStr, otherWorkflows)
+	if errParsingIfFalse != nil {
+		return nil, errParsingIfFalse
+	}
 	return &Rule{
 		condition: condition,
-		ifTrue:    NewStep(ifTrueStr, otherWorkflows),
-		ifFalse:   NewStep(ifFalseStr, otherWorkflows),
-	}
+		ifTrue:    ifTrue,
+		ifFalse:   ifFalse,
+	}, nil
 }
 
 func (r *Rule) String(indent string) string {
@@ -110,7 +93,7 @@ type Step struct {
 	rule     *Rule
 }
 
-func NewStep(workflowContent string, otherRawWorkflows RawWorkflows) *Step {
+func NewStep(workflow RawWorkflow, workflowContent string, otherRawWorkflows RawWorkflows) (*Step, error) {
 	stepList := strings.Split(workflowContent, ",")
 	stepStr := stepList[0]
 	switch stepStr {
@@ -118,29 +101,36 @@ func NewStep(workflowContent string, otherRawWorkflows RawWorkflows) *Step {
 		value := false
 		return &Step{
 			approved: &value,
-		}
+		}, nil
 	case "A":
 		value := true
 		return &Step{
 			approved: &value,
-		}
+		}, nil
 	default:
-		if nextWorkflowContent, ok := otherRawWorkflows[stepStr]; ok {
-			return NewStep(nextWorkflowContent, otherRawWorkflows)
+		if nextWorkflow, ok := otherRawWorkflows[stepStr]; ok {
+			nextWorkflow.depth = workflow.depth + 1
+			if nextWorkflow.depth > len(otherRawWorkflows) {
+				return nil, workflow.Unexpected(stepStr, "a workflow not sending back to itself")
+			}
+			return NewStep(nextWorkflow, nextWorkflow.content, otherRawWorkflows)
 		}
 		conditionParts := strings.Split(stepStr, ":")
 		if len(conditionParts) != 2 {
-			log.Fatalf("Unable to parse step for condition: %s", stepStr)
+			return nil, workflow.Unexpected(stepStr, "'A', 'R', a workflow name or a rule '<condition>:<next>'")
 		}
 		if len(stepList) == 1 {
-			log.Fatalf("Unable to parse step for next step: %s", stepStr)
+			return nil, workflow.Unexpected(stepStr, "a fallback step after the rule")
 		}
 		nextStepStr := strings.Join(stepList[1:], ",")
-		return &Step{
-			rule: NewRule(conditionParts[0], conditionParts[1], nextStepStr, otherRawWorkflows),
+		rule, errParsingRule := NewRule(workflow, conditionParts[0], conditionParts[1], nextStepStr, otherRawWorkflows)
+		if errParsingRule != nil {
+			return nil, errParsingRule
 		}
+		return &Step{
+			rule: rule,
+		}, nil
 	}
-	return nil
 }
 
 func (s *Step) String(indent string) string {
@@ -153,47 +143,41 @@ func (s *Step) String(indent string) string {
 	return s.rule.String(indent)
 }
 
-type Range struct {
-	min int64
-	max int64
-}
-
 type ApprovedInstructionRange struct {
-	x Range
-	m Range
-	a Range
-	s Range
+	x intervals.Interval
+	m intervals.Interval
+	a intervals.Interval
+	s intervals.Interval
 }
 
 const approvedInstructionRangeMin = 1
 const approvedInstructionRangeMax = 4000
 
 func NewApprovedInstructionRange() ApprovedInstructionRange {
-	return ApprovedInstructionRange{
-		x: Range{
-			min: approvedInstructionRangeMin,
-			max: approvedInstructionRangeMax,
-		},
-		m: Range{
-			min: approvedInstructionRangeMin,
-			max: approvedInstructionRangeMax,
-		},
-		a: Range{
-			min: approvedInstructionRangeMin,
-			max: approvedInstructionRangeMax,
-		},
-		s: Range{
-			min: approvedInstructionRangeMin,
-			max: approvedInstructionRangeMax,
-		},
+	all := intervals.Closed(approvedInstructionRangeMin, approvedInstructionRangeMax)
+	return ApprovedInstructionRange{x: all, m: all, a: all, s: all}
+}
+
+func (r *ApprovedInstructionRange) rating(key string) *intervals.Interval {
+	switch key {
+	case "x":
+		return &r.x
+	case "m":
+		return &r.m
+	case "a":
+		return &r.a
+	default:
+		return &r.s
 	}
 }
 
+// Combinations returns the number of parts whose ratings are in the range.
+func (r ApprovedInstructionRange) Combinations() int64 {
+	return r.x.Len() * r.m.Len() * r.a.Len() * r.s.Len()
+}
+
 func (r ApprovedInstructionRange) String() string {
-	return "{x=" + strconv.FormatInt(r.x.min, 10) + "," + strconv.FormatInt(r.x.max, 10) +
-		",m=" + strconv.FormatInt(r.m.min, 10) + "," + strconv.FormatInt(r.m.max, 10) +
-		",a=" + strconv.FormatInt(r.a.min, 10) + "," + strconv.FormatInt(r.a.max, 10) +
-		",s=" + strconv.FormatInt(r.s.min, 10) + "," + strconv.FormatInt(r.s.max, 10) + "}"
+	return "{x=" + r.x.String() + ",m=" + r.m.String() + ",a=" + r.a.String() + ",s=" + r.s.String() + "}"
 }
 
 func (s *Step) ComputeListOfApprovedInstructionRange(currentRange ApprovedInstructionRange) []ApprovedInstructionRange {
@@ -203,9 +187,6 @@ func (s *Step) ComputeListOfApprovedInstructionRange(currentRange ApprovedInstru
 		}
 		return nil
 	}
-	if s.rule == nil {
-		log.Fatalf("Unable to compute list of approved instruction range, approved and rule are nil: %s", s.String(""))
-	}
 	ifTrueRange, ifFalseRange := s.rule.condition.Apply(currentRange)
 	var result []ApprovedInstructionRange
 	result = append(result, s.rule.ifTrue.ComputeListOfApprovedInstructionRange(ifTrueRange)...)
@@ -213,20 +194,50 @@ func (s *Step) ComputeListOfApprovedInstructionRange(currentRange ApprovedInstru
 	return result
 }
 
-type RawWorkflows map[string]string
+// RawWorkflow is a workflow kept unparsed until its steps are built, along
+// with its input line to locate parse errors. Its depth is the number of
+// workflows sent to before reaching it, more than the count of workflows
+// meaning they loop.
+type RawWorkflow struct {
+	content string
+	line    int
+	text    string
+	depth   int
+}
+
+func (w RawWorkflow) Unexpected(text, expected string) error {
+	return w.Wrap(aoc.Unexpected(text, expected))
+}
+
+func (w RawWorkflow) Wrap(err error) error {
+	var parseErr *aoc.ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	located := *parseErr
+	located.Line = w.line
+	if idx := strings.Index(w.text, located.Text); idx >= 0 {
+		located.Column = idx + 1
+	}
+	return &located
+}
+
+type RawWorkflows map[string]RawWorkflow
 
 var ruleRegex = regexp.MustCompile(`^([a-zA-Z0-9]+)\{(.*)}$`)
 
-func parseRawWorkflow(line string) (string, string) {
+func parseRawWorkflow(line string) (string, string, error) {
 	ruleMatches := ruleRegex.FindStringSubmatch(line)
 	if len(ruleMatches) != 3 {
-		log.Fatalf("Unable to parse rule for finding the key: %s", line)
+		return "", "", aoc.Unexpected(line, "'<name>{<rules>}'")
 	}
-	return ruleMatches[1], ruleMatches[2]
+	return ruleMatches[1], ruleMatches[2], nil
 }
 
-func parseInput(input io.Reader) RawWorkflows {
-	scanner := bufio.NewScanner(input)
+const firstWorkflowKey = "in"
+
+func parseInput(input io.Reader) (RawWorkflows, error) {
+	scanner := aoc.NewScanner(input)
 
 	rawWorkflows := make(RawWorkflows)
 	for scanner.Scan() {
@@ -234,44 +245,39 @@ func parseInput(input io.Reader) RawWorkflows {
 		if line == "" {
 			break
 		}
-		workflowKey, workflowContent := parseRawWorkflow(line)
-		rawWorkflows[workflowKey] = workflowContent
+		workflowKey, workflowContent, errParsing := parseRawWorkflow(line)
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		rawWorkflows[workflowKey] = RawWorkflow{
+			content: workflowContent,
+			line:    scanner.Line(),
+			text:    line,
+		}
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return rawWorkflows
-}
-
-const firstWorkflowKey = "in"
-
-func ComputeBreakPointForRanges(ranges []Range) []int64 {
-	result := make(map[int64]bool)
-	result[approvedInstructionRangeMin] = true
-	result[approvedInstructionRangeMax+1] = true
-	for _, r := range ranges {
-		result[r.min] = true
-		result[r.max+1] = true
+	if _, firstWorkflowFound := rawWorkflows[firstWorkflowKey]; !firstWorkflowFound {
+		return nil, scanner.Missing("a workflow named '" + firstWorkflowKey + "'")
 	}
-	var resultSlice []int64
-	for k := range result {
-		resultSlice = append(resultSlice, k)
-	}
-	slices.Sort(resultSlice)
-	return resultSlice
-}
 
-func getResult(input io.Reader) int64 {
-	rawWorkflows := parseInput(input)
+	return rawWorkflows, nil
+}
 
-	firstWorkflowContent, firstWorkflowFound := rawWorkflows[firstWorkflowKey]
-	if !firstWorkflowFound {
-		log.Fatalf("Unable to find the first workflow: %s", firstWorkflowKey)
+func getResult(input io.Reader) (int64, error) {
+	rawWorkflows, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
 
-	firstStep := NewStep(firstWorkflowContent, rawWorkflows)
+	firstWorkflow := rawWorkflows[firstWorkflowKey]
+	firstStep, errParsingStep := NewStep(firstWorkflow, firstWorkflow.content, rawWorkflows)
+	if errParsingStep != nil {
+		return 0, errParsingStep
+	}
 	//log.Printf("First step:\n%s", firstStep.String("  "))
 
 	approvedInstructionRangeList := firstStep.ComputeListOfApprovedInstructionRange(NewApprovedInstructionRange())
@@ -280,89 +286,13 @@ func getResult(input io.Reader) int64 {
 	//	log.Printf("Approved instruction range: %s", approvedInstructionRange.String())
 	//}
 
-	var xRanges []Range
-	var mRanges []Range
-	var aRanges []Range
-	var sRanges []Range
-	for _, approvedInstructionRange := range approvedInstructionRangeList {
-		xRanges = append(xRanges, approvedInstructionRange.x)
-		mRanges = append(mRanges, approvedInstructionRange.m)
-		aRanges = append(aRanges, approvedInstructionRange.a)
-		sRanges = append(sRanges, approvedInstructionRange.s)
-	}
-
-	xBreakPoints := ComputeBreakPointForRanges(xRanges)
-	//log.Printf("Found %d x break points: %v", len(xBreakPoints), xBreakPoints)
-	mBreakPoints := ComputeBreakPointForRanges(mRanges)
-	//log.Printf("Found %d m break points: %v", len(mBreakPoints), mBreakPoints)
-	aBreakPoints := ComputeBreakPointForRanges(aRanges)
-	//log.Printf("Found %d a break points: %v", len(aBreakPoints), aBreakPoints)
-	sBreakPoints := ComputeBreakPointForRanges(sRanges)
-	//log.Printf("Found %d s break points: %v", len(sBreakPoints), sBreakPoints)
-
+	// Each rule splits a range in two, so the approved ranges never overlap.
 	var result int64
-	for xIdx := 0; xIdx < len(xBreakPoints)-1; xIdx++ {
-		xMin := xBreakPoints[xIdx]
-		xMax := xBreakPoints[xIdx+1]
-
-		var stillValidInstructionsAfterX []ApprovedInstructionRange
-		for _, approvedInstructionRange := range approvedInstructionRangeList {
-			if approvedInstructionRange.x.min <= xMin && xMax <= approvedInstructionRange.x.max+1 {
-				stillValidInstructionsAfterX = append(stillValidInstructionsAfterX, approvedInstructionRange)
-			}
-		}
-		if len(stillValidInstructionsAfterX) == 0 {
-			continue
-		}
-
-		for mIdx := 0; mIdx < len(mBreakPoints)-1; mIdx++ {
-			mMin := mBreakPoints[mIdx]
-			mMax := mBreakPoints[mIdx+1]
-
-			var stillValidInstructionsAfterM []ApprovedInstructionRange
-			for _, approvedInstructionRange := range stillValidInstructionsAfterX {
-				if approvedInstructionRange.m.min <= mMin && mMax <= approvedInstructionRange.m.max+1 {
-					stillValidInstructionsAfterM = append(stillValidInstructionsAfterM, approvedInstructionRange)
-				}
-			}
-			if len(stillValidInstructionsAfterM) == 0 {
-				continue
-			}
-
-			for aIdx := 0; aIdx < len(aBreakPoints)-1; aIdx++ {
-				aMin := aBreakPoints[aIdx]
-				aMax := aBreakPoints[aIdx+1]
-
-				var stillValidInstructionsAfterA []ApprovedInstructionRange
-				for _, approvedInstructionRange := range stillValidInstructionsAfterM {
-					if approvedInstructionRange.a.min <= aMin && aMax <= approvedInstructionRange.a.max+1 {
-						stillValidInstructionsAfterA = append(stillValidInstructionsAfterA, approvedInstructionRange)
-					}
-				}
-				if len(stillValidInstructionsAfterA) == 0 {
-					continue
-				}
-
-				for sIdx := 0; sIdx < len(sBreakPoints)-1; sIdx++ {
-					sMin := sBreakPoints[sIdx]
-					sMax := sBreakPoints[sIdx+1]
-
-					foundS := false
-					for _, approvedInstructionRange := range stillValidInstructionsAfterA {
-						if approvedInstructionRange.s.min <= sMin && sMax <= approvedInstructionRange.s.max+1 {
-							foundS = true
-							break
-						}
-					}
-					if foundS {
-						result += (xMax - xMin) * (mMax - mMin) * (aMax - aMin) * (sMax - sMin)
-					}
-				}
-			}
-		}
+	for _, approvedInstructionRange := range approvedInstructionRangeList {
+		result += approvedInstructionRange.Combinations()
 	}
 
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -373,13 +303,20 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   19,
+	Title: "Aplenty",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`\w+\{.*\}`, "a workflow as '<name>{<rules>}'"),
+		aoc.LinesMatching(`\{x=\d+,m=\d+,a=\d+,s=\d+\}`, "a part as '{x=<x>,m=<m>,a=<a>,s=<s>}'"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
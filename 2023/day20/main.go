@@ -1,12 +1,15 @@
-package main
+package day20
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/numtheory"
 )
 
 type Pulse int
@@ -214,37 +217,37 @@ func parseModuleIds(modulesStr string) []ModuleId {
 	return moduleIds
 }
 
-func parseModule(line string) (Module, AttachModuleId) {
+func parseModule(line string) (Module, AttachModuleId, error) {
 	var module Module
 	var declareModuleId AttachModuleId
 	if nextFlipFlopStr, foundFlipFlopPrefix := strings.CutPrefix(line, "%"); foundFlipFlopPrefix {
 		parts := strings.Split(nextFlipFlopStr, " -> ")
 		if len(parts) != 2 {
-			log.Fatalf("Unable to parse flip-flop module line: %s", line)
+			return nil, nil, aoc.Unexpected(line, "'%<name> -> <outputs>'")
 		}
 		module = NewFlipFlop(parts[0], parseModuleIds(parts[1]))
 	} else if nextConjunctionStr, foundConjunctionPrefix := strings.CutPrefix(line, "&"); foundConjunctionPrefix {
 		parts := strings.Split(nextConjunctionStr, " -> ")
 		if len(parts) != 2 {
-			log.Fatalf("Unable to parse conjunction module line: %s", line)
+			return nil, nil, aoc.Unexpected(line, "'&<name> -> <outputs>'")
 		}
 		module, declareModuleId = NewConjunction(parts[0], parseModuleIds(parts[1]))
 	} else {
-		log.Fatalf("Unable to parse module line: %s", line)
+		return nil, nil, aoc.Unexpected(line, "a flip-flop '%', a conjunction '&' or the broadcaster")
 	}
-	return module, declareModuleId
+	return module, declareModuleId, nil
 }
 
-func parseBroadcast(line string) *Broadcast {
+func parseBroadcast(line string) (*Broadcast, error) {
 	modulesStr, foundPrefix := strings.CutPrefix(line, "broadcaster -> ")
 	if !foundPrefix {
-		log.Fatalf("Unable to parse line: %s", line)
+		return nil, aoc.Unexpected(line, "'broadcaster -> <outputs>'")
 	}
-	return NewBroadcast(parseModuleIds(modulesStr))
+	return NewBroadcast(parseModuleIds(modulesStr)), nil
 }
 
-func parseInput(input io.Reader) (*Broadcast, map[ModuleId]Module, *Sand) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (*Broadcast, map[ModuleId]Module, *Sand, error) {
+	scanner := aoc.NewScanner(input)
 
 	var broadcast *Broadcast
 	modules := make(map[ModuleId]Module)
@@ -258,9 +261,16 @@ func parseInput(input io.Reader) (*Broadcast, map[ModuleId]Module, *Sand) {
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "broadcaster ->") {
-			broadcast = parseBroadcast(line)
+			var errParsing error
+			broadcast, errParsing = parseBroadcast(line)
+			if errParsing != nil {
+				return nil, nil, nil, scanner.Wrap(errParsing)
+			}
 		} else {
-			module, declareModuleId := parseModule(line)
+			module, declareModuleId, errParsing := parseModule(line)
+			if errParsing != nil {
+				return nil, nil, nil, scanner.Wrap(errParsing)
+			}
 			modules[module.GetId()] = module
 			if declareModuleId != nil {
 				waitingAttachingParentModule[module.GetId()] = declareModuleId
@@ -269,7 +279,11 @@ func parseInput(input io.Reader) (*Broadcast, map[ModuleId]Module, *Sand) {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, nil, errScanningFile
+	}
+
+	if broadcast == nil {
+		return nil, nil, nil, scanner.Missing("the broadcaster module")
 	}
 
 	// Setup conjunctions modules
@@ -281,7 +295,7 @@ func parseInput(input io.Reader) (*Broadcast, map[ModuleId]Module, *Sand) {
 		}
 	}
 
-	return broadcast, modules, sand
+	return broadcast, modules, sand, nil
 }
 
 func TriggerOnce(broadcast *Broadcast, modules map[ModuleId]Module, moduleHighInputWatch *ModuleId) (int64, int64, []ModuleId) {
@@ -324,83 +338,81 @@ func TriggerOnce(broadcast *Broadcast, modules map[ModuleId]Module, moduleHighIn
 	return countOfLowPulses, countOfHighPulses, moduleHighInputDetected
 }
 
-func getResultForPart1(text io.Reader) int64 {
-	broadcast, modules, _ := parseInput(text)
+func getResultForPart1(ctx context.Context, text io.Reader) (int64, error) {
+	broadcast, modules, _, errParsing := parseInput(text)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var countOfLowPulses, countOfHighPulses int64
 	for i := int64(0); i < 1000; i++ {
 		newCountOfLowPulses, newCountOfHighPulses, _ := TriggerOnce(broadcast, modules, nil)
 		countOfLowPulses += newCountOfLowPulses
 		countOfHighPulses += newCountOfHighPulses
 	}
-	log.Printf("Count of low pulses: %d", countOfLowPulses)
-	log.Printf("Count of high pulses: %d", countOfHighPulses)
-	return countOfLowPulses * countOfHighPulses
+	aoc.Emit(ctx, aoc.Value{Name: "count of low pulses", Value: countOfLowPulses})
+	aoc.Emit(ctx, aoc.Value{Name: "count of high pulses", Value: countOfHighPulses})
+	return countOfLowPulses * countOfHighPulses, nil
 }
 
-func allModulesStepsDetected(moduleIdsLowAfter map[ModuleId]int64) (bool, []int64) {
-	var result []int64
-	for _, after := range moduleIdsLowAfter {
-		if after == -1 {
+// allModulesCyclesDetected returns, once every watched module has been high
+// twice, the congruences giving the presses where each of them is high.
+func allModulesCyclesDetected(moduleIdsHighAfter map[ModuleId][]int64) (bool, []numtheory.Congruence) {
+	var result []numtheory.Congruence
+	for _, after := range moduleIdsHighAfter {
+		if len(after) < 2 {
 			return false, nil
 		}
-		result = append(result, after)
+		result = append(result, numtheory.Congruence{Residue: after[0], Modulus: after[1] - after[0]})
 	}
 	return true, result
 }
 
-func greatestCommonDivisor(a, b int64) int64 {
-	for b != 0 {
-		t := b
-		b = a % b
-		a = t
+func getResultForPart2(ctx context.Context, text io.Reader) (int64, error) {
+	broadcast, modules, sand, errParsing := parseInput(text)
+	if errParsing != nil {
+		return 0, errParsing
 	}
-	return a
-}
-
-func leastCommonMultiple(a, b int64) int64 {
-	return a * b / greatestCommonDivisor(a, b)
-}
-
-func getResultForPart2(text io.Reader) int64 {
-	broadcast, modules, sand := parseInput(text)
-
-	log.Printf("Broadcast: %#v", *broadcast)
-	log.Printf("Modules: %v", modules)
-	log.Printf("Sand: %#v", *sand)
 
 	parentSandModuleId := sand.parent
-	parentSandModule, foundParentSandModule := modules[parentSandModuleId]
+	parentSandModule, foundParentSandModule := modules[parentSandModuleId].(*Conjunction)
 	if !foundParentSandModule {
-		log.Fatalf("Unable to find parent sand module: %s", parentSandModuleId)
+		return 0, fmt.Errorf("no conjunction module sends pulses to %s", sand.GetId())
 	}
 
-	log.Printf("Parent Sand module: %#v", parentSandModule)
-
-	moduleIdsWatching := make(map[ModuleId]int64)
-	for moduleId := range parentSandModule.(*Conjunction).alreadyReceived {
-		moduleIdsWatching[moduleId] = -1
+	moduleIdsWatching := make(map[ModuleId][]int64)
+	for moduleId := range parentSandModule.alreadyReceived {
+		moduleIdsWatching[moduleId] = nil
 	}
 
-	log.Printf("Waiting modules IDs to be high: %#v", moduleIdsWatching)
-	isAllDetected, afters := allModulesStepsDetected(moduleIdsWatching)
+	aoc.Emit(ctx, aoc.Value{Name: "parent sand module", Value: parentSandModuleId})
+	end := aoc.Phase(ctx, "waiting for the watched modules to be high twice")
+	isAllDetected, cycles := allModulesCyclesDetected(moduleIdsWatching)
 	for i := int64(1); !isAllDetected; i++ {
 		_, _, detected := TriggerOnce(broadcast, modules, &sand.parent)
 		for _, moduleId := range detected {
-			if moduleIdsWatching[moduleId] == -1 {
-				log.Printf("Module %s is high after %d steps", moduleId, i)
-				moduleIdsWatching[moduleId] = i
+			if after := moduleIdsWatching[moduleId]; len(after) < 2 && (len(after) == 0 || after[len(after)-1] != i) {
+				aoc.Emit(ctx, aoc.Value{Name: fmt.Sprintf("module %s high after", moduleId), Value: i})
+				moduleIdsWatching[moduleId] = append(after, i)
+				if len(after) == 1 {
+					aoc.Emit(ctx, aoc.CycleDetected{Name: fmt.Sprintf("module %s", moduleId), Tail: int(after[0]), Period: int(i - after[0])})
+				}
 			}
 		}
-		isAllDetected, afters = allModulesStepsDetected(moduleIdsWatching)
+		isAllDetected, cycles = allModulesCyclesDetected(moduleIdsWatching)
 	}
 
-	log.Printf("All modules are high after %#v steps", afters)
+	end()
 
-	minRequiredStep := int64(1)
-	for _, steps := range afters {
-		minRequiredStep = leastCommonMultiple(minRequiredStep, steps)
+	solution, errSolving := numtheory.CRT(cycles...)
+	if errSolving != nil {
+		return 0, errSolving
+	}
+	// The cycles only start after the first time every module is high.
+	var lastFirstHigh int64
+	for _, after := range moduleIdsWatching {
+		lastFirstHigh = max(lastFirstHigh, after[0])
 	}
-	return minRequiredStep
+	return solution.AtLeast(lastFirstHigh), nil
 }
 
 func loadFile() *os.File {
@@ -411,13 +423,22 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultForPart2(inputFile)
-
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   20,
+	Title: "Pulse Propagation",
+	Input: aoc.LinesMatching(`(broadcaster|[%&][a-z]+) -> [a-z]+(, [a-z]+)*`, "a module as '<type><name> -> <outputs>'"),
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultForPart1(ctx, input)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultForPart2(ctx, input)
+		return aoc.Int(result), err
+	},
+	Simulate: simulate,
+}
+
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,53 +1,58 @@
-package main
+package day20
 
 import (
+	"bytes"
+	"context"
 	"strings"
 	"testing"
-)
-
-const testingInput1 = `broadcaster -> a, b, c
-%a -> b
-%b -> c
-%c -> inv
-&inv -> a
-`
 
-const testingInput2 = `broadcaster -> a
-%a -> inv, con
-&inv -> b
-%b -> con
-&con -> output
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResultForPart1(t *testing.T) {
-	t.Run("first", func(t *testing.T) {
-		const testingExpectedResult1 = 32000000
-		result := getResultForPart1(strings.NewReader(testingInput1))
-		if result != testingExpectedResult1 {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult1, result)
-		}
-	})
-	t.Run("second", func(t *testing.T) {
-		const testingExpectedResult2 = 11687500
-		result := getResultForPart1(strings.NewReader(testingInput2))
-		if result != testingExpectedResult2 {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult2, result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResultForPart1(strings.NewReader(testingInput1))
+			getResultForPart1(context.Background(), bytes.NewReader(input))
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			getResultForPart1(inputFile)
+			getResultForPart1(context.Background(), inputFile)
 			inputFile.Close()
 		}
 	})
 }
+
+func TestSimulation(t *testing.T) {
+	simulation, err := simulate(context.Background(), 1, bytes.NewReader(aoctest.ReadInput(t, "example-2")))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !simulation.Step() {
+		t.Fatalf("Expected the button to be pushed")
+	}
+	view := simulation.View()
+	if view.Grid[0] != "##" || view.Lines[4] != "&inv [a:high] -> b" {
+		t.Errorf("Unexpected state after a push:\n%s\n%s", view.Grid[0], strings.Join(view.Lines, "\n"))
+	}
+	first := simulation.Clone()
+	for simulation.Step() {
+	}
+	if values := simulation.View().Values; values[0].Value != int64(1000) || values[3].Value != int64(11687500) {
+		t.Errorf("Expected part 1 to end after 1000 pushes with the product 11687500, got %v", values)
+	}
+	if presses := first.View().Values[0].Value; presses != int64(1) {
+		t.Errorf("Expected the clone not to be pushed along, got %v presses", presses)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -0,0 +1,146 @@
+package day20
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// pressesOfPart1 is the number of button presses counted by part 1.
+const pressesOfPart1 = 1000
+
+// pulseSimulation pushes the button once per step, for 1000 pushes in part 1
+// and without end in part 2.
+type pulseSimulation struct {
+	broadcast *Broadcast
+	modules   map[ModuleId]Module
+	sand      *Sand
+	maxPushes int64
+	presses   int64
+	low       int64
+	high      int64
+	// sentHigh are the modules which sent a high pulse to the parent of rx
+	// during the last push.
+	sentHigh []ModuleId
+}
+
+func simulate(_ context.Context, part int, input io.Reader) (aoc.Simulation, error) {
+	broadcast, modules, sand, errParsing := parseInput(input)
+	if errParsing != nil {
+		return nil, errParsing
+	}
+	simulation := &pulseSimulation{broadcast: broadcast, modules: modules, sand: sand, maxPushes: -1}
+	if part == 1 {
+		simulation.maxPushes = pressesOfPart1
+	}
+	return simulation, nil
+}
+
+func (p *pulseSimulation) Step() bool {
+	if p.presses == p.maxPushes {
+		return false
+	}
+	var watch *ModuleId
+	if p.sand.parent != "" {
+		watch = &p.sand.parent
+	}
+	low, high, sentHigh := TriggerOnce(p.broadcast, p.modules, watch)
+	p.presses++
+	p.low += low
+	p.high += high
+	p.sentHigh = sentHigh
+	return true
+}
+
+func (p *pulseSimulation) Clone() aoc.Simulation {
+	clone := *p
+	clone.modules = make(map[ModuleId]Module, len(p.modules))
+	for id, module := range p.modules {
+		switch m := module.(type) {
+		case *FlipFlop:
+			flipFlop := *m
+			clone.modules[id] = &flipFlop
+		case *Conjunction:
+			conjunction := *m
+			conjunction.alreadyReceived = maps.Clone(m.alreadyReceived)
+			clone.modules[id] = &conjunction
+		case *Sand:
+			sand := *m
+			clone.modules[id] = &sand
+			clone.sand = &sand
+		default:
+			clone.modules[id] = module
+		}
+	}
+	clone.sentHigh = append([]ModuleId(nil), p.sentHigh...)
+	return &clone
+}
+
+func joinModuleIds(ids []ModuleId) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = string(id)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (p Pulse) String() string {
+	if p == High {
+		return "high"
+	}
+	return "low"
+}
+
+// View shows the states of the flip-flops as a row of cells, on being '#',
+// then every module with its memory.
+func (p *pulseSimulation) View() aoc.View {
+	ids := make([]ModuleId, 0, len(p.modules))
+	for id := range p.modules {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var flipFlops strings.Builder
+	lines := []string{"broadcaster -> " + joinModuleIds(p.broadcast.to)}
+	for _, id := range ids {
+		switch m := p.modules[id].(type) {
+		case *FlipFlop:
+			state, cell := "off", byte('.')
+			if m.isOnState {
+				state, cell = "on", '#'
+			}
+			flipFlops.WriteByte(cell)
+			lines = append(lines, fmt.Sprintf("%%%s %s -> %s", id, state, joinModuleIds(m.to)))
+		case *Conjunction:
+			inputs := make([]ModuleId, 0, len(m.alreadyReceived))
+			for input := range m.alreadyReceived {
+				inputs = append(inputs, input)
+			}
+			sort.Slice(inputs, func(i, j int) bool { return inputs[i] < inputs[j] })
+			var memory []string
+			for _, input := range inputs {
+				memory = append(memory, fmt.Sprintf("%s:%s", input, m.alreadyReceived[input]))
+			}
+			lines = append(lines, fmt.Sprintf("&%s [%s] -> %s", id, strings.Join(memory, " "), joinModuleIds(m.to)))
+		}
+	}
+
+	return aoc.View{
+		Grid:  []string{flipFlops.String()},
+		Lines: lines,
+		Values: []aoc.Value{
+			{Name: "presses", Value: p.presses},
+			{Name: "low", Value: p.low},
+			{Name: "high", Value: p.high},
+			{Name: "product", Value: p.low * p.high},
+			{Name: "rx.low", Value: p.sand.countOfLowPulses},
+			{Name: "rx.high", Value: p.sand.countOfHighPulses},
+			{Name: "sent.high", Value: joinModuleIds(p.sentHigh)},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package day21
+
+import (
+	"io"
+	"math/rand"
+)
+
+// generateInput writes a garden of (2×size+1)² tiles like the puzzle inputs:
+// the start in the middle, on a row and a column without rocks, as are the
+// borders. The other tiles are rocks once in five. Without these clear paths
+// the count of reachable positions can take many more moves to settle into a
+// quadratic growth than the solution explores.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	side := 2*size + 1
+	tiles := make([][]byte, side)
+	for y := range tiles {
+		tiles[y] = make([]byte, side)
+		for x := range tiles[y] {
+			tiles[y][x] = '.'
+			clear := x == 0 || y == 0 || x == side-1 || y == side-1 || x == size || y == size
+			if !clear && rng.Intn(5) == 0 {
+				tiles[y][x] = '#'
+			}
+		}
+	}
+	tiles[size][size] = 'S'
+	for _, row := range tiles {
+		if _, errWriting := w.Write(append(row, '\n')); errWriting != nil {
+			return errWriting
+		}
+	}
+	return nil
+}
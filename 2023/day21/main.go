@@ -1,48 +1,53 @@
-package main
+package day21
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"image"
 	"io"
 	"log"
 	"os"
-	"strings"
-	"time"
-)
 
-type Grid [][]rune
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
+)
 
-type Position struct {
-	i int64
-	j int64
+type Grid struct {
+	grid.Grid[rune]
 }
 
-func parseInput(input io.Reader) (Grid, Position) {
-	scanner := bufio.NewScanner(input)
-
-	var grid Grid
-	var start Position
-	for i := int64(0); scanner.Scan(); i++ {
-		line := scanner.Text()
-		if idx := strings.Index(line, "S"); idx >= 0 {
-			start = Position{i, int64(idx)}
-			line = strings.Replace(line, "S", ".", 1)
+func parseInput(input io.Reader) (Grid, image.Point, error) {
+	var start image.Point
+	foundStart := false
+	g, errParsing := grid.Parse(input, func(p image.Point, char rune) (rune, error) {
+		switch char {
+		case '.', '#':
+			return char, nil
+		case 'S':
+			if foundStart {
+				return 0, aoc.Unexpected("S", "a single starting position")
+			}
+			start, foundStart = p, true
+			return '.', nil
 		}
-		grid = append(grid, []rune(line))
+		return 0, aoc.Unexpected(string(char), "a garden plot '.', a rock '#' or the start 'S'")
+	})
+	if errParsing != nil {
+		return Grid{}, image.Point{}, errParsing
 	}
-
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+	if !foundStart {
+		return Grid{}, image.Point{}, &aoc.ParseError{Line: g.Height() + 1, Err: io.ErrUnexpectedEOF, Expected: "a starting position 'S'"}
 	}
-
-	return grid, start
+	return Grid{g}, start, nil
 }
 
-func (g Grid) isValidMove(position Position) bool {
-	rows, cols := int64(len(g)), int64(len(g[0]))
-	return position.i >= 0 && position.i < rows && position.j >= 0 && position.j < cols && g[position.i][position.j] == '.'
+func (g Grid) isValidMove(position image.Point) bool {
+	tile, ok := g.Get(position)
+	return ok && tile == '.'
 }
 
-func modGrid(idx, length int64) int64 {
+func modGrid(idx, length int) int {
 	m := idx % length
 	if m < 0 {
 		m += length
@@ -50,102 +55,171 @@ func modGrid(idx, length int64) int64 {
 	return m
 }
 
-func (p Position) modulo(g Grid) Position {
-	return Position{
-		i: modGrid(p.i, int64(len(g))),
-		j: modGrid(p.j, int64(len(g[0]))),
-	}
+func modulo(p image.Point, g Grid) image.Point {
+	return image.Pt(modGrid(p.X, g.Width()), modGrid(p.Y, g.Height()))
 }
 
-func (g Grid) countReachablePositions(start Position, moves int, infiniteGrid bool) int64 {
-	directions := []Position{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
-
-	exploration := []Position{start}
-	prevExplorationSize := 0
+// countReachablePositions explores the positions reachable after each move,
+// up to maxCompute moves. The positions reachable after i moves are the ones
+// at most i moves away with the parity of i, the elf stepping back and forth,
+// so the BFS only explores the positions first reached at each move.
+//
+// Past maxCompute moves, the count of positions is extrapolated: it eventually
+// grows along a quadratic every period moves, so that its second differences
+// taken period moves apart repeat. The period is the smallest one for which
+// they do on the last third of the explored moves.
+func (g Grid) countReachablePositions(ctx context.Context, start image.Point, moves int, infiniteGrid bool, maxCompute int) (int64, error) {
+	checker := aoc.NewChecker(ctx, 10000)
+	seen := map[image.Point]bool{start: true}
+	frontier := []image.Point{start}
 
-	var values []int
-	var delta1 []int
-	var delta2 []int
-
-	maxCompute := 1000
+	// values[i] is the count of positions reachable after i moves, and
+	// reached the count of positions seen at each parity.
+	values := []int{1}
+	reached := [2]int{1, 0}
 
 	// BFS
-	movesLeft := moves
-	for i := 0; i < maxCompute && movesLeft > 0; i++ {
-		nextUniqueExploration := make(map[Position]bool)
-		for i := 0; i < len(exploration); i++ {
-			current := exploration[i]
-			for _, dir := range directions {
-				next := Position{current.i + dir.i, current.j + dir.j}
+	for len(values) <= min(moves, maxCompute) {
+		var nextFrontier []image.Point
+		for _, current := range frontier {
+			if checker.Canceled() {
+				return 0, aoc.Canceled(ctx, aoc.Answer{})
+			}
+			for _, dir := range grid.Directions4 {
+				next := current.Add(dir)
+				if seen[next] {
+					continue
+				}
 				var isValid bool
 				if infiniteGrid {
-					isValid = g.isValidMove(next.modulo(g))
+					isValid = g.isValidMove(modulo(next, g))
 				} else {
 					isValid = g.isValidMove(next)
 				}
 				if isValid {
-					nextUniqueExploration[next] = true
+					seen[next] = true
+					nextFrontier = append(nextFrontier, next)
 				}
 			}
 		}
-		nextExploration := make([]Position, 0, len(nextUniqueExploration))
-		for next := range nextUniqueExploration {
-			nextExploration = append(nextExploration, next)
-		}
-		newExplorationSize := len(nextExploration)
-		prevExplorationSize = len(exploration)
-		exploration = nextExploration
-		movesLeft--
-
-		values = append(values, newExplorationSize)
-		delta1 = append(delta1, newExplorationSize-prevExplorationSize)
-		if len(delta1) > len(g) {
-			delta2 = append(delta2, delta1[len(delta1)-1]-delta1[len(delta1)-1-len(g)])
-		} else {
-			delta2 = append(delta2, 0)
+		// Without a plot around the start, the elf can't move at all.
+		if len(seen) == 1 {
+			reached[0] = 0
 		}
+		frontier = nextFrontier
+		reached[len(values)%2] += len(frontier)
+		values = append(values, reached[len(values)%2])
 	}
 
-	if movesLeft <= 0 {
-		return int64(len(exploration))
+	if moves < len(values) {
+		return int64(values[moves]), nil
 	}
 
-	restartAt := maxCompute - len(g)
+	period, found := findPeriod(values)
+	if !found {
+		return 0, fmt.Errorf("%w in %d moves", errNoPeriod, maxCompute)
+	}
 
-	values = values[:restartAt]
-	delta1 = delta1[:restartAt]
-	delta2 = delta2[:restartAt]
+	// Extrapolate from the last explored move n with as many moves left as a
+	// multiple k of the period: the count then grows by delta1 plus j times
+	// delta2 on the j-th period.
+	n := len(values) - period + (moves-len(values)+period)%period
+	k := int64((moves - n) / period)
+	delta1 := int64(values[n] - values[n-period])
+	delta2 := int64(secondDifference(values, n, period))
+	return int64(values[n]) + k*delta1 + delta2*k*(k+1)/2, nil
+}
 
-	result := values[len(values)-1]
-	for i := restartAt + 1; i < moves+1; i++ {
-		delta2ForI := delta2[i-len(g)-1]
-		delta1ForI := delta1[i-len(g)-1] + delta2ForI
-		result += delta1ForI
+var errNoPeriod = errors.New("no period of the count of reachable positions")
 
-		delta1 = append(delta1, delta1ForI)
-		delta2 = append(delta2, delta2ForI)
+// secondDifference returns the difference between the growth of the values
+// on the period ending at n and on the one before.
+func secondDifference(values []int, n, period int) int {
+	return values[n] - 2*values[n-period] + values[n-2*period]
+}
+
+// findPeriod returns the smallest period whose second differences repeat on
+// the last third of the values.
+func findPeriod(values []int) (int, bool) {
+	window := len(values) / 3
+	for period := 1; 3*period <= len(values)-window; period++ {
+		repeating := true
+		for n := len(values) - window; n < len(values) && repeating; n++ {
+			repeating = secondDifference(values, n, period) == secondDifference(values, n-period, period)
+		}
+		if repeating {
+			return period, true
+		}
 	}
+	return 0, false
+}
 
-	return int64(result)
+// countReachablePositionsNaive walks the whole distance from the start of
+// every position at most moves away, as the reference of
+// countReachablePositions. The reachable positions are the ones at the same
+// parity as the moves, since the elf can step back and forth.
+func (g Grid) countReachablePositionsNaive(ctx context.Context, start image.Point, moves int, infiniteGrid bool) (int64, error) {
+	checker := aoc.NewChecker(ctx, 10000)
+	distances := map[image.Point]int{start: 0}
+	queue := []image.Point{start}
+	var count int64
+	for len(queue) > 0 {
+		if checker.Canceled() {
+			return 0, aoc.Canceled(ctx, aoc.Answer{})
+		}
+		current := queue[0]
+		queue = queue[1:]
+		distance := distances[current]
+		if distance%2 == moves%2 {
+			count++
+		}
+		if distance == moves {
+			continue
+		}
+		for _, dir := range grid.Directions4 {
+			next := current.Add(dir)
+			if _, seen := distances[next]; seen {
+				continue
+			}
+			if infiniteGrid && g.isValidMove(modulo(next, g)) || !infiniteGrid && g.isValidMove(next) {
+				distances[next] = distance + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	// Without a plot around the start, the elf can't move at all.
+	if moves > 0 && len(distances) == 1 {
+		return 0, nil
+	}
+	return count, nil
 }
 
-func (g Grid) String() string {
-	var sb strings.Builder
-	for _, line := range g {
-		sb.WriteString(string(line))
-		sb.WriteString("\n")
+// defaultMaxCompute is the number of moves explored before extrapolating the
+// count of positions, which the "rounds" parameter overrides.
+const defaultMaxCompute = 1000
+
+func getResultPart1(ctx context.Context, input io.Reader, moves int) (int64, error) {
+	grid, start, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
 	}
-	return sb.String()
+	return grid.countReachablePositions(ctx, start, moves, false, defaultMaxCompute)
 }
 
-func getResultPart1(input io.Reader, moves int) int64 {
-	grid, start := parseInput(input)
-	return grid.countReachablePositions(start, moves, false)
+func getResultPart2(ctx context.Context, input io.Reader, moves, maxCompute int) (int64, error) {
+	grid, start, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	return grid.countReachablePositions(ctx, start, moves, true, maxCompute)
 }
 
-func getResultPart2(input io.Reader, moves int) int64 {
-	grid, start := parseInput(input)
-	return grid.countReachablePositions(start, moves, true)
+func getResultNaive(ctx context.Context, input io.Reader, moves int, infiniteGrid bool) (int64, error) {
+	grid, start, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	return grid.countReachablePositionsNaive(ctx, start, moves, infiniteGrid)
 }
 
 func loadFile() *os.File {
@@ -156,13 +230,50 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultPart2(inputFile, 26501365)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   21,
+	Title: "Step Counter",
+	Input: aoc.Grid{Alphabet: ".#S", Counts: map[rune]int{'S': 1}},
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		moves, errParam := aoc.ParamPositiveInt(ctx, "steps", 64)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResultPart1(ctx, input, moves)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		moves, errParam := aoc.ParamPositiveInt(ctx, "steps", 26501365)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		maxCompute, errParam := aoc.ParamPositiveInt(ctx, "rounds", defaultMaxCompute)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResultPart2(ctx, input, moves, maxCompute)
+		return aoc.Int(result), err
+	},
+	Oracle1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		moves, errParam := aoc.ParamPositiveInt(ctx, "steps", 64)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResultNaive(ctx, input, moves, false)
+		return aoc.Int(result), err
+	},
+	Oracle2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		moves, errParam := aoc.ParamPositiveInt(ctx, "steps", 26501365)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResultNaive(ctx, input, moves, true)
+		return aoc.Int(result), err
+	},
+	Generate: generateInput,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
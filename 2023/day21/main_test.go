@@ -1,52 +1,56 @@
-package main
+package day21
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-const testingInput = `...........
-.....###.#.
-.###.##..#.
-..#.#...#..
-....#.#....
-.##..S####.
-.##..#...#.
-.......##..
-.##.#.####.
-.##..##.##.
-...........
-`
-
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		const testingExpectedResult = 16
-		result := getResultPart1(strings.NewReader(testingInput), 6)
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
-	t.Run("part2", func(t *testing.T) {
-		const testingExpectedResult = 16733044
-		result := getResultPart2(strings.NewReader(testingInput), 5000)
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
+func TestCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := Solver.SolveContext(ctx, 2, strings.NewReader("S\n"))
+	var partialErr *aoc.PartialError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("Expected the part to be stopped, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the part to stop soon after its timeout, took %s", elapsed)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
+
+func TestDifferential(t *testing.T) {
+	aoctest.Differential(t, Solver, aoc.Params{"steps": "150", "rounds": "80"}, 1, 2, 4)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResultPart1(strings.NewReader(testingInput), 6)
+			getResultPart1(context.Background(), bytes.NewReader(input), 6)
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			getResultPart1(inputFile, 64)
+			getResultPart1(context.Background(), inputFile, 64)
 			inputFile.Close()
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
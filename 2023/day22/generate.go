@@ -0,0 +1,39 @@
+package day22
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generateFootprint is the width and depth of the generated towers, the one
+// of the real inputs.
+const generateFootprint = 10
+
+// generateInput writes size bricks of one to four cubes, at random places of
+// the footprint and heights up to twice their number, without overlapping.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	plan := NewPlan()
+	for len(plan.bricks) < size {
+		from := Position{x: rng.Intn(generateFootprint), y: rng.Intn(generateFootprint), z: 1 + rng.Intn(2*size)}
+		to := from
+		length := rng.Intn(4)
+		switch rng.Intn(3) {
+		case 0:
+			to.x = min(from.x+length, generateFootprint-1)
+		case 1:
+			to.y = min(from.y+length, generateFootprint-1)
+		default:
+			to.z += length
+		}
+		brick := &Brick{from: from, to: to}
+		if plan.Overlaps(brick) {
+			continue
+		}
+		plan.Add(brick)
+		if _, errWriting := fmt.Fprintf(w, "%d,%d,%d~%d,%d,%d\n", from.x, from.y, from.z, to.x, to.y, to.z); errWriting != nil {
+			return errWriting
+		}
+	}
+	return nil
+}
@@ -1,14 +1,14 @@
-package main
+package day22
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"slices"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Position struct {
@@ -64,6 +64,9 @@ func (p *Plan) CopyWithout(removedBrick *Brick) *Plan {
 	return newPlan
 }
 
+// Add places the brick in the plan, which must be free at its positions: parsed
+// bricks are checked with Overlaps first, the other ones are moved to free
+// positions.
 func (p *Plan) Add(brick *Brick) {
 	p.bricks = append(p.bricks, brick)
 	if brick.to.x > p.maxX {
@@ -84,9 +87,6 @@ func (p *Plan) Add(brick *Brick) {
 				p.positions[x][y] = make(map[int]*Brick)
 			}
 			for z := brick.from.z; z <= brick.to.z; z++ {
-				if p.positions[x][y][z] != nil {
-					log.Fatalf("Brick with ID %s cannot be added to the plan, another brick is already there at position  %d,%d,%d", string(brick.id), x, y, z)
-				}
 				p.positions[x][y][z] = brick
 			}
 		}
@@ -106,6 +106,19 @@ func (p *Plan) GetBrickAt(position Position) *Brick {
 	return p.positions[position.x][position.y][position.z]
 }
 
+func (p *Plan) Overlaps(brick *Brick) bool {
+	for x := brick.from.x; x <= brick.to.x; x++ {
+		for y := brick.from.y; y <= brick.to.y; y++ {
+			for z := brick.from.z; z <= brick.to.z; z++ {
+				if p.GetBrickAt(Position{x: x, y: y, z: z}) != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (p *Plan) PlanXZ() string {
 	var result strings.Builder
 	for z := p.maxZ; z > 0; z-- {
@@ -282,55 +295,67 @@ func (p *Plan) CountBricksFallIfDisintegrated() int {
 	return totalBricksFall
 }
 
-func parseCoordinates(coordinates string) Position {
+func parseCoordinates(coordinates string) (Position, error) {
 	coords := strings.Split(coordinates, ",")
 	if len(coords) != 3 {
-		log.Fatalf("Invalid coordinates: %s", coordinates)
+		return Position{}, aoc.Unexpected(coordinates, "'<x>,<y>,<z>'")
 	}
-	x, errParsingX := strconv.Atoi(coords[0])
+	x, errParsingX := aoc.Atoi(coords[0])
 	if errParsingX != nil {
-		log.Fatalf("Invalid X coordinate: %s", coords[0])
+		return Position{}, errParsingX
 	}
-	y, errParsingY := strconv.Atoi(coords[1])
+	y, errParsingY := aoc.Atoi(coords[1])
 	if errParsingY != nil {
-		log.Fatalf("Invalid Y coordinate: %s", coords[1])
+		return Position{}, errParsingY
 	}
-	z, errParsingZ := strconv.Atoi(coords[2])
+	z, errParsingZ := aoc.Atoi(coords[2])
 	if errParsingZ != nil {
-		log.Fatalf("Invalid Z coordinate: %s", coords[2])
+		return Position{}, errParsingZ
 	}
 	return Position{
 		x: x,
 		y: y,
 		z: z,
-	}
+	}, nil
 }
 
-func parseBrick(line string) *Brick {
+func parseBrick(line string) (*Brick, error) {
 	coords := strings.Split(line, "~")
 	if len(coords) != 2 {
-		log.Fatalf("Invalid brick line: %s", line)
+		return nil, aoc.Unexpected(line, "'<x>,<y>,<z>~<x>,<y>,<z>'")
+	}
+	from, errParsingFrom := parseCoordinates(coords[0])
+	if errParsingFrom != nil {
+		return nil, errParsingFrom
+	}
+	to, errParsingTo := parseCoordinates(coords[1])
+	if errParsingTo != nil {
+		return nil, errParsingTo
 	}
-	from := parseCoordinates(coords[0])
-	to := parseCoordinates(coords[1])
 	if from.x > to.x || from.y > to.y || from.z > to.z {
-		log.Fatalf("Invalid brick line: %s", line)
+		return nil, aoc.Unexpected(coords[1], "an end not lower than the start "+coords[0])
 	}
 	return &Brick{
-		from: parseCoordinates(coords[0]),
-		to:   parseCoordinates(coords[1]),
-	}
+		from: from,
+		to:   to,
+	}, nil
 }
 
 const allowedBrickChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
 
-func parseInput(input io.Reader) *Plan {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (*Plan, error) {
+	scanner := aoc.NewScanner(input)
 
 	plan := NewPlan()
 	brickId := 0
 	for scanner.Scan() {
-		brick := parseBrick(scanner.Text())
+		brick, errParsing := parseBrick(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		if plan.Overlaps(brick) {
+			return nil, scanner.Unexpected("a brick not overlapping the previous ones")
+		}
 		brick.id = rune(allowedBrickChars[brickId])
 		brickId++
 		if brickId >= len(allowedBrickChars) {
@@ -340,22 +365,28 @@ func parseInput(input io.Reader) *Plan {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return plan
+	return plan, nil
 }
 
-func getResultPart1(input io.Reader) int {
-	plan := parseInput(input)
+func getResultPart1(input io.Reader) (int, error) {
+	plan, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	stabilizedPlan, _ := plan.Stabilize()
-	return stabilizedPlan.CountPossibleBricksToDisintegrate()
+	return stabilizedPlan.CountPossibleBricksToDisintegrate(), nil
 }
 
-func getResultPart2(input io.Reader) int {
-	plan := parseInput(input)
+func getResultPart2(input io.Reader) (int, error) {
+	plan, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	stabilizedPlan, _ := plan.Stabilize()
-	return stabilizedPlan.CountBricksFallIfDisintegrated()
+	return stabilizedPlan.CountBricksFallIfDisintegrated(), nil
 }
 
 func loadFile() *os.File {
@@ -366,13 +397,22 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultPart2(inputFile)
+var Solver = aoc.Solver{
+	Year:     2023,
+	Day:      22,
+	Title:    "Sand Slabs",
+	Input:    aoc.LinesMatching(`\d+,\d+,\d+~\d+,\d+,\d+`, "a brick as '<x>,<y>,<z>~<x>,<y>,<z>'"),
+	Generate: generateInput,
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart1(input)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart2(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
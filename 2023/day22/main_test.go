@@ -1,40 +1,25 @@
-package main
+package day22
 
 import (
-	"strings"
+	"bytes"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-const testingInput = `1,0,1~1,2,1
-0,0,2~2,0,2
-0,2,3~2,2,3
-0,0,4~0,2,4
-2,0,5~2,2,5
-0,1,6~2,1,6
-1,1,8~1,1,9
-`
+func TestGenerated(t *testing.T) {
+	aoctest.Generated(t, Solver, 1, 10, 100)
+}
 
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		const testingExpectedResult = 5
-		result := getResultPart1(strings.NewReader(testingInput))
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
-	t.Run("part2", func(t *testing.T) {
-		const testingExpectedResult = 7
-		result := getResultPart2(strings.NewReader(testingInput))
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResultPart1(strings.NewReader(testingInput))
+			getResultPart1(bytes.NewReader(input))
 		}
 	})
 
@@ -46,3 +31,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
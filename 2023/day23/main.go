@@ -1,13 +1,16 @@
-package main
+package day23
 
 import (
-	"bufio"
-	"fmt"
+	"context"
+	"image"
 	"io"
 	"log"
 	"os"
 	"slices"
-	"time"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
 )
 
 type Direction int
@@ -19,38 +22,30 @@ const (
 	West  Direction = iota
 )
 
-type Position struct {
-	i int
-	j int
+var directionOffsets = [...]image.Point{
+	North: {0, -1},
+	South: {0, 1},
+	East:  {1, 0},
+	West:  {-1, 0},
 }
 
-func (p Position) Move(direction Direction) Position {
-	switch direction {
-	case North:
-		return Position{p.i - 1, p.j}
-	case South:
-		return Position{p.i + 1, p.j}
-	case East:
-		return Position{p.i, p.j + 1}
-	case West:
-		return Position{p.i, p.j - 1}
-	}
-	log.Fatalf("Unknown direction: %d", direction)
-	return p
+type Position = image.Point
+
+func move(p Position, direction Direction) Position {
+	return p.Add(directionOffsets[direction])
 }
 
-type Grid [][]rune
+type Grid struct {
+	grid.Grid[rune]
+}
 
 func (g Grid) isValidMove(position Position) bool {
-	return position.i >= 0 &&
-		position.i < len(g) &&
-		position.j >= 0 &&
-		position.j < len(g[position.i]) &&
-		g[position.i][position.j] != '#'
+	tile, ok := g.Get(position)
+	return ok && tile != '#'
 }
 
 func (g Grid) isSlopeWithDirection(position Position) (bool, Direction) {
-	switch g[position.i][position.j] {
+	switch g.At(position) {
 	case 'v':
 		return true, South
 	case '>':
@@ -64,18 +59,11 @@ func (g Grid) isSlopeWithDirection(position Position) (bool, Direction) {
 }
 
 func (g Grid) String(positions ...Position) string {
-	var result string
-	for i, row := range g {
-		for j, cell := range row {
-			if slices.Contains(positions, Position{i, j}) {
-				result += "O"
-			} else {
-				result += fmt.Sprintf("%c", cell)
-			}
-		}
-		result += "\n"
+	path := g.Clone()
+	for _, position := range positions {
+		path.Set(position, 'O')
 	}
-	return result
+	return path.String()
 }
 
 type Link struct {
@@ -92,21 +80,18 @@ type Graph map[Position]*Node
 
 func (g Grid) Graph() Graph {
 	graph := make(Graph)
-	for i, row := range g {
-		for j, cell := range row {
-			position := Position{i, j}
-			if cell != '#' {
-				graph[position] = &Node{
-					position:  position,
-					neighbors: make([]Link, 0),
-				}
+	g.Each(func(position Position, cell rune) {
+		if cell != '#' {
+			graph[position] = &Node{
+				position:  position,
+				neighbors: make([]Link, 0),
 			}
 		}
-	}
+	})
 
 	for _, node := range graph {
 		for _, direction := range []Direction{North, South, East, West} {
-			if newNeighbor := node.position.Move(direction); g.isValidMove(newNeighbor) {
+			if newNeighbor := move(node.position, direction); g.isValidMove(newNeighbor) {
 				node.neighbors = append(node.neighbors, Link{
 					to:   graph[newNeighbor],
 					cost: 1,
@@ -159,16 +144,22 @@ func (g Grid) Graph() Graph {
 	return graph
 }
 
-func (g Graph) GetHighestPath(start, end Position, initialPathLength int64, visited []Position) int64 {
+// GetHighestPath returns the length of the longest path to end, or -1 when
+// there is none. Once the checker is canceled, it returns the longest path
+// found so far.
+func (g Graph) GetHighestPath(start, end Position, initialPathLength int64, visited []Position, checker *aoc.Checker) int64 {
 	if start == end {
 		return initialPathLength
 	}
 	maxLength := int64(-1)
 	for _, neighbor := range g[start].neighbors {
+		if checker.Canceled() {
+			break
+		}
 		if slices.Contains(visited, neighbor.to.position) {
 			continue
 		}
-		pathLength := g.GetHighestPath(neighbor.to.position, end, initialPathLength+neighbor.cost, append(visited, neighbor.to.position))
+		pathLength := g.GetHighestPath(neighbor.to.position, end, initialPathLength+neighbor.cost, append(visited, neighbor.to.position), checker)
 		if pathLength > maxLength {
 			maxLength = pathLength
 		}
@@ -193,7 +184,7 @@ func StartStep(position Position) *Step {
 }
 
 func (s *Step) Move(grid Grid, direction Direction) (*Step, bool) {
-	newPosition := s.position.Move(direction)
+	newPosition := move(s.position, direction)
 
 	if !grid.isValidMove(newPosition) {
 		return nil, false
@@ -231,7 +222,9 @@ func (s *Step) GetPath() []Position {
 	return append(s.previousPositions, s.position)
 }
 
-func (g Grid) GetHighestPath(start, end Position) *Step {
+// GetHighestPath returns the longest path to end, nil when there is none.
+// Once the checker is canceled, it returns the longest path found so far.
+func (g Grid) GetHighestPath(start, end Position, checker *aoc.Checker) *Step {
 	allowedMoves := []Direction{North, South, East, West}
 
 	var stepQueue []*Step
@@ -239,7 +232,7 @@ func (g Grid) GetHighestPath(start, end Position) *Step {
 
 	var highestPath *Step
 
-	for len(stepQueue) > 0 {
+	for len(stepQueue) > 0 && !checker.Canceled() {
 		step := stepQueue[0]
 		stepQueue = stepQueue[1:]
 		if step.position == end {
@@ -259,34 +252,86 @@ func (g Grid) GetHighestPath(start, end Position) *Step {
 	return highestPath
 }
 
-func parseInput(input io.Reader) Grid {
-	scanner := bufio.NewScanner(input)
-
-	var grid Grid
-	for scanner.Scan() {
-		grid = append(grid, []rune(scanner.Text()))
+func parseInput(input io.Reader) (Grid, Position, Position, error) {
+	g, errParsing := grid.Parse(input, func(p image.Point, char rune) (rune, error) {
+		if !strings.ContainsRune(".#^>v<", char) {
+			return 0, aoc.Unexpected(string(char), "a tile among '.', '#', '^', '>', 'v' or '<'")
+		}
+		return char, nil
+	})
+	if errParsing != nil {
+		return Grid{}, Position{}, Position{}, errParsing
 	}
 
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+	if g.Height() < 2 || g.Width() < 2 {
+		return Grid{}, Position{}, Position{}, &aoc.ParseError{Line: g.Height() + 1, Err: io.ErrUnexpectedEOF, Expected: "a map of at least two lines"}
+	}
+	start, errStart := opening(g, 0, "start")
+	if errStart != nil {
+		return Grid{}, Position{}, Position{}, errStart
+	}
+	end, errEnd := opening(g, g.Height()-1, "end")
+	if errEnd != nil {
+		return Grid{}, Position{}, Position{}, errEnd
 	}
 
-	return grid
+	return Grid{g}, start, end, nil
 }
 
-func getResultPart1(input io.Reader) int {
-	grid := parseInput(input)
-	start := Position{0, 1}
-	end := Position{len(grid) - 1, len(grid[len(grid)-1]) - 2}
-	return grid.GetHighestPath(start, end).PathLength()
+// opening returns the single path tile of the row y, the rest of the row being
+// forest.
+func opening(g grid.Grid[rune], y int, name string) (Position, error) {
+	var row strings.Builder
+	found := false
+	var position Position
+	for x := range g.Width() {
+		tile := g.At(image.Pt(x, y))
+		row.WriteRune(tile)
+		if tile == '#' {
+			continue
+		}
+		if tile != '.' || found {
+			return Position{}, &aoc.ParseError{Line: y + 1, Column: x + 1, Text: string(tile), Expected: "the forest around the " + name + " path tile"}
+		}
+		found, position = true, image.Pt(x, y)
+	}
+	if !found {
+		return Position{}, &aoc.ParseError{Line: y + 1, Text: row.String(), Expected: "a row with the " + name + " path tile"}
+	}
+	return position, nil
+}
+
+func getResultPart1(ctx context.Context, input io.Reader) (int, error) {
+	grid, start, end, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	checker := aoc.NewChecker(ctx, 1000)
+	highestPath := grid.GetHighestPath(start, end, checker)
+	if checker.Canceled() {
+		if highestPath == nil {
+			return 0, aoc.Canceled(ctx, aoc.Answer{})
+		}
+		return 0, aoc.Canceled(ctx, aoc.Int(highestPath.PathLength()))
+	}
+	return highestPath.PathLength(), nil
 }
 
-func getResultPart2(input io.Reader) int64 {
-	grid := parseInput(input)
+func getResultPart2(ctx context.Context, input io.Reader) (int64, error) {
+	grid, start, end, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	graph := grid.Graph()
-	start := Position{0, 1}
-	end := Position{len(grid) - 1, len(grid[len(grid)-1]) - 2}
-	return graph.GetHighestPath(start, end, 0, []Position{start})
+	checker := aoc.NewChecker(ctx, 1000)
+	highestPath := graph.GetHighestPath(start, end, 0, []Position{start}, checker)
+	if checker.Canceled() {
+		if highestPath < 0 {
+			return 0, aoc.Canceled(ctx, aoc.Answer{})
+		}
+		return 0, aoc.Canceled(ctx, aoc.Int(highestPath))
+	}
+	return highestPath, nil
 }
 
 func loadFile() *os.File {
@@ -297,13 +342,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResultPart2(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   23,
+	Title: "A Long Walk",
+	Input: aoc.Grid{Alphabet: ".#^>v<"},
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart1(ctx, input)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultPart2(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
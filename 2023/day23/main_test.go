@@ -1,64 +1,34 @@
-package main
+package day23
 
 import (
-	"strings"
+	"bytes"
+	"context"
 	"testing"
-)
 
-const testingInput = `#.#####################
-#.......#########...###
-#######.#########.#.###
-###.....#.>.>.###.#.###
-###v#####.#v#.###.#.###
-###.>...#.#.#.....#...#
-###v###.#.#.#########.#
-###...#.#.#.......#...#
-#####.#.#.#######.#.###
-#.....#.#.#.......#...#
-#.#####.#.#.#########v#
-#.#...#...#...###...>.#
-#.#.#v#######v###.###v#
-#...#.>.#...>.>.#.###.#
-#####v#.#.###v#.#.###.#
-#.....#...#...#.#.#...#
-#.#########.###.#.#.###
-#...###...#...#...#.###
-###.###.#.###v#####v###
-#...#...#.#.>.>.#.>.###
-#.###.###.#.###.#.#v###
-#.....###...###...#...#
-#####################.#
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		const testingExpectedResult = 94
-		result := getResultPart1(strings.NewReader(testingInput))
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
-	t.Run("part2", func(t *testing.T) {
-		const testingExpectedResult = 154
-		result := getResultPart2(strings.NewReader(testingInput))
-		if result != testingExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResultPart1(strings.NewReader(testingInput))
+			getResultPart1(context.Background(), bytes.NewReader(input))
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			getResultPart1(inputFile)
+			getResultPart1(context.Background(), inputFile)
 			inputFile.Close()
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
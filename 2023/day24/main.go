@@ -1,13 +1,17 @@
-package main
+package day24
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/linalg"
 )
 
 type Coordinates struct {
@@ -16,50 +20,6 @@ type Coordinates struct {
 	z float64
 }
 
-func (c Coordinates) Add(other Coordinates) Coordinates {
-	return Coordinates{
-		x: c.x + other.x,
-		y: c.y + other.y,
-		z: c.z + other.z,
-	}
-}
-
-func (c Coordinates) Multiply(scalar float64) Coordinates {
-	return Coordinates{
-		x: c.x * scalar,
-		y: c.y * scalar,
-		z: c.z * scalar,
-	}
-}
-
-func (c Coordinates) Subtract(other Coordinates) Coordinates {
-	return Coordinates{
-		x: c.x - other.x,
-		y: c.y - other.y,
-		z: c.z - other.z,
-	}
-}
-
-func (c Coordinates) Divide(scalar float64) Coordinates {
-	return Coordinates{
-		x: c.x / scalar,
-		y: c.y / scalar,
-		z: c.z / scalar,
-	}
-}
-
-func (c Coordinates) CrossProduct(other Coordinates) Coordinates {
-	return Coordinates{
-		x: c.y*other.z - c.z*other.y,
-		y: c.z*other.x - c.x*other.z,
-		z: c.x*other.y - c.y*other.x,
-	}
-}
-
-func (c Coordinates) DotProduct(other Coordinates) float64 {
-	return c.x*other.x + c.y*other.y + c.z*other.z
-}
-
 type Zone struct {
 	min Coordinates
 	max Coordinates
@@ -90,6 +50,17 @@ func (t Trajectory) IsPossibleXY(x float64, y float64) bool {
 	return true
 }
 
+var puzzleTestZone = Zone{
+	min: Coordinates{
+		x: 200000000000000,
+		y: 200000000000000,
+	},
+	max: Coordinates{
+		x: 400000000000000,
+		y: 400000000000000,
+	},
+}
+
 func (t Trajectory) IsXYCollidingWith(other Trajectory, in Zone) bool {
 	// Initial point and vector to line equation y = a x + b:
 	// x = x0 + vx t
@@ -152,61 +123,76 @@ func (t Trajectory) IsXYCollidingWith(other Trajectory, in Zone) bool {
 	return t.IsPossibleXY(commonX, commonY) && other.IsPossibleXY(commonX, commonY)
 }
 
-func parseCoordinates(coordinates string) Coordinates {
+func parseCoordinates(coordinates string) (Coordinates, error) {
 	parts := strings.Split(coordinates, ",")
 	if len(parts) != 3 {
-		log.Fatalf("Unable to parse coordinates: %s", coordinates)
+		return Coordinates{}, aoc.Unexpected(coordinates, "'<x>, <y>, <z>'")
 	}
 	xStr := strings.TrimSpace(parts[0])
 	x, errParsingX := strconv.ParseFloat(xStr, 64)
 	if errParsingX != nil {
-		log.Fatalf("Unable to parse x coordinate: %s", xStr)
+		return Coordinates{}, aoc.Unexpected(xStr, "a number")
 	}
 	yStr := strings.TrimSpace(parts[1])
 	y, errParsingY := strconv.ParseFloat(yStr, 64)
 	if errParsingY != nil {
-		log.Fatalf("Unable to parse y coordinate: %s", yStr)
+		return Coordinates{}, aoc.Unexpected(yStr, "a number")
 	}
 	zStr := strings.TrimSpace(parts[2])
 	z, errParsingZ := strconv.ParseFloat(zStr, 64)
 	if errParsingZ != nil {
-		log.Fatalf("Unable to parse z coordinate: %s", zStr)
+		return Coordinates{}, aoc.Unexpected(zStr, "a number")
 	}
 	return Coordinates{
 		x: x,
 		y: y,
 		z: z,
-	}
+	}, nil
 }
 
-func parseLine(line string) Trajectory {
+func parseLine(line string) (Trajectory, error) {
 	coordinates := strings.Split(line, "@")
 	if len(coordinates) != 2 {
-		log.Fatalf("Unable to parse line: %s", line)
+		return Trajectory{}, aoc.Unexpected(line, "'<position> @ <velocity>'")
 	}
-	return Trajectory{
-		position: parseCoordinates(coordinates[0]),
-		velocity: parseCoordinates(coordinates[1]),
+	position, errParsingPosition := parseCoordinates(coordinates[0])
+	if errParsingPosition != nil {
+		return Trajectory{}, errParsingPosition
+	}
+	velocity, errParsingVelocity := parseCoordinates(coordinates[1])
+	if errParsingVelocity != nil {
+		return Trajectory{}, errParsingVelocity
 	}
+	return Trajectory{
+		position: position,
+		velocity: velocity,
+	}, nil
 }
 
-func parseInput(input io.Reader) []Trajectory {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]Trajectory, error) {
+	scanner := aoc.NewScanner(input)
 
 	var hailstones []Trajectory
 	for scanner.Scan() {
-		hailstones = append(hailstones, parseLine(scanner.Text()))
+		hailstone, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		hailstones = append(hailstones, hailstone)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return hailstones
+	return hailstones, nil
 }
 
-func GetResultPart1(input io.Reader, testZone Zone) int64 {
-	hailstones := parseInput(input)
+func GetResultPart1(input io.Reader, testZone Zone) (int64, error) {
+	hailstones, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var count int64
 	for i := 0; i < len(hailstones); i++ {
@@ -217,57 +203,82 @@ func GetResultPart1(input io.Reader, testZone Zone) int64 {
 		}
 	}
 
-	return count
+	return count, nil
 }
 
-func planeLineIntersection(p0, n, p, v Coordinates) (Coordinates, float64) {
-	denominator := v.DotProduct(n)
-	numerator := p0.Subtract(p).DotProduct(n)
-	t := numerator / denominator
-	intersection := p.Add(v.Multiply(t))
-	return intersection, t
-}
+// Vector is a 3D vector of exact coordinates.
+type Vector [3]linalg.Rat
 
-func reduceHailstonesPositions(hailstones []Trajectory) []Trajectory {
-	result := make([]Trajectory, len(hailstones))
-	for i := 0; i < len(hailstones); i++ {
-		result[i] = Trajectory{
-			position: Coordinates{
-				x: hailstones[i].position.x / 1e12,
-				y: hailstones[i].position.y / 1e12,
-				z: hailstones[i].position.z / 1e12,
-			},
-			velocity: hailstones[i].velocity,
-		}
-	}
-	return result
+func (c Coordinates) Exact() Vector {
+	return Vector{linalg.Int(int64(c.x)), linalg.Int(int64(c.y)), linalg.Int(int64(c.z))}
 }
 
-// https://en.wikipedia.org/wiki/Line%E2%80%93plane_intersection
-func GetResultPart2(input io.Reader) int64 {
-	originalHailstones := parseInput(input)
-	hailstones := reduceHailstonesPositions(originalHailstones)
-
-	p0, v0 := hailstones[0].position, hailstones[0].velocity
-	p1, v1 := hailstones[1].position.Subtract(p0), hailstones[1].velocity.Subtract(v0)
-	p2, v2 := hailstones[2].position.Subtract(p0), hailstones[2].velocity.Subtract(v0)
-	p3, v3 := hailstones[3].position.Subtract(p0), hailstones[3].velocity.Subtract(v0)
-
-	rockPlaneN := p1.CrossProduct(p1.Add(v1))
+func (v Vector) Subtract(other Vector) Vector {
+	return Vector{v[0].Sub(other[0]), v[1].Sub(other[1]), v[2].Sub(other[2])}
+}
 
-	origin := Coordinates{x: 0, y: 0, z: 0}
-	p02, t02 := planeLineIntersection(origin, rockPlaneN, p2, v2)
-	p03, t03 := planeLineIntersection(origin, rockPlaneN, p3, v3)
+func (v Vector) CrossProduct(other Vector) Vector {
+	return Vector{
+		v[1].Mul(other[2]).Sub(v[2].Mul(other[1])),
+		v[2].Mul(other[0]).Sub(v[0].Mul(other[2])),
+		v[0].Mul(other[1]).Sub(v[1].Mul(other[0])),
+	}
+}
 
-	vRock := p02.Subtract(p03).Divide(t02 - t03)
-	pRock := p02.Subtract(vRock.Multiply(t02))
+// addRockEquations adds to the system the 3 equations given by the hailstones
+// i and j on the rock position P and velocity V, as rows of coefficients of
+// (Px, Py, Pz, Vx, Vy, Vz).
+//
+// The rock hits each hailstone so (P - pi) × (V - vi) = 0, which expands to
+// P × V - P × vi - pi × V + pi × vi = 0. Subtracting the equations of two
+// hailstones removes the non linear P × V:
+// P × (vj - vi) + (pj - pi) × V = pj × vj - pi × vi
+func addRockEquations(system [][]linalg.Rat, rhs []linalg.Rat, hi, hj Trajectory) ([][]linalg.Rat, []linalg.Rat) {
+	pi, vi := hi.position.Exact(), hi.velocity.Exact()
+	pj, vj := hj.position.Exact(), hj.velocity.Exact()
+	a, b := vj.Subtract(vi), pj.Subtract(pi)
+	c := pj.CrossProduct(vj).Subtract(pi.CrossProduct(vi))
+	var zero linalg.Rat
+	system = append(system,
+		[]linalg.Rat{zero, a[2], a[1].Neg(), zero, b[2].Neg(), b[1]},
+		[]linalg.Rat{a[2].Neg(), zero, a[0], b[2], zero, b[0].Neg()},
+		[]linalg.Rat{a[1], a[0].Neg(), zero, b[1].Neg(), b[0], zero},
+	)
+	return system, append(rhs, c[0], c[1], c[2])
+}
 
-	pRock = pRock.Add(p0)
-	vRock = vRock.Add(v0)
+var errNoRock = errors.New("no single rock trajectory hits all the hailstones")
 
-	rock := Trajectory{position: pRock, velocity: vRock}
+func GetResultPart2(input io.Reader) (int64, error) {
+	hailstones, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	if len(hailstones) < 3 {
+		return 0, fmt.Errorf("at least 3 hailstones are required, got %d", len(hailstones))
+	}
 
-	return int64(rock.position.x*1e12 + rock.position.y*1e12 + rock.position.z*1e12)
+	// Two pairs of hailstones give 6 equations for the 6 unknowns, unless some
+	// of them are parallel and another hailstone has to be used.
+	for k := 2; k < len(hailstones); k++ {
+		rows, rhs := addRockEquations(nil, nil, hailstones[0], hailstones[1])
+		rows, rhs = addRockEquations(rows, rhs, hailstones[0], hailstones[k])
+		system := linalg.New(len(rows), 6)
+		for i, row := range rows {
+			copy(system.Row(i), row)
+		}
+		solution, errSolving := system.Solve(rhs)
+		if errSolving != nil || !solution.Unique() {
+			continue
+		}
+		rock := solution.Particular
+		sum, isInt := rock[0].Add(rock[1]).Add(rock[2]).Int64()
+		if !isInt {
+			return 0, fmt.Errorf("the rock position %v is not made of integers", rock[:3])
+		}
+		return sum, nil
+	}
+	return 0, errNoRock
 }
 
 func loadFile() *os.File {
@@ -278,13 +289,42 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
+// getTestZone returns the test zone of the part 1, whose bounds on both axes
+// the "min" and "max" parameters override for the examples.
+func getTestZone(ctx context.Context) (Zone, error) {
+	low, errMin := aoc.ParamInt(ctx, "min", int(puzzleTestZone.min.x))
+	if errMin != nil {
+		return Zone{}, errMin
+	}
+	high, errMax := aoc.ParamInt(ctx, "max", int(puzzleTestZone.max.x))
+	if errMax != nil {
+		return Zone{}, errMax
+	}
+	return Zone{
+		min: Coordinates{x: float64(low), y: float64(low)},
+		max: Coordinates{x: float64(high), y: float64(high)},
+	}, nil
+}
 
-	result := GetResultPart2(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   24,
+	Title: "Never Tell Me The Odds",
+	Input: aoc.LinesMatching(`-?\d+, +-?\d+, +-?\d+ +@ +-?\d+, +-?\d+, +-?\d+`, "'<x>, <y>, <z> @ <vx>, <vy>, <vz>'"),
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		testZone, errZone := getTestZone(ctx)
+		if errZone != nil {
+			return aoc.Answer{}, errZone
+		}
+		result, err := GetResultPart1(input, testZone)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := GetResultPart2(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
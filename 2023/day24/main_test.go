@@ -1,16 +1,11 @@
-package main
+package day24
 
 import (
-	"strings"
+	"bytes"
 	"testing"
-)
 
-const testingInput = `19, 13, 30 @ -2,  1, -2
-18, 19, 22 @ -1, -1, -2
-20, 25, 34 @ -2, -2, -4
-12, 31, 28 @ -1, -2, -1
-20, 19, 15 @  1, -5, -3
-`
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
 var testingTestZone = Zone{
 	min: Coordinates{
@@ -23,59 +18,40 @@ var testingTestZone = Zone{
 	},
 }
 
-var finalTestZone = Zone{
-	min: Coordinates{
-		x: 200000000000000,
-		y: 200000000000000,
-	},
-	max: Coordinates{
-		x: 400000000000000,
-		y: 400000000000000,
-	},
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
-func TestGetResults(t *testing.T) {
-	t.Run("part1", func(t *testing.T) {
-		t.Run("small", func(t *testing.T) {
-			const testingExpectedResult = 2
-			result := GetResultPart1(strings.NewReader(testingInput), testingTestZone)
-			if result != testingExpectedResult {
-				t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-			}
-		})
-		t.Run("large", func(t *testing.T) {
-			const finalResult = 17244
-			inputFile := loadFile()
-			defer inputFile.Close()
-			result := GetResultPart1(inputFile, finalTestZone)
-			if result != finalResult {
-				t.Errorf("Expected result to be %d, got %d", finalResult, result)
-			}
-		})
-	})
-	t.Run("part2", func(t *testing.T) {
-		t.Run("small", func(t *testing.T) {
-			const testingExpectedResult = 47
-			result := GetResultPart2(strings.NewReader(testingInput))
-			if result != testingExpectedResult {
-				t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-			}
-		})
-	})
+func TestGetResultPart1(t *testing.T) {
+	const finalResult = 17244
+	inputFile := loadFile()
+	defer inputFile.Close()
+	result, err := GetResultPart1(inputFile, puzzleTestZone)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != finalResult {
+		t.Errorf("Expected result to be %d, got %d", finalResult, result)
+	}
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			GetResultPart1(strings.NewReader(testingInput), testingTestZone)
+			GetResultPart1(bytes.NewReader(input), testingTestZone)
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			GetResultPart1(inputFile, finalTestZone)
+			GetResultPart1(inputFile, puzzleTestZone)
 			inputFile.Close()
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -1,5 +1,9 @@
 module github.com/antitoine/advent-of-code/2023/day25
 
-go 1.21.3
+go 1.25.4
 
 require gonum.org/v1/gonum v0.14.0
+
+require github.com/antitoine/advent-of-code/aoc v0.0.0
+
+replace github.com/antitoine/advent-of-code/aoc => ../../aoc
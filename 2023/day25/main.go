@@ -1,13 +1,15 @@
-package main
+package day25
 
 import (
-	"bufio"
-	"gonum.org/v1/gonum/mat"
+	"context"
+	"errors"
 	"io"
 	"log"
 	"os"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"gonum.org/v1/gonum/mat"
 )
 
 type Set map[string]struct{}
@@ -60,28 +62,34 @@ func (g Graph) GetMatrixForKeys(keys Keys) (*mat.SymDense, *mat.SymDense, *mat.S
 	return degreeMatrix, adjacencyMatrix, laplacianMatrix
 }
 
-func parseLine(line string) (string, []string) {
+func parseLine(line string) (string, []string, error) {
 	parts := strings.Split(line, ": ")
 	if len(parts) != 2 {
-		log.Fatalf("Unable to parse line: %s", line)
+		return "", nil, aoc.Unexpected(line, "'<component>: <components>'")
 	}
 	otherParts := strings.Split(parts[1], " ")
-	return parts[0], otherParts
+	return parts[0], otherParts, nil
 }
 
-func parseInput(input io.Reader) (Keys, Graph) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (Keys, Graph, error) {
+	scanner := aoc.NewScanner(input)
 
 	graph := make(Graph)
 	for scanner.Scan() {
-		key, connectedKeys := parseLine(scanner.Text())
+		key, connectedKeys, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, nil, scanner.Wrap(errParsing)
+		}
 		for _, connectedKey := range connectedKeys {
 			graph.AddEdge(key, connectedKey)
 		}
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
+	}
+	if len(graph) == 0 {
+		return nil, nil, scanner.Missing("'<component>: <components>'")
 	}
 
 	keys := make(Keys, len(graph))
@@ -89,18 +97,21 @@ func parseInput(input io.Reader) (Keys, Graph) {
 		keys = append(keys, key)
 	}
 
-	return keys, graph
+	return keys, graph, nil
 }
 
-func getResult(input io.Reader) int {
-	keys, graph := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	keys, graph, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	_, _, laplacianMatrix := graph.GetMatrixForKeys(keys)
 
 	var eigen mat.EigenSym
 	ok := eigen.Factorize(laplacianMatrix, true)
 	if !ok {
-		log.Fatal("Symmetric eigen decomposition failed")
+		return 0, errors.New("symmetric eigen decomposition failed")
 	}
 	var eigenVectors mat.Dense
 	eigen.VectorsTo(&eigenVectors)
@@ -118,7 +129,7 @@ func getResult(input io.Reader) int {
 		}
 	}
 
-	return firstGroupSize * secondGroupSize
+	return firstGroupSize * secondGroupSize, nil
 }
 
 func loadFile() *os.File {
@@ -129,13 +140,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2023,
+	Day:   25,
+	Title: "Snowverload",
+	Input: aoc.LinesMatching(`[a-z]+:( [a-z]+)+`, "'<component>: <components>'"),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,58 +1,64 @@
-package main
+package day01
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseLine(line string) (int, int) {
+func parseLine(line string) (int, int, error) {
 	parts := strings.Split(line, "   ")
 	if len(parts) != 2 {
-		log.Fatalf("Unable to parse line: %s", line)
+		return 0, 0, aoc.Unexpected(line, "'<left>   <right>'")
 	}
-	firstNumber, errFirstNumber := strconv.Atoi(parts[0])
+	firstNumber, errFirstNumber := aoc.Atoi(parts[0])
 	if errFirstNumber != nil {
-		log.Fatalf("Unable to parse first number: %v", errFirstNumber)
+		return 0, 0, errFirstNumber
 	}
-	secondNumber, errSecondNumber := strconv.Atoi(parts[1])
+	secondNumber, errSecondNumber := aoc.Atoi(parts[1])
 	if errSecondNumber != nil {
-		log.Fatalf("Unable to parse second number: %v", errSecondNumber)
+		return 0, 0, errSecondNumber
 	}
-	return firstNumber, secondNumber
+	return firstNumber, secondNumber, nil
 }
 
-func parseInput(input io.Reader) ([]int, map[int]int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]int, map[int]int, error) {
+	scanner := aoc.NewScanner(input)
 
 	var firstList []int
 	secondListOcc := make(map[int]int)
 	for scanner.Scan() {
-		firstNumber, secondNumber := parseLine(scanner.Text())
+		firstNumber, secondNumber, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, nil, scanner.Wrap(errParsing)
+		}
 		firstList = append(firstList, firstNumber)
 		secondListOcc[secondNumber]++
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return firstList, secondListOcc
+	return firstList, secondListOcc, nil
 }
 
-func getResult(input io.Reader) int {
-	firstList, secondListOcc := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	firstList, secondListOcc, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var result int
 	for i := 0; i < len(firstList); i++ {
 		result += firstList[i] * secondListOcc[firstList[i]]
 	}
 
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -63,13 +69,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   1,
+	Title: "Historian Hysteria",
+	Input: aoc.LinesMatching(`\d+   \d+`, "'<left>   <right>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
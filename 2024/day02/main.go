@@ -1,26 +1,29 @@
-package main
+package day02
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseLine(line string) []int {
+func parseLine(line string) ([]int, error) {
 	parts := strings.Split(line, " ")
+	if len(parts) < 5 {
+		return nil, aoc.Unexpected(line, "a report of at least 5 levels")
+	}
 	numbers := make([]int, len(parts))
 	for i, part := range parts {
-		number, errNumber := strconv.Atoi(part)
+		number, errNumber := aoc.Atoi(part)
 		if errNumber != nil {
-			log.Fatalf("Unable to parse number: %v", errNumber)
+			return nil, errNumber
 		}
 		numbers[i] = number
 	}
-	return numbers
+	return numbers, nil
 }
 
 func isSafeLevels(ascending bool, a, b int) bool {
@@ -81,25 +84,28 @@ func isSafeReportWithOneError(numbers []int) bool {
 	return true
 }
 
-func parseInput(input io.Reader) int {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (int, error) {
+	scanner := aoc.NewScanner(input)
 
 	var safeReports int
 	for scanner.Scan() {
-		numbers := parseLine(scanner.Text())
+		numbers, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
 		if isSafeReportWithOneError(numbers) {
 			safeReports++
 		}
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return safeReports
+	return safeReports, nil
 }
 
-func getResult(input io.Reader) int {
+func getResult(input io.Reader) (int, error) {
 	return parseInput(input)
 }
 
@@ -111,13 +117,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   2,
+	Title: "Red-Nosed Reports",
+	Input: aoc.LinesMatching(`\d+( \d+)+`, "a report of levels"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
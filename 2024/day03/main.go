@@ -1,29 +1,27 @@
-package main
+package day03
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 var operations = regexp.MustCompile(`mul\((\d+),(\d+)\)|don't\(\)|do\(\)`)
-var enabled = true
 
-func parseLine(line string) int64 {
+// parseLine returns the sum of the enabled multiplications of a line, and
+// whether they are still enabled at its end.
+func parseLine(line string, enabled bool) (int64, bool, error) {
 	matches := operations.FindAllStringSubmatch(line, -1)
 	if matches == nil {
-		return 0
+		return 0, enabled, nil
 	}
 
 	var result int64
 	for _, match := range matches {
-		if len(match) <= 0 {
-			log.Fatalf("Unexpected number of matches: %v", match)
-		}
 		if match[0] == "do()" {
 			enabled = true
 			continue
@@ -32,43 +30,46 @@ func parseLine(line string) int64 {
 			enabled = false
 			continue
 		}
-		if len(match) != 3 {
-			log.Fatalf("Unexpected number of matches ofr a mult operation: %v", match)
-		}
 		if !enabled {
 			continue
 		}
 		firstStr, secondStr := match[1], match[2]
-		first, errFirst := strconv.ParseInt(firstStr, 10, 64)
+		first, errFirst := aoc.ParseInt(firstStr, 64)
 		if errFirst != nil {
-			log.Fatalf("Unable to parse first number: %v", errFirst)
+			return 0, enabled, errFirst
 		}
-		second, errSecond := strconv.ParseInt(secondStr, 10, 64)
+		second, errSecond := aoc.ParseInt(secondStr, 64)
 		if errSecond != nil {
-			log.Fatalf("Unable to parse second number: %v", errSecond)
+			return 0, enabled, errSecond
 		}
 		result += first * second
 	}
 
-	return result
+	return result, enabled, nil
 }
 
-func parseInput(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 
 	var result int64
+	enabled := true
 	for scanner.Scan() {
-		result += parseLine(scanner.Text())
+		lineResult, stillEnabled, errParsing := parseLine(scanner.Text(), enabled)
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		result += lineResult
+		enabled = stillEnabled
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return result
+	return result, nil
 }
 
-func getResult(input io.Reader) int64 {
+func getResult(input io.Reader) (int64, error) {
 	return parseInput(input)
 }
 
@@ -80,13 +81,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   3,
+	Title: "Mull It Over",
+	Input: aoc.LinesMatching(`.+`, "a line of corrupted memory"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
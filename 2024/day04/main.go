@@ -1,26 +1,32 @@
-package main
+package day04
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseInput(input io.Reader) []string {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]string, error) {
+	scanner := aoc.NewScanner(input)
 
 	var grid []string
 	for scanner.Scan() {
-		grid = append(grid, scanner.Text())
+		line := scanner.Text()
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			return nil, scanner.Unexpected(fmt.Sprintf("a line of %d letters", len(grid[0])))
+		}
+		grid = append(grid, line)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return grid
+	return grid, nil
 }
 
 func isValidMatrix(grid []string, i, j int) bool {
@@ -45,8 +51,11 @@ func isValidMatrix(grid []string, i, j int) bool {
 	return false
 }
 
-func getResult(input io.Reader) int {
-	grid := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	grid, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var cnt int
 	for i := 0; i < len(grid); i++ {
 		for j := 0; j < len(grid[i]); j++ {
@@ -55,7 +64,7 @@ func getResult(input io.Reader) int {
 			}
 		}
 	}
-	return cnt
+	return cnt, nil
 }
 
 func loadFile() *os.File {
@@ -66,13 +75,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   4,
+	Title: "Ceres Search",
+	Input: aoc.Grid{Alphabet: "XMAS"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
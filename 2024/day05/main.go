@@ -1,46 +1,46 @@
-package main
+package day05
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseRule(line string) (int, int) {
+func parseRule(line string) (int, int, error) {
 	parts := strings.Split(line, "|")
 	if len(parts) != 2 {
-		log.Fatalf("Invalid rule: %s", line)
+		return 0, 0, aoc.Unexpected(line, "'<before>|<after>'")
 	}
-	before, errBefore := strconv.Atoi(parts[0])
+	before, errBefore := aoc.Atoi(parts[0])
 	if errBefore != nil {
-		log.Fatalf("Invalid rule for before number: %s", line)
+		return 0, 0, errBefore
 	}
-	after, errAfter := strconv.Atoi(parts[1])
+	after, errAfter := aoc.Atoi(parts[1])
 	if errAfter != nil {
-		log.Fatalf("Invalid rule for after number: %s", line)
+		return 0, 0, errAfter
 	}
-	return before, after
+	return before, after, nil
 }
 
-func parseUpdate(line string) []int {
+func parseUpdate(line string) ([]int, error) {
 	var updates []int
 	parts := strings.Split(line, ",")
 	for _, part := range parts {
-		num, err := strconv.Atoi(part)
+		num, err := aoc.Atoi(part)
 		if err != nil {
-			log.Fatalf("Invalid update number: %s", line)
+			return nil, err
 		}
 		updates = append(updates, num)
 	}
-	return updates
+	return updates, nil
 }
 
-func parseInput(input io.Reader) (map[int][]int, [][]int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (map[int][]int, [][]int, error) {
+	scanner := aoc.NewScanner(input)
 
 	rules := make(map[int][]int)
 	var updates [][]int
@@ -52,18 +52,25 @@ func parseInput(input io.Reader) (map[int][]int, [][]int) {
 			continue
 		}
 		if !switchToUpdates {
-			before, after := parseRule(text)
+			before, after, errParsing := parseRule(text)
+			if errParsing != nil {
+				return nil, nil, scanner.Wrap(errParsing)
+			}
 			rules[before] = append(rules[before], after)
 		} else {
-			updates = append(updates, parseUpdate(text))
+			update, errParsing := parseUpdate(text)
+			if errParsing != nil {
+				return nil, nil, scanner.Wrap(errParsing)
+			}
+			updates = append(updates, update)
 		}
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return rules, updates
+	return rules, updates, nil
 }
 
 func sortUpdate(rules map[int][]int, update []int) bool {
@@ -89,8 +96,11 @@ func sortUpdate(rules map[int][]int, update []int) bool {
 	return valid
 }
 
-func getResult(input io.Reader) int64 {
-	rules, updates := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	rules, updates, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var result int64
 	for _, update := range updates {
@@ -105,7 +115,7 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -116,13 +126,20 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   5,
+	Title: "Print Queue",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`\d+\|\d+`, "a rule as '<before>|<after>'"),
+		aoc.LinesMatching(`\d+(,\d+)*`, "an update as comma-separated pages"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,125 +1,97 @@
-package main
+package day06
 
 import (
-	"bufio"
+	"context"
+	"image"
 	"io"
 	"log"
 	"os"
 	"slices"
-	"time"
-)
 
-type Position struct {
-	x, y int
-}
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/cycle"
+	"github.com/antitoine/advent-of-code/aoc/grid"
+)
 
 type Guard struct {
-	position  Position
-	direction rune
+	position  image.Point
+	direction int
 }
 
-func (g Guard) NextPosition() Position {
-	switch g.direction {
-	case '^':
-		g.position.y--
-	case 'v':
-		g.position.y++
-	case '<':
-		g.position.x--
-	case '>':
-		g.position.x++
-	}
-	return g.position
-}
+// directions are the guard characters in clockwise order, matching the
+// offsets of grid.Directions4.
+var directions = []rune{'^', '>', 'v', '<'}
 
-func (g Guard) Next(grid [][]rune) Guard {
-	g.position = g.NextPosition()
-	if g.position.x < 0 || g.position.x >= len(grid[0]) || g.position.y < 0 || g.position.y >= len(grid) {
-		return g
-	}
-	if grid[g.position.y][g.position.x] == '#' {
-		switch g.direction {
-		case '^':
-			g.position.y++
-			g.position.x++
-			g.direction = '>'
-		case 'v':
-			g.position.y--
-			g.position.x--
-			g.direction = '<'
-		case '<':
-			g.position.x++
-			g.position.y--
-			g.direction = '^'
-		case '>':
-			g.position.x--
-			g.position.y++
-			g.direction = 'v'
-		}
+// Next moves the guard one step forward or, when an obstruction is in front
+// of it, turns it right and moves it one step in its new direction.
+func (g Guard) Next(lab grid.Grid[rune]) Guard {
+	next := g.position.Add(grid.Directions4[g.direction])
+	if tile, ok := lab.Get(next); ok && tile == '#' {
+		g.direction = (g.direction + 1) % len(directions)
+		next = g.position.Add(grid.Directions4[g.direction])
 	}
+	g.position = next
 	return g
 }
 
-var directions = []rune{'^', '>', 'v', '<'}
-
-func parseInput(input io.Reader) ([][]rune, Guard) {
-	scanner := bufio.NewScanner(input)
-
-	var grid [][]rune
+func parseInput(input io.Reader) (grid.Grid[rune], Guard, error) {
 	var guard Guard
-	y := 0
-	for scanner.Scan() {
-		line := []rune(scanner.Text())
-		for x, char := range line {
-			if slices.Contains(directions, char) {
-				guard = Guard{
-					position:  Position{x, y},
-					direction: char,
-				}
-				line[x] = '.'
-				break
+	foundGuard := false
+	lab, errParsing := grid.Parse(input, func(p image.Point, char rune) (rune, error) {
+		if direction := slices.Index(directions, char); direction >= 0 {
+			if foundGuard {
+				return 0, aoc.Unexpected(string(char), "a single guard")
 			}
+			guard = Guard{position: p, direction: direction}
+			foundGuard = true
+			return '.', nil
 		}
-		grid = append(grid, line)
-		y++
+		if char != '.' && char != '#' {
+			return 0, aoc.Unexpected(string(char), "'.', '#' or a guard among '^', '>', 'v' or '<'")
+		}
+		return char, nil
+	})
+	if errParsing != nil {
+		return grid.Grid[rune]{}, Guard{}, errParsing
 	}
 
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+	if !foundGuard {
+		return grid.Grid[rune]{}, Guard{}, &aoc.ParseError{Line: lab.Height() + 1, Err: io.ErrUnexpectedEOF, Expected: "a guard among '^', '>', 'v' or '<'"}
 	}
 
-	return grid, guard
+	return lab, guard, nil
 }
 
-func getResult(input io.Reader) int {
-	grid, guard := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	lab, guard, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	startingPosition := guard.position
 
-	obstaclesForLoop := make(map[Position]struct{})
-
-	for y := 0; y < len(grid); y++ {
-		for x := 0; x < len(grid[y]); x++ {
-			obstacle := Position{x, y}
-			if grid[obstacle.y][obstacle.x] == '.' && startingPosition != obstacle {
-				grid[obstacle.y][obstacle.x] = '#'
-				previousState := make(map[Guard]struct{})
-				previousState[guard] = struct{}{}
-				guardWithObstacle := guard.Next(grid)
-				for guardWithObstacle.position.y >= 0 && guardWithObstacle.position.y < len(grid) && guardWithObstacle.position.x >= 0 && guardWithObstacle.position.x < len(grid[guardWithObstacle.position.y]) {
-					if _, ok := previousState[guardWithObstacle]; ok {
-						obstaclesForLoop[obstacle] = struct{}{}
-						break
-					}
-					previousState[guardWithObstacle] = struct{}{}
-					guardWithObstacle = guardWithObstacle.Next(grid)
-				}
-				grid[obstacle.y][obstacle.x] = '.'
+	obstaclesForLoop := make(map[image.Point]struct{})
+
+	for _, obstacle := range lab.FindAll(func(tile rune) bool { return tile == '.' }) {
+		if startingPosition == obstacle {
+			continue
+		}
+		lab.Set(obstacle, '#')
+		// A guard having left the lab stays there, so the patrol always ends
+		// in a cycle, inside the lab only when the guard is stuck in a loop.
+		patrol := cycle.Brent(guard, func(g Guard) Guard {
+			if !lab.In(g.position) {
+				return g
 			}
+			return g.Next(lab)
+		}, func(a, b Guard) bool { return a == b })
+		if lab.In(patrol.Entry.position) {
+			obstaclesForLoop[obstacle] = struct{}{}
 		}
+		lab.Set(obstacle, '.')
 	}
 
-	return len(obstaclesForLoop)
+	return len(obstaclesForLoop), nil
 }
 
 func loadFile() *os.File {
@@ -130,16 +102,18 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	// 1980 Wrong too high
-	// 1928 Good
-	// 1909 Wrong oo low
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   6,
+	Title: "Guard Gallivant",
+	Input: aoc.Grid{Alphabet: ".#^>v<"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+	Simulate: simulate,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
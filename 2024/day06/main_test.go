@@ -1,35 +1,24 @@
-package main
+package day06
 
 import (
+	"bytes"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-const testingInput = `....#.....
-.........#
-..........
-..#.......
-.......#..
-..........
-.#..^.....
-........#.
-#.........
-......#...
-`
-
-const testingExpectedResult = 6
-
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput))
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput))
+			getResult(bytes.NewReader(input))
 		}
 	})
 
@@ -41,3 +30,40 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func TestSimulation(t *testing.T) {
+	input := aoctest.ReadInput(t, "example")
+	simulation, err := simulate(context.Background(), 2, bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for simulation.Step() {
+	}
+	if values := simulation.View().Values; values[3].Value != 41 || values[4].Value != false || values[5].Value != false {
+		t.Errorf("Expected the guard to leave the lab after visiting 41 positions, got %v", values)
+	}
+
+	ctx := aoc.WithParams(context.Background(), aoc.Params{"obstacle": "3,6"})
+	simulation, err = simulate(ctx, 2, bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for simulation.Step() {
+	}
+	view := simulation.View()
+	if view.Values[4].Value != true || view.Values[5].Value != true {
+		t.Errorf("Expected the guard to loop in the lab, got %v", view.Values)
+	}
+	if view.Grid[6][3] != 'O' {
+		t.Errorf("Expected the obstacle to be shown, got:\n%s", strings.Join(view.Grid, "\n"))
+	}
+
+	ctx = aoc.WithParams(context.Background(), aoc.Params{"obstacle": "4,0"})
+	if _, err := simulate(ctx, 2, bytes.NewReader(input)); err == nil {
+		t.Errorf("Expected an obstacle on a wall to be refused")
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
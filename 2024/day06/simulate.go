@@ -0,0 +1,123 @@
+package day06
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
+)
+
+// patrolSimulation moves the guard one step at a time, until it leaves the
+// lab or walks in a loop.
+type patrolSimulation struct {
+	lab      grid.Grid[rune]
+	obstacle *image.Point
+	guard    Guard
+	seen     map[Guard]struct{}
+	visited  map[image.Point]struct{}
+	looping  bool
+}
+
+// parseObstacle parses the obstacle parameter, the position of an obstacle
+// added to the lab written as x,y, nil if it isn't set.
+func parseObstacle(ctx context.Context) (*image.Point, error) {
+	value, found := aoc.ParamsFrom(ctx)["obstacle"]
+	if !found {
+		return nil, nil
+	}
+	var obstacle image.Point
+	if _, errScanning := fmt.Sscanf(value, "%d,%d", &obstacle.X, &obstacle.Y); errScanning != nil {
+		return nil, fmt.Errorf("invalid obstacle %q, expected x,y", value)
+	}
+	return &obstacle, nil
+}
+
+// simulate walks the guard through the lab, along with the obstacle given as
+// a parameter to look at the loops of part 2.
+func simulate(ctx context.Context, _ int, input io.Reader) (aoc.Simulation, error) {
+	lab, guard, errParsing := parseInput(input)
+	if errParsing != nil {
+		return nil, errParsing
+	}
+	obstacle, errObstacle := parseObstacle(ctx)
+	if errObstacle != nil {
+		return nil, errObstacle
+	}
+	if obstacle != nil {
+		if tile, ok := lab.Get(*obstacle); !ok || tile != '.' || *obstacle == guard.position {
+			return nil, fmt.Errorf("obstacle %d,%d not on a free tile of the lab", obstacle.X, obstacle.Y)
+		}
+		lab = lab.Clone()
+		lab.Set(*obstacle, '#')
+	}
+	return &patrolSimulation{
+		lab:      lab,
+		obstacle: obstacle,
+		guard:    guard,
+		seen:     map[Guard]struct{}{guard: {}},
+		visited:  map[image.Point]struct{}{guard.position: {}},
+	}, nil
+}
+
+func (p *patrolSimulation) Step() bool {
+	if p.looping || !p.lab.In(p.guard.position) {
+		return false
+	}
+	p.guard = p.guard.Next(p.lab)
+	if !p.lab.In(p.guard.position) {
+		return true
+	}
+	if _, found := p.seen[p.guard]; found {
+		p.looping = true
+	}
+	p.seen[p.guard] = struct{}{}
+	p.visited[p.guard.position] = struct{}{}
+	return true
+}
+
+func (p *patrolSimulation) Clone() aoc.Simulation {
+	clone := *p
+	clone.seen = make(map[Guard]struct{}, len(p.seen))
+	for guard := range p.seen {
+		clone.seen[guard] = struct{}{}
+	}
+	clone.visited = make(map[image.Point]struct{}, len(p.visited))
+	for position := range p.visited {
+		clone.visited[position] = struct{}{}
+	}
+	return &clone
+}
+
+func (p *patrolSimulation) View() aoc.View {
+	cells := make([][]rune, p.lab.Height())
+	for y := range cells {
+		cells[y] = append([]rune(nil), p.lab.Row(y)...)
+	}
+	for position := range p.visited {
+		cells[position.Y][position.X] = 'X'
+	}
+	if p.obstacle != nil {
+		cells[p.obstacle.Y][p.obstacle.X] = 'O'
+	}
+	if p.lab.In(p.guard.position) {
+		cells[p.guard.position.Y][p.guard.position.X] = directions[p.guard.direction]
+	}
+	rows := make([]string, len(cells))
+	for y, row := range cells {
+		rows[y] = string(row)
+	}
+	return aoc.View{
+		Grid: rows,
+		Values: []aoc.Value{
+			{Name: "x", Value: p.guard.position.X},
+			{Name: "y", Value: p.guard.position.Y},
+			{Name: "direction", Value: string(directions[p.guard.direction])},
+			{Name: "visited", Value: len(p.visited)},
+			{Name: "inside", Value: p.lab.In(p.guard.position)},
+			{Name: "looping", Value: p.looping},
+		},
+	}
+}
@@ -1,14 +1,15 @@
-package main
+package day07
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 func getTestValueIfValid(testNum int64, values []int64, currentResult int64) int64 {
@@ -34,7 +35,8 @@ func getTestValueIfValid(testNum int64, values []int64, currentResult int64) int
 	}
 	concatSum, errConcat := strconv.ParseInt(fmt.Sprintf("%d%d", currentResult, values[0]), 10, 64)
 	if errConcat != nil {
-		log.Fatalf("Unable to concatenate %d and %d: %v", currentResult, values[0], errConcat)
+		// A concatenation overflowing is greater than any test value.
+		return 0
 	}
 	concatenation := getTestValueIfValid(testNum, values[1:], concatSum)
 	if concatenation > 0 {
@@ -43,43 +45,47 @@ func getTestValueIfValid(testNum int64, values []int64, currentResult int64) int
 	return 0
 }
 
-func parseLine(line string) int64 {
+func parseLine(line string) (int64, error) {
 	parts := strings.Split(line, ": ")
 	if len(parts) != 2 {
-		log.Fatalf("Invalid line: %s", line)
+		return 0, aoc.Unexpected(line, "'<test value>: <numbers>'")
 	}
-	testNum, errTestNum := strconv.ParseInt(parts[0], 10, 64)
+	testNum, errTestNum := aoc.ParseInt(parts[0], 64)
 	if errTestNum != nil {
-		log.Fatalf("Invalid test number '%s': %v", parts[0], errTestNum)
+		return 0, errTestNum
 	}
 	valuesStr := strings.Split(parts[1], " ")
 	var values []int64
 	for _, valueStr := range valuesStr {
-		value, errValue := strconv.ParseInt(valueStr, 10, 64)
+		value, errValue := aoc.ParseInt(valueStr, 64)
 		if errValue != nil {
-			log.Fatalf("Invalid value '%s': %v", valueStr, errValue)
+			return 0, errValue
 		}
 		values = append(values, value)
 	}
-	return getTestValueIfValid(testNum, values, 0)
+	return getTestValueIfValid(testNum, values, 0), nil
 }
 
-func parseInput(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 
 	var result int64
 	for scanner.Scan() {
-		result += parseLine(scanner.Text())
+		testValue, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		result += testValue
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return result
+	return result, nil
 }
 
-func getResult(input io.Reader) int64 {
+func getResult(input io.Reader) (int64, error) {
 	return parseInput(input)
 }
 
@@ -91,13 +97,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   7,
+	Title: "Bridge Repair",
+	Input: aoc.LinesMatching(`\d+:( \d+)+`, "'<test value>: <numbers>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
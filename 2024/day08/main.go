@@ -1,22 +1,27 @@
-package main
+package day08
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseInput(input io.Reader) (map[rune][]image.Point, int, int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (map[rune][]image.Point, int, int, error) {
+	scanner := aoc.NewScanner(input)
 
 	antennas := make(map[rune][]image.Point)
 	y := 0
 	x := 0
 	for scanner.Scan() {
 		line := scanner.Text()
+		if y > 0 && len(line) != x {
+			return nil, 0, 0, scanner.Unexpected(fmt.Sprintf("a line of %d positions", x))
+		}
 		x = len(line)
 		for x, char := range line {
 			if char == '.' {
@@ -28,18 +33,21 @@ func parseInput(input io.Reader) (map[rune][]image.Point, int, int) {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, 0, 0, errScanningFile
 	}
 
-	return antennas, x, y
+	return antennas, x, y, nil
 }
 
 func isValidPosition(pos image.Point, xSize, ySize int) bool {
 	return pos.X >= 0 && pos.X < xSize && pos.Y >= 0 && pos.Y < ySize
 }
 
-func getResult(input io.Reader) int {
-	antennas, xSize, ySize := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	antennas, xSize, ySize, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	antinodes := map[image.Point]struct{}{}
 	for _, positions := range antennas {
 		for _, antenna1 := range positions {
@@ -55,7 +63,7 @@ func getResult(input io.Reader) int {
 		}
 
 	}
-	return len(antinodes)
+	return len(antinodes), nil
 }
 
 func loadFile() *os.File {
@@ -66,13 +74,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   8,
+	Title: "Resonant Collinearity",
+	Input: aoc.Grid{Alphabet: ".0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,31 +1,31 @@
-package main
+package day09
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseInput(input io.Reader) []int {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]int, error) {
+	scanner := aoc.NewScanner(input)
 
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the input file correctly")
+		return nil, scanner.Missing("a disk map")
 	}
 
 	line := scanner.Text()
 	result := make([]int, 0)
 	isBlocks := true
 	blockNumber := 0
-	for _, numStr := range line {
-		num, errParsing := strconv.Atoi(string(numStr))
-		if errParsing != nil {
-			log.Fatalf("Unable to parse the number '%s': %v", string(numStr), errParsing)
+	for i, numStr := range line {
+		if numStr < '0' || numStr > '9' {
+			return nil, &aoc.ParseError{Line: scanner.Line(), Column: i + 1, Text: string(numStr), Expected: "a digit"}
 		}
+		num := int(numStr - '0')
 		for i := 0; i < num; i++ {
 			if isBlocks {
 				result = append(result, blockNumber)
@@ -40,10 +40,10 @@ func parseInput(input io.Reader) []int {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return result
+	return result, nil
 }
 
 func countBlockSize(disk []int, j int) int {
@@ -63,8 +63,11 @@ func sortFreeSpaces(freeSpaces [][]int) {
 	})
 }
 
-func getResult(input io.Reader) int64 {
-	initialState := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	initialState, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var freeSpaces [][]int
 	for i := 0; i < len(initialState); i++ {
@@ -114,7 +117,7 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	return checksum
+	return checksum, nil
 }
 
 func loadFile() *os.File {
@@ -125,13 +128,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   9,
+	Title: "Disk Fragmenter",
+	Input: aoc.LinesMatching(`\d+`, "a disk map of digits"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,47 +1,54 @@
-package main
+package day10
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseLine(line string, y int) ([]image.Point, []int) {
+func parseLine(line string, y int) ([]image.Point, []int, error) {
 	var startingPoints []image.Point
 	lineNumbers := make([]int, len(line))
 	for i, numStr := range line {
-		num, errParsing := strconv.Atoi(string(numStr))
-		if errParsing != nil {
-			log.Fatalf("Unable to parse the number '%s': %v", string(numStr), errParsing)
+		if numStr < '0' || numStr > '9' {
+			return nil, nil, &aoc.ParseError{Column: i + 1, Text: string(numStr), Expected: "a height digit"}
 		}
+		num := int(numStr - '0')
 		if num == 0 {
 			startingPoints = append(startingPoints, image.Point{X: i, Y: y})
 		}
 		lineNumbers[i] = num
 	}
-	return startingPoints, lineNumbers
+	return startingPoints, lineNumbers, nil
 }
 
-func parseInput(input io.Reader) ([]image.Point, [][]int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]image.Point, [][]int, error) {
+	scanner := aoc.NewScanner(input)
 
 	var startingPoints []image.Point
 	var grid [][]int
 	for y := 0; scanner.Scan(); y++ {
-		newStartingPoints, row := parseLine(scanner.Text(), y)
+		if y > 0 && len(scanner.Text()) != len(grid[0]) {
+			return nil, nil, scanner.Unexpected(fmt.Sprintf("a line of %d heights", len(grid[0])))
+		}
+		newStartingPoints, row, errParsing := parseLine(scanner.Text(), y)
+		if errParsing != nil {
+			return nil, nil, scanner.Wrap(errParsing)
+		}
 		grid = append(grid, row)
 		startingPoints = append(startingPoints, newStartingPoints...)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return startingPoints, grid
+	return startingPoints, grid, nil
 }
 
 var directions = []image.Point{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}}
@@ -60,14 +67,17 @@ func countTrailheadScore(startingPoint image.Point, grid [][]int) int64 {
 	return score
 }
 
-func getResult(input io.Reader) int64 {
-	startingPoints, grid := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	startingPoints, grid, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	var sumScore int64
 	for _, startingPoint := range startingPoints {
 		score := countTrailheadScore(startingPoint, grid)
 		sumScore += score
 	}
-	return sumScore
+	return sumScore, nil
 }
 
 func loadFile() *os.File {
@@ -78,13 +88,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   10,
+	Title: "Hoof It",
+	Input: aoc.Grid{Alphabet: "0123456789"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
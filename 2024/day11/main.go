@@ -1,43 +1,47 @@
-package main
+package day11
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseLine(line string) []int64 {
+func parseLine(line string) ([]int64, error) {
 	parts := strings.Split(line, " ")
 	result := make([]int64, len(parts))
 	for i, part := range parts {
-		num, errParsing := strconv.ParseInt(part, 10, 64)
+		num, errParsing := aoc.ParseInt(part, 64)
 		if errParsing != nil {
-			log.Fatalf("Unable to parse the number '%s': %v", part, errParsing)
+			return nil, errParsing
 		}
 		result[i] = num
 	}
-	return result
+	return result, nil
 }
 
-func parseInput(input io.Reader) []int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]int64, error) {
+	scanner := aoc.NewScanner(input)
 
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the input file correctly")
+		return nil, scanner.Missing("a line of stones")
 	}
 
-	stones := parseLine(scanner.Text())
+	stones, errParsing := parseLine(scanner.Text())
+	if errParsing != nil {
+		return nil, scanner.Wrap(errParsing)
+	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return stones
+	return stones, nil
 }
 
 func splitDigits(stone int64) (int64, int64, bool) {
@@ -48,12 +52,12 @@ func splitDigits(stone int64) (int64, int64, bool) {
 	leftStr := stoneStr[:len(stoneStr)/2]
 	leftNum, errParsingLeft := strconv.ParseInt(leftStr, 10, 64)
 	if errParsingLeft != nil {
-		log.Fatalf("Unable to parse the number '%s': %v", leftStr, errParsingLeft)
+		return 0, 0, false
 	}
 	rightStr := stoneStr[len(stoneStr)/2:]
 	rightNum, errParsingRight := strconv.ParseInt(rightStr, 10, 64)
 	if errParsingRight != nil {
-		log.Fatalf("Unable to parse the number '%s': %v", rightStr, errParsingRight)
+		return 0, 0, false
 	}
 	return leftNum, rightNum, true
 }
@@ -85,16 +89,19 @@ func nbStonesAfterNBlinks(stone int64, nbBlinks int, cache map[Stone]int64) int6
 	return nbStones
 }
 
-func getResult(input io.Reader) int64 {
-	state := parseInput(input)
+func getResult(input io.Reader, blinks int) (int64, error) {
+	state, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	cache := make(map[Stone]int64)
 	var result int64
 	for _, stone := range state {
-		result += nbStonesAfterNBlinks(stone, 25, cache)
+		result += nbStonesAfterNBlinks(stone, blinks, cache)
 	}
 
-	return result
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -105,13 +112,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   11,
+	Title: "Plutonian Pebbles",
+	Input: aoc.LinesMatching(`\d+( \d+)*`, "a line of stones"),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 25)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 75)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,57 +1,27 @@
-package main
+package day12
 
 import (
-	"bufio"
+	"context"
 	"image"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strings"
-	"time"
-)
-
-func parseLine(line string) {
-	strings.Split(line, " ")
-}
-
-func parseInput(input io.Reader) [][]rune {
-	scanner := bufio.NewScanner(input)
-
-	var grid [][]rune
-	for scanner.Scan() {
-		line := scanner.Text()
-		grid = append(grid, []rune(line))
-	}
-
-	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
-	}
-
-	return grid
-}
 
-func isValidPoint(grid [][]rune, point image.Point) bool {
-	return point.In(image.Rect(0, 0, len(grid[0]), len(grid)))
-}
-
-var directions = []image.Point{
-	image.Pt(0, 1),
-	image.Pt(1, 0),
-	image.Pt(0, -1),
-	image.Pt(-1, 0),
-}
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
+)
 
 type Side struct {
 	direction image.Point
 	point     image.Point
 }
 
-func getZoneArea(grid [][]rune, point image.Point, visited map[image.Point]struct{}, sides map[Side]struct{}) int64 {
+func getZoneArea(garden grid.Grid[rune], point image.Point, visited map[image.Point]struct{}, sides map[Side]struct{}) int64 {
 	var area int64
-	for _, direction := range directions {
+	for _, direction := range grid.Directions4 {
 		neighbour := point.Add(direction)
-		if !isValidPoint(grid, neighbour) || grid[neighbour.Y][neighbour.X] != grid[point.Y][point.X] {
+		if plant, ok := garden.Get(neighbour); !ok || plant != garden.At(point) {
 			sides[Side{
 				direction: direction,
 				point:     point,
@@ -63,7 +33,7 @@ func getZoneArea(grid [][]rune, point image.Point, visited map[image.Point]struc
 		}
 		visited[neighbour] = struct{}{}
 
-		neighbourArea := getZoneArea(grid, neighbour, visited, sides)
+		neighbourArea := getZoneArea(garden, neighbour, visited, sides)
 		area += neighbourArea
 	}
 
@@ -80,7 +50,7 @@ func getAxisAndPosition(direction image.Point, point image.Point) (int, int) {
 
 func countDistinctSides(sides map[Side]struct{}) int64 {
 	var sidesCnt int64
-	for _, direction := range directions {
+	for _, direction := range grid.Directions4 {
 		sameSidePoints := make(map[int][]int)
 		for side := range sides {
 			if side.direction == direction {
@@ -101,23 +71,23 @@ func countDistinctSides(sides map[Side]struct{}) int64 {
 	return sidesCnt
 }
 
-func getResult(input io.Reader) int64 {
-	grid := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	garden, errParsing := grid.Parse(input, grid.Runes)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	visited := make(map[image.Point]struct{})
 	var result int64
-	for y := 0; y < len(grid); y++ {
-		for x := 0; x < len(grid[y]); x++ {
-			point := image.Pt(x, y)
-			if _, ok := visited[point]; ok {
-				continue
-			}
-			visited[point] = struct{}{}
-			sides := make(map[Side]struct{})
-			area := getZoneArea(grid, point, visited, sides)
-			result += area * countDistinctSides(sides)
+	garden.Each(func(point image.Point, _ rune) {
+		if _, ok := visited[point]; ok {
+			return
 		}
-	}
-	return result
+		visited[point] = struct{}{}
+		sides := make(map[Side]struct{})
+		area := getZoneArea(garden, point, visited, sides)
+		result += area * countDistinctSides(sides)
+	})
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -128,13 +98,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   12,
+	Title: "Garden Groups",
+	Input: aoc.Grid{Alphabet: "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -0,0 +1,37 @@
+package day13
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"math/rand"
+)
+
+// generateInput writes size claw machines at most, with small buttons and
+// prizes, meant to be solved without correction. A quarter of the machines
+// have buttons moving the claw along the same line, and half of the prizes
+// are reachable.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	machines := 1 + rng.Intn(size)
+	for i := 0; i < machines; i++ {
+		buttonA := image.Pt(1+rng.Intn(20), 1+rng.Intn(20))
+		buttonB := image.Pt(1+rng.Intn(20), 1+rng.Intn(20))
+		if rng.Intn(4) == 0 {
+			direction := image.Pt(1+rng.Intn(5), 1+rng.Intn(5))
+			buttonA, buttonB = direction.Mul(1+rng.Intn(4)), direction.Mul(1+rng.Intn(4))
+		}
+		prize := image.Pt(rng.Intn(800), rng.Intn(800))
+		if rng.Intn(2) == 0 {
+			prize = buttonA.Mul(rng.Intn(40)).Add(buttonB.Mul(rng.Intn(40)))
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		_, errWriting := fmt.Fprintf(w, "Button A: X+%d, Y+%d\nButton B: X+%d, Y+%d\nPrize: X=%d, Y=%d\n",
+			buttonA.X, buttonA.Y, buttonB.X, buttonB.Y, prize.X, prize.Y)
+		if errWriting != nil {
+			return errWriting
+		}
+	}
+	return nil
+}
@@ -1,58 +1,61 @@
-package main
+package day13
 
 import (
-	"bufio"
+	"context"
 	"image"
 	"io"
 	"log"
+	"math"
 	"os"
 	"regexp"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/linalg"
+	"github.com/antitoine/advent-of-code/aoc/numtheory"
 )
 
 var buttonRegex = regexp.MustCompile(`Button \w: X\+(\d+), Y\+(\d+)`)
 
-func parseButton(line string) image.Point {
+func parseButton(line string) (image.Point, error) {
 	matches := buttonRegex.FindStringSubmatch(line)
 	if matches == nil {
-		log.Fatalf("Unable to parse button from line: %s", line)
+		return image.Point{}, aoc.Unexpected(line, "'Button <name>: X+<x>, Y+<y>'")
 	}
 
-	x, errParsingX := strconv.Atoi(matches[1])
+	x, errParsingX := aoc.Atoi(matches[1])
 	if errParsingX != nil {
-		log.Fatalf("Unable to parse X from line: %s", errParsingX)
+		return image.Point{}, errParsingX
 	}
 
-	y, errParsingY := strconv.Atoi(matches[2])
+	y, errParsingY := aoc.Atoi(matches[2])
 	if errParsingY != nil {
-		log.Fatalf("Unable to parse Y from line: %s", errParsingY)
+		return image.Point{}, errParsingY
 	}
 
-	return image.Pt(x, y)
+	return image.Pt(x, y), nil
 }
 
 var prizeRegex = regexp.MustCompile(`Prize: X=(\d+), Y=(\d+)`)
 
 const prizeCorrection = 10000000000000
 
-func parsePrize(line string) image.Point {
+func parsePrize(line string, correction int) (image.Point, error) {
 	matches := prizeRegex.FindStringSubmatch(line)
 	if matches == nil {
-		log.Fatalf("Unable to parse prize from line: %s", line)
+		return image.Point{}, aoc.Unexpected(line, "'Prize: X=<x>, Y=<y>'")
 	}
 
-	x, errParsingX := strconv.Atoi(matches[1])
+	x, errParsingX := aoc.Atoi(matches[1])
 	if errParsingX != nil {
-		log.Fatalf("Unable to parse X from line: %s", errParsingX)
+		return image.Point{}, errParsingX
 	}
 
-	y, errParsingY := strconv.Atoi(matches[2])
+	y, errParsingY := aoc.Atoi(matches[2])
 	if errParsingY != nil {
-		log.Fatalf("Unable to parse Y from line: %s", errParsingY)
+		return image.Point{}, errParsingY
 	}
 
-	return image.Pt(x+prizeCorrection, y+prizeCorrection)
+	return image.Pt(x+correction, y+correction), nil
 }
 
 type Game struct {
@@ -64,26 +67,111 @@ type Game struct {
 const costButtonA = 3
 const costButtonB = 1
 
+// Solve returns the cost of the presses of the buttons reaching the prize, or
+// 0 when it can't be reached.
 func (g Game) Solve() int {
-	det := g.ButtonA.X*g.ButtonB.Y - g.ButtonA.Y*g.ButtonB.X
-	if det == 0 {
+	buttons, _ := linalg.FromInts([][]int64{
+		{int64(g.ButtonA.X), int64(g.ButtonB.X)},
+		{int64(g.ButtonA.Y), int64(g.ButtonB.Y)},
+	})
+	solution, errSolving := buttons.Solve([]linalg.Rat{linalg.Int(int64(g.Prize.X)), linalg.Int(int64(g.Prize.Y))})
+	if errSolving != nil {
 		return 0
 	}
-	xNumerator := g.Prize.X*g.ButtonB.Y - g.Prize.Y*g.ButtonB.X
-	yNumerator := g.ButtonA.X*g.Prize.Y - g.ButtonA.Y*g.Prize.X
+	if !solution.Unique() {
+		return g.solveAligned()
+	}
 
-	if xNumerator%det != 0 || yNumerator%det != 0 {
+	aPresses, aIsInt := solution.Particular[0].Int64()
+	bPresses, bIsInt := solution.Particular[1].Int64()
+	if !aIsInt || !bIsInt || aPresses < 0 || bPresses < 0 {
 		return 0
 	}
 
-	aParticular := xNumerator / det
-	bParticular := yNumerator / det
+	return costButtonA*int(aPresses) + costButtonB*int(bPresses)
+}
+
+// solveAligned returns the cheapest cost reaching the prize when the buttons
+// move the claw along the same line as the prize, or 0 when it can't be
+// reached. The presses a and b then only have to solve p*a + q*b = r along
+// one axis, whose solutions are a0 + k*q/g and b0 - k*p/g, the cost changing
+// linearly with k.
+func (g Game) solveAligned() int {
+	p, q, r := int64(g.ButtonA.X), int64(g.ButtonB.X), int64(g.Prize.X)
+	if p == 0 && q == 0 {
+		p, q, r = int64(g.ButtonA.Y), int64(g.ButtonB.Y), int64(g.Prize.Y)
+	}
+	if p == 0 && q == 0 {
+		return 0
+	}
 
-	return costButtonA*aParticular + costButtonB*bParticular
+	gcd, x, y := numtheory.ExtendedGCD(p, q)
+	if r%gcd != 0 {
+		return 0
+	}
+	a0, b0 := x*(r/gcd), y*(r/gcd)
+	stepA, stepB := q/gcd, p/gcd
+
+	// The presses can't be negative, which bounds k.
+	kMin, kMax := int64(math.MinInt64), int64(math.MaxInt64)
+	if stepA > 0 {
+		kMin = ceilDiv(-a0, stepA)
+	} else if a0 < 0 {
+		return 0
+	}
+	if stepB > 0 {
+		kMax = floorDiv(b0, stepB)
+	} else if b0 < 0 {
+		return 0
+	}
+	if kMin > kMax {
+		return 0
+	}
+
+	// Pick the end of the range lowering the cost, which is bounded as the
+	// steps aren't both 0.
+	k := kMin
+	if costButtonA*stepA-costButtonB*stepB < 0 {
+		k = kMax
+	}
+	return costButtonA*int(a0+k*stepA) + costButtonB*int(b0-k*stepB)
 }
 
-func parseInput(input io.Reader) []Game {
-	scanner := bufio.NewScanner(input)
+func floorDiv(a, b int64) int64 {
+	quotient := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		quotient--
+	}
+	return quotient
+}
+
+func ceilDiv(a, b int64) int64 {
+	return -floorDiv(-a, b)
+}
+
+// SolveNaive tries every count of presses of the buttons not going past the
+// prize, as the reference of Solve.
+func (g Game) SolveNaive() int {
+	best := 0
+	for a := 0; a*g.ButtonA.X <= g.Prize.X && a*g.ButtonA.Y <= g.Prize.Y; a++ {
+		for b := 0; a*g.ButtonA.X+b*g.ButtonB.X <= g.Prize.X && a*g.ButtonA.Y+b*g.ButtonB.Y <= g.Prize.Y; b++ {
+			cost := costButtonA*a + costButtonB*b
+			if g.ButtonA.Mul(a).Add(g.ButtonB.Mul(b)) == g.Prize && (best == 0 || cost < best) {
+				best = cost
+			}
+			if g.ButtonB == (image.Point{}) {
+				break
+			}
+		}
+		if g.ButtonA == (image.Point{}) {
+			break
+		}
+	}
+	return best
+}
+
+func parseInput(input io.Reader, correction int) ([]Game, error) {
+	scanner := aoc.NewScanner(input)
 
 	var games []Game
 	for scanner.Scan() {
@@ -92,17 +180,26 @@ func parseInput(input io.Reader) []Game {
 			continue
 		}
 
-		buttonA := parseButton(scanner.Text())
+		buttonA, errParsingButtonA := parseButton(scanner.Text())
+		if errParsingButtonA != nil {
+			return nil, scanner.Wrap(errParsingButtonA)
+		}
 
 		if !scanner.Scan() {
-			log.Fatalf("Unable to scan the input file correctly for button B: %v", scanner.Err())
+			return nil, scanner.Missing("the button B line")
+		}
+		buttonB, errParsingButtonB := parseButton(scanner.Text())
+		if errParsingButtonB != nil {
+			return nil, scanner.Wrap(errParsingButtonB)
 		}
-		buttonB := parseButton(scanner.Text())
 
 		if !scanner.Scan() {
-			log.Fatalf("Unable to scan the input file correctly for button B: %v", scanner.Err())
+			return nil, scanner.Missing("the prize line")
+		}
+		prize, errParsingPrize := parsePrize(scanner.Text(), correction)
+		if errParsingPrize != nil {
+			return nil, scanner.Wrap(errParsingPrize)
 		}
-		prize := parsePrize(scanner.Text())
 
 		games = append(games, Game{
 			ButtonA: buttonA,
@@ -112,21 +209,54 @@ func parseInput(input io.Reader) []Game {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return games
+	return games, nil
+}
+
+// getCorrection returns the correction of the prize positions, which the
+// "correction" parameter overrides so that small prizes can be brute-forced.
+func getCorrection(ctx context.Context) (int, error) {
+	return aoc.ParamInt(ctx, "correction", prizeCorrection)
 }
 
-func getResult(input io.Reader) int64 {
-	games := parseInput(input)
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
+	correction, errCorrection := getCorrection(ctx)
+	if errCorrection != nil {
+		return 0, errCorrection
+	}
+	games, errParsing := parseInput(input, correction)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var result int64
 	for _, game := range games {
 		result += int64(game.Solve())
 	}
 
-	return result
+	return result, nil
+}
+
+// getResultNaive tries every count of presses of the buttons not going past
+// the prizes, as the reference of getResult.
+func getResultNaive(ctx context.Context, input io.Reader) (int64, error) {
+	correction, errCorrection := getCorrection(ctx)
+	if errCorrection != nil {
+		return 0, errCorrection
+	}
+	games, errParsing := parseInput(input, correction)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+
+	var result int64
+	for _, game := range games {
+		result += int64(game.SolveNaive())
+	}
+
+	return result, nil
 }
 
 func loadFile() *os.File {
@@ -137,13 +267,22 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   13,
+	Title: "Claw Contraption",
+	Input: aoc.Blocks(aoc.LinesMatching(`Button [AB]: X\+\d+, Y\+\d+|Prize: X=\d+, Y=\d+`, "a button or a prize line")),
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+	Oracle2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultNaive(ctx, input)
+		return aoc.Int(result), err
+	},
+	Generate: generateInput,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,17 +1,22 @@
-package main
+package day14
 
 import (
-	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
-	"time"
+	"slices"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
+var errNoTree = errors.New("the robots never display a Christmas tree")
+
 type Robot struct {
 	position image.Point
 	velocity image.Point
@@ -19,51 +24,58 @@ type Robot struct {
 
 var lineRegex = regexp.MustCompile(`p=(\d+),(\d+) v=(-?\d+),(-?\d+)`)
 
-func parseLine(line string) Robot {
+func parseLine(line string) (Robot, error) {
 	matches := lineRegex.FindStringSubmatch(line)
 	if matches == nil {
-		log.Fatalf("Unable to parse line: %s", line)
+		return Robot{}, aoc.Unexpected(line, "'p=<x>,<y> v=<vx>,<vy>'")
 	}
 
-	x, errParsingX := strconv.Atoi(matches[1])
+	x, errParsingX := aoc.Atoi(matches[1])
 	if errParsingX != nil {
-		log.Fatalf("Unable to parse X from line: %s", errParsingX)
+		return Robot{}, errParsingX
 	}
 
-	y, errParsingY := strconv.Atoi(matches[2])
+	y, errParsingY := aoc.Atoi(matches[2])
 	if errParsingY != nil {
-		log.Fatalf("Unable to parse Y from line: %s", errParsingY)
+		return Robot{}, errParsingY
 	}
 
-	vx, errParsingVX := strconv.Atoi(matches[3])
+	vx, errParsingVX := aoc.Atoi(matches[3])
 	if errParsingVX != nil {
-		log.Fatalf("Unable to parse VX from line: %s", errParsingVX)
+		return Robot{}, errParsingVX
 	}
 
-	vy, errParsingVY := strconv.Atoi(matches[4])
+	vy, errParsingVY := aoc.Atoi(matches[4])
 	if errParsingVY != nil {
-		log.Fatalf("Unable to parse VY from line: %s", errParsingVY)
+		return Robot{}, errParsingVY
 	}
 
 	return Robot{
 		position: image.Pt(x, y),
 		velocity: image.Pt(vx, vy),
-	}
+	}, nil
 }
 
-func parseInput(input io.Reader) []Robot {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader, space image.Rectangle) ([]Robot, error) {
+	scanner := aoc.NewScanner(input)
 
 	var robots []Robot
 	for scanner.Scan() {
-		robots = append(robots, parseLine(scanner.Text()))
+		robot, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		if !robot.position.In(space) {
+			return nil, scanner.Unexpected(fmt.Sprintf("a position within %dx%d tiles", space.Dx(), space.Dy()))
+		}
+		robots = append(robots, robot)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return robots
+	return robots, nil
 }
 
 func checkAlignment(Robots []Robot, maxX, maxY int) bool {
@@ -94,55 +106,82 @@ func checkAlignment(Robots []Robot, maxX, maxY int) bool {
 	return false
 }
 
-func getResult(input io.Reader, sizeX, sizeY int) int {
-	robots := parseInput(input)
-	quadrants := [4]image.Rectangle{
+func getQuadrants(sizeX, sizeY int) [4]image.Rectangle {
+	return [4]image.Rectangle{
 		image.Rect(0, 0, sizeX/2, sizeY/2),
 		image.Rect((sizeX/2)+1, 0, sizeX, sizeY/2),
 		image.Rect(0, (sizeY/2)+1, sizeX/2, sizeY),
 		image.Rect((sizeX/2)+1, (sizeY/2)+1, sizeX, sizeY),
 	}
-	nbRobotsInQuadrants := [4]int64{0, 0, 0, 0}
-	for seconds := 1; seconds < 1000000; seconds++ {
-		for i, robot := range robots {
-			for q, quadrant := range quadrants {
-				if robot.position.In(quadrant) {
-					nbRobotsInQuadrants[q]--
-					break
-				}
-			}
-			robots[i].position = robot.position.Add(robot.velocity).Mod(image.Rect(0, 0, sizeX, sizeY))
-			for q, quadrant := range quadrants {
-				if robot.position.In(quadrant) {
-					nbRobotsInQuadrants[q]++
-					break
-				}
+}
+
+func getResultPart1(input io.Reader, sizeX, sizeY int) (int, error) {
+	robots, errParsing := parseInput(input, image.Rect(0, 0, sizeX, sizeY))
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	quadrants := getQuadrants(sizeX, sizeY)
+	nbRobotsInQuadrants := [4]int{0, 0, 0, 0}
+	for _, robot := range robots {
+		position := robot.position.Add(robot.velocity.Mul(100)).Mod(image.Rect(0, 0, sizeX, sizeY))
+		for q, quadrant := range quadrants {
+			if position.In(quadrant) {
+				nbRobotsInQuadrants[q]++
+				break
 			}
 		}
+	}
+	return nbRobotsInQuadrants[0] * nbRobotsInQuadrants[1] * nbRobotsInQuadrants[2] * nbRobotsInQuadrants[3], nil
+}
 
+func treeSnapshot(robots []Robot, sizeX, sizeY int) aoc.GridSnapshot {
+	rows := make([][]byte, sizeY)
+	for y := range rows {
+		rows[y] = bytes.Repeat([]byte{'.'}, sizeX)
+	}
+	for _, robot := range robots {
+		rows[robot.position.Y][robot.position.X] = '#'
+	}
+	snapshot := aoc.GridSnapshot{Name: "tree"}
+	for _, row := range rows {
+		snapshot.Rows = append(snapshot.Rows, string(row))
+	}
+	return snapshot
+}
+
+func getResultPart2(ctx context.Context, input io.Reader, sizeX, sizeY int) (int, error) {
+	robots, errParsing := parseInput(input, image.Rect(0, 0, sizeX, sizeY))
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	space := image.Rect(0, 0, sizeX, sizeY)
+	move := func(robots []Robot) []Robot {
+		moved := make([]Robot, len(robots))
+		for i, robot := range robots {
+			moved[i] = Robot{position: robot.position.Add(robot.velocity).Mod(space), velocity: robot.velocity}
+		}
+		return moved
+	}
+	// Moving the robots can be undone, so they come back to their starting
+	// positions at least every sizeX*sizeY seconds, and the tree must show up
+	// before. The cycle is detected along the search, so that it stops with
+	// the context.
+	start := robots
+	checker := aoc.NewChecker(ctx, 100)
+	for seconds := 1; ; seconds++ {
+		if checker.Canceled() {
+			return 0, aoc.Canceled(ctx, aoc.Answer{})
+		}
+		robots = move(robots)
 		if checkAlignment(robots, sizeX, sizeY) {
-			for y := 0; y < sizeY; y++ {
-				for x := 0; x < sizeX; x++ {
-					found := false
-					for _, robot := range robots {
-						if robot.position.X == x && robot.position.Y == y {
-							found = true
-							break
-						}
-					}
-					if found {
-						fmt.Print("#")
-					} else {
-						fmt.Print(".")
-					}
-				}
-				fmt.Print("\n")
-			}
-			fmt.Printf("Seconds: %d\n", seconds)
-			return seconds
+			aoc.Emit(ctx, treeSnapshot(robots, sizeX, sizeY))
+			return seconds, nil
+		}
+		if slices.Equal(robots, start) {
+			aoc.Emit(ctx, aoc.CycleDetected{Name: "robots", Period: seconds})
+			return 0, errNoTree
 		}
 	}
-	return 0
 }
 
 func loadFile() *os.File {
@@ -153,13 +192,40 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
+// spaceSize returns the size of the space of the robots, smaller in the
+// examples than in the real inputs.
+func spaceSize(ctx context.Context) (int, int, error) {
+	sizeX, errParam := aoc.ParamPositiveInt(ctx, "width", 101)
+	if errParam != nil {
+		return 0, 0, errParam
+	}
+	sizeY, errParam := aoc.ParamPositiveInt(ctx, "height", 103)
+	return sizeX, sizeY, errParam
+}
 
-	result := getResult(inputFile, 101, 103)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   14,
+	Title: "Restroom Redoubt",
+	Input: aoc.LinesMatching(`p=\d+,\d+ v=-?\d+,-?\d+`, "'p=<x>,<y> v=<vx>,<vy>'"),
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		sizeX, sizeY, errParams := spaceSize(ctx)
+		if errParams != nil {
+			return aoc.Answer{}, errParams
+		}
+		result, err := getResultPart1(input, sizeX, sizeY)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		sizeX, sizeY, errParams := spaceSize(ctx)
+		if errParams != nil {
+			return aoc.Answer{}, errParams
+		}
+		result, err := getResultPart2(ctx, input, sizeX, sizeY)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
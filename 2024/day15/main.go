@@ -1,33 +1,35 @@
-package main
+package day15
 
 import (
-	"bufio"
-	"fmt"
+	"context"
 	"image"
 	"io"
 	"log"
 	"os"
-	"time"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/grid"
 )
 
 type State struct {
-	matrix [][]rune
-	space  image.Rectangle
+	matrix grid.Grid[rune]
 	robot  image.Point
 }
 
 func (s *State) checkMoveElt(instruction image.Point, elt image.Point) bool {
 	newPosition := elt.Add(instruction)
-	if !newPosition.In(s.space) {
+	tile, inside := s.matrix.Get(newPosition)
+	if !inside {
 		return false
 	}
-	if s.matrix[newPosition.Y][newPosition.X] == '#' {
+	if tile == '#' {
 		return false
 	}
-	if s.matrix[newPosition.Y][newPosition.X] == 'O' && !s.checkMoveElt(instruction, newPosition) {
+	if tile == 'O' && !s.checkMoveElt(instruction, newPosition) {
 		return false
 	}
-	if s.matrix[newPosition.Y][newPosition.X] == '[' {
+	if tile == '[' {
 		if instruction == directions[Right] {
 			return s.checkMoveElt(instruction, newPosition.Add(image.Pt(1, 0)))
 		} else if instruction == directions[Up] || instruction == directions[Down] {
@@ -36,7 +38,7 @@ func (s *State) checkMoveElt(instruction image.Point, elt image.Point) bool {
 			return s.checkMoveElt(instruction, newPosition)
 		}
 	}
-	if s.matrix[newPosition.Y][newPosition.X] == ']' {
+	if tile == ']' {
 		if instruction == directions[Left] {
 			return s.checkMoveElt(instruction, newPosition.Add(image.Pt(-1, 0)))
 		} else if instruction == directions[Up] || instruction == directions[Down] {
@@ -49,16 +51,18 @@ func (s *State) checkMoveElt(instruction image.Point, elt image.Point) bool {
 }
 
 func (s *State) moveElt(instruction image.Point, elt image.Point, sibling bool) {
-	if s.matrix[elt.Y][elt.X] == '#' || s.matrix[elt.Y][elt.X] == '.' {
+	tile := s.matrix.At(elt)
+	if tile == '#' || tile == '.' {
 		return
-	} else if s.matrix[elt.Y][elt.X] == '[' && !sibling {
+	} else if tile == '[' && !sibling {
 		s.moveElt(instruction, elt.Add(image.Pt(1, 0)), true)
-	} else if s.matrix[elt.Y][elt.X] == ']' && !sibling {
+	} else if tile == ']' && !sibling {
 		s.moveElt(instruction, elt.Add(image.Pt(-1, 0)), true)
 	}
 	newPosition := elt.Add(instruction)
 	s.moveElt(instruction, newPosition, false)
-	s.matrix[newPosition.Y][newPosition.X], s.matrix[elt.Y][elt.X] = s.matrix[elt.Y][elt.X], s.matrix[newPosition.Y][newPosition.X]
+	s.matrix.Set(elt, s.matrix.At(newPosition))
+	s.matrix.Set(newPosition, tile)
 }
 
 func (s *State) Move(instruction image.Point) {
@@ -69,69 +73,73 @@ func (s *State) Move(instruction image.Point) {
 }
 
 func (s *State) GetBoxPositions() []image.Point {
-	var boxes []image.Point
-	for y, row := range s.matrix {
-		for x, char := range row {
-			if char == 'O' || char == '[' {
-				boxes = append(boxes, image.Pt(x, y))
-			}
-		}
-	}
-	return boxes
+	return s.matrix.FindAll(func(char rune) bool {
+		return char == 'O' || char == '['
+	})
 }
 
-func (s *State) Print() {
-	for _, row := range s.matrix {
-		fmt.Println(string(row))
+// GPSSum returns the sum of the GPS coordinates of the boxes.
+func (s *State) GPSSum() int64 {
+	var result int64
+	for _, box := range s.GetBoxPositions() {
+		result += int64(box.X + box.Y*100)
 	}
+	return result
+}
+
+func (s *State) Snapshot() aoc.GridSnapshot {
+	return aoc.GridSnapshot{Name: "warehouse", Rows: strings.Split(strings.TrimSuffix(s.matrix.String(), "\n"), "\n")}
 }
 
 func (s *State) UpSize() {
-	newMatrix := make([][]rune, s.space.Dy())
+	newMatrix := grid.New[rune](s.matrix.Width()*2, s.matrix.Height())
 	var newRobot image.Point
-	for y := range newMatrix {
-		newMatrix[y] = make([]rune, s.space.Dx()*2)
-		for x, char := range s.matrix[y] {
-			if char == 'O' {
-				newMatrix[y][x*2] = '['
-				newMatrix[y][(x*2)+1] = ']'
-				continue
-			}
-			if char == '@' {
-				newMatrix[y][x*2] = '@'
-				newMatrix[y][(x*2)+1] = '.'
-				newRobot = image.Pt(x*2, y)
-				continue
-			}
-			newMatrix[y][x*2] = char
-			newMatrix[y][(x*2)+1] = char
+	s.matrix.Each(func(p image.Point, char rune) {
+		left, right := image.Pt(p.X*2, p.Y), image.Pt((p.X*2)+1, p.Y)
+		switch char {
+		case 'O':
+			newMatrix.Set(left, '[')
+			newMatrix.Set(right, ']')
+		case '@':
+			newMatrix.Set(left, '@')
+			newMatrix.Set(right, '.')
+			newRobot = left
+		default:
+			newMatrix.Set(left, char)
+			newMatrix.Set(right, char)
 		}
-	}
+	})
 	s.matrix = newMatrix
-	s.space = image.Rect(0, 0, s.space.Dx()*2, s.space.Dy())
 	s.robot = newRobot
 }
 
-func parseMap(lines []string) *State {
+// parseMap parses the warehouse map, found at the start of the input.
+func parseMap(lines []string) (*State, error) {
 	var robot image.Point
-	var matrix [][]rune
-
-	for y, line := range lines {
-		var row []rune
-		for x, char := range line {
-			row = append(row, char)
-			if char == '@' {
-				robot = image.Pt(x, y)
+	foundRobot := false
+	matrix, errParsing := grid.Parse(strings.NewReader(strings.Join(lines, "\n")), func(p image.Point, char rune) (rune, error) {
+		switch char {
+		case '@':
+			if foundRobot {
+				return 0, aoc.Unexpected("@", "a single robot")
 			}
+			robot = p
+			foundRobot = true
+		case '#', '.', 'O':
+		default:
+			return 0, aoc.Unexpected(string(char), "a tile among '#', '.', 'O' or '@'")
 		}
-		matrix = append(matrix, row)
+		return char, nil
+	})
+	if errParsing != nil {
+		return nil, errParsing
 	}
 
-	return &State{
-		matrix: matrix,
-		space:  image.Rect(0, 0, len(matrix[0]), len(matrix)),
-		robot:  robot,
+	if !foundRobot {
+		return nil, &aoc.ParseError{Line: len(lines) + 1, Expected: "a robot '@' in the map", Err: io.ErrUnexpectedEOF}
 	}
+
+	return &State{matrix: matrix, robot: robot}, nil
 }
 
 const (
@@ -148,20 +156,20 @@ var directions = map[rune]image.Point{
 	Right: image.Pt(1, 0),
 }
 
-func parseInstructions(line string) []image.Point {
+func parseInstructions(line string) ([]image.Point, error) {
 	var instructions []image.Point
-	for _, char := range line {
+	for i, char := range line {
 		if direction, ok := directions[char]; ok {
 			instructions = append(instructions, direction)
 		} else {
-			log.Fatalf("Invalid direction: %c", char)
+			return nil, &aoc.ParseError{Column: i + 1, Text: string(char), Expected: "a direction among '^', 'v', '<' or '>'"}
 		}
 	}
-	return instructions
+	return instructions, nil
 }
 
-func parseInput(input io.Reader) (*State, []image.Point) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (*State, []image.Point, error) {
+	scanner := aoc.NewScanner(input)
 
 	var lines []string
 	for scanner.Scan() {
@@ -171,32 +179,49 @@ func parseInput(input io.Reader) (*State, []image.Point) {
 		}
 		lines = append(lines, line)
 	}
-	state := parseMap(lines)
+	if errScanningMap := scanner.Err(); errScanningMap != nil {
+		return nil, nil, errScanningMap
+	}
+	state, errParsingMap := parseMap(lines)
+	if errParsingMap != nil {
+		return nil, nil, errParsingMap
+	}
 
 	var instructions []image.Point
 	for scanner.Scan() {
-		instructions = append(instructions, parseInstructions(scanner.Text())...)
+		lineInstructions, errParsing := parseInstructions(scanner.Text())
+		if errParsing != nil {
+			return nil, nil, scanner.Wrap(errParsing)
+		}
+		instructions = append(instructions, lineInstructions...)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return state, instructions
+	return state, instructions, nil
 }
 
-func getResult(input io.Reader) int64 {
-	state, instructions := parseInput(input)
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
+	state, instructions, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	state.UpSize()
+	// Snapshotting the warehouse after every move is only worth it when
+	// someone looks at them.
+	observing := aoc.Observing(ctx)
+	if observing {
+		aoc.Emit(ctx, state.Snapshot())
+	}
 	for _, instruction := range instructions {
 		state.Move(instruction)
+		if observing {
+			aoc.Emit(ctx, state.Snapshot())
+		}
 	}
-	boxes := state.GetBoxPositions()
-	var result int64
-	for _, box := range boxes {
-		result += int64(box.X + box.Y*100)
-	}
-	return result
+	return state.GPSSum(), nil
 }
 
 func loadFile() *os.File {
@@ -207,13 +232,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   15,
+	Title: "Warehouse Woes",
+	Input: aoc.Sections(
+		aoc.Grid{Alphabet: "#.O@", Counts: map[rune]int{'@': 1}},
+		aoc.LinesMatching(`[<>^v]+`, "moves among '<', '>', '^' or 'v'"),
+	),
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+	Simulate: simulate,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
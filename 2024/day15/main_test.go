@@ -1,77 +1,59 @@
-package main
+package day15
 
 import (
-	"strings"
+	"bytes"
+	"context"
 	"testing"
-)
-
-const testing1Input = `##########
-#..O..O.O#
-#......O.#
-#.OO..O.O#
-#..O@..O.#
-#O#..O...#
-#O..O..O.#
-#.OO.O.OO#
-#....O...#
-##########
-
-<vv>^<v^>v>^vv^v>v<>v^v<v<^vv<<<^><<><>>v<vvv<>^v^>^<<<><<v<<<v^vv^v>^
-vvv<<^>^v^^><<>>><>^<<><^vv^^<>vvv<>><^^v>^>vv<>v<<<<v<^v>^<^^>>>^<v<v
-><>vv>v^v^<>><>>>><^^>vv>v<^^^>>v^v^<^^>v^^>v^<^v>v<>>v^v^<v>v^^<^^vv<
-<<v<^>>^^^^>>>v^<>vvv^><v<<<>^^^vv^<vvv>^>v<^^^^v<>^>vvvv><>>v^<<^^^^^
-^><^><>>><>^^<<^^v>>><^<v>^<vv>>v>>>^v><>^v><<<<v>>v<v<v>vvv>^<><<>^><
-^>><>^v<><^vvv<^^<><v<<<<<><^v<<<><<<^^<v<^^^><^>>^<v^><<<^>>^v<v^v<v^
->^>>^v>vv>^<<^v<>><<><<v<<v><>v<^vv<<<>^^v^>^^>>><<^v>>v^v><^^>>^<>vv^
-<><^^>^^^<><vvvvv^v<v<<>^v<v>v<<^><<><<><<<^^<<<^<<>><<><^^^>^^<>^>v<>
-^^>vv<^v^v<vv>^<><v<^v>^^^>>>^^vvv^>vvv<>>>^<^>>>>>^<<^v>^vvv<>^<><<v>
-v^^>>><<^^<>>^v^<v^vv<>v^<<>^<^v^v><^<<<><<^<v><v<>vv>>v><v^<vv<>v^<<^
-`
-
-const testing1ExpectedResult = 9021
-
-const testing2Input = `########
-#..O.O.#
-##@.O..#
-#...O..#
-#.#.O..#
-#...O..#
-#......#
-########
-
-<^^>>>vv<v>>v<<
-`
 
-const testing2ExpectedResult = 1751
-
-func TestGetResults(t *testing.T) {
-	t.Run("small", func(t *testing.T) {
-		result := getResult(strings.NewReader(testing2Input))
-		if result != testing2ExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testing2ExpectedResult, result)
-		}
-	})
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-	t.Run("large", func(t *testing.T) {
-		result := getResult(strings.NewReader(testing1Input))
-		if result != testing1ExpectedResult {
-			t.Errorf("Expected result to be %d, got %d", testing2ExpectedResult, result)
-		}
-	})
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "small")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testing2Input))
+			getResult(context.Background(), bytes.NewReader(input))
 		}
 	})
 
 	b.Run("large", func(b *testing.B) {
 		for n := 0; n < b.N; n++ {
 			inputFile := loadFile()
-			getResult(inputFile)
+			getResult(context.Background(), inputFile)
 			inputFile.Close()
 		}
 	})
 }
+
+func TestSimulation(t *testing.T) {
+	input := aoctest.ReadInput(t, "small")
+	for part, expected := range map[int]int64{1: 2028, 2: 1751} {
+		simulation, err := simulate(context.Background(), part, bytes.NewReader(input))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		start := simulation.Clone()
+		steps := 0
+		for simulation.Step() {
+			steps++
+		}
+		if steps != 15 {
+			t.Errorf("Expected 15 moves, got %d", steps)
+		}
+		view := simulation.View()
+		if gps := view.Values[3].Value; gps != expected {
+			t.Errorf("Expected part %d to end with the GPS sum %d, got %v", part, expected, gps)
+		}
+		if start.View().Values[3].Value == expected {
+			t.Errorf("Expected the clone not to be moved along")
+		}
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -0,0 +1,65 @@
+package day15
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// warehouseSimulation moves the robot one instruction at a time, in the
+// warehouse of part 1 or in the twice as wide one of part 2.
+type warehouseSimulation struct {
+	state        *State
+	instructions []image.Point
+	next         int
+}
+
+func simulate(_ context.Context, part int, input io.Reader) (aoc.Simulation, error) {
+	state, instructions, errParsing := parseInput(input)
+	if errParsing != nil {
+		return nil, errParsing
+	}
+	if part == 2 {
+		state.UpSize()
+	}
+	return &warehouseSimulation{state: state, instructions: instructions}, nil
+}
+
+func (w *warehouseSimulation) Step() bool {
+	if w.next == len(w.instructions) {
+		return false
+	}
+	w.state.Move(w.instructions[w.next])
+	w.next++
+	return true
+}
+
+func (w *warehouseSimulation) Clone() aoc.Simulation {
+	clone := *w
+	clone.state = &State{matrix: w.state.matrix.Clone(), robot: w.state.robot}
+	return &clone
+}
+
+func (w *warehouseSimulation) View() aoc.View {
+	next := "none"
+	if w.next < len(w.instructions) {
+		for char, direction := range directions {
+			if direction == w.instructions[w.next] {
+				next = string(char)
+			}
+		}
+	}
+	return aoc.View{
+		Grid:  w.state.Snapshot().Rows,
+		Lines: []string{fmt.Sprintf("move %d of %d", w.next, len(w.instructions))},
+		Values: []aoc.Value{
+			{Name: "robot.x", Value: w.state.robot.X},
+			{Name: "robot.y", Value: w.state.robot.Y},
+			{Name: "next", Value: next},
+			{Name: "gps", Value: w.state.GPSSum()},
+		},
+	}
+}
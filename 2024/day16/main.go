@@ -1,14 +1,15 @@
-package main
+package day16
 
 import (
-	"bufio"
-	"container/heap"
+	"context"
 	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/search"
 )
 
 type Board struct {
@@ -18,37 +19,79 @@ type Board struct {
 	end   image.Point
 }
 
-func (b *Board) Print() {
+func (b *Board) Snapshot() aoc.GridSnapshot {
+	snapshot := aoc.GridSnapshot{Name: "board"}
 	for _, row := range b.cells {
-		fmt.Println(string(row))
+		snapshot.Rows = append(snapshot.Rows, string(row))
+	}
+	return snapshot
+}
+
+// PathsSnapshot returns the board with the given tiles marked 'O', as on the
+// best paths of the reindeer.
+func (b *Board) PathsSnapshot(tiles map[image.Point]struct{}) aoc.GridSnapshot {
+	snapshot := aoc.GridSnapshot{Name: "best paths"}
+	for y, row := range b.cells {
+		marked := append([]rune(nil), row...)
+		for x := range marked {
+			if _, onPath := tiles[image.Pt(x, y)]; onPath {
+				marked[x] = 'O'
+			}
+		}
+		snapshot.Rows = append(snapshot.Rows, string(marked))
 	}
+	return snapshot
 }
 
-func parseInput(input io.Reader) *Board {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (*Board, error) {
+	scanner := aoc.NewScanner(input)
 
 	board := &Board{}
+	foundStart, foundEnd := false, false
 
 	for y := 0; scanner.Scan(); y++ {
+		line := scanner.Text()
+		if y > 0 && len(line) != len(board.cells[0]) {
+			return nil, scanner.Unexpected(fmt.Sprintf("a line of %d tiles", len(board.cells[0])))
+		}
 		var row []rune
-		for x, char := range scanner.Text() {
+		for x, char := range line {
 			row = append(row, char)
-			if char == 'S' {
+			switch char {
+			case 'S':
+				if foundStart {
+					return nil, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: "S", Expected: "a single start tile"}
+				}
 				board.start = image.Pt(x, y)
-			} else if char == 'E' {
+				foundStart = true
+			case 'E':
+				if foundEnd {
+					return nil, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: "E", Expected: "a single end tile"}
+				}
 				board.end = image.Pt(x, y)
+				foundEnd = true
+			case '#', '.':
+			default:
+				return nil, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: string(char), Expected: "a tile among '#', '.', 'S' or 'E'"}
 			}
 		}
 		board.cells = append(board.cells, row)
 	}
 
-	board.space = image.Rect(0, 0, len(board.cells[0]), len(board.cells))
-
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
+	}
+
+	if !foundStart {
+		return nil, scanner.Missing("a start tile 'S'")
+	}
+	if !foundEnd {
+		return nil, scanner.Missing("an end tile 'E'")
 	}
 
-	return board
+	board.space = image.Rect(0, 0, len(board.cells[0]), len(board.cells))
+
+	return board, nil
 }
 
 type Direction rune
@@ -81,141 +124,56 @@ func nextDirection(dir Direction) Direction {
 	return 0
 }
 
-type Path struct {
-	score int64
-	dir   Direction
-	pos   image.Point
-	path  []image.Point
-}
-
-type SmallestPathHeap []Path
-
-func (h SmallestPathHeap) Len() int           { return len(h) }
-func (h SmallestPathHeap) Less(i, j int) bool { return h[i].score < h[j].score }
-func (h SmallestPathHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-
-func (h *SmallestPathHeap) Push(x any) {
-	*h = append(*h, x.(Path))
+// Reindeer is the state of the reindeer on a tile.
+type Reindeer struct {
+	pos image.Point
+	dir Direction
 }
 
-func (h *SmallestPathHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+func (b *Board) isFree(pos image.Point) bool {
+	return pos.In(b.space) && b.cells[pos.Y][pos.X] != '#'
 }
 
-func smallestPath(board *Board) int64 {
-	paths := &SmallestPathHeap{}
-	heap.Push(paths, Path{0, East, board.start, nil})
-	visited := make(map[image.Point]struct{})
-
-	for paths.Len() > 0 {
-		path := heap.Pop(paths).(Path)
-
-		if path.pos == board.end {
-			return path.score
-		}
-
-		nextPos := path.pos.Add(directions[path.dir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			if _, ok := visited[nextPos]; !ok {
-				visited[nextPos] = struct{}{}
-				heap.Push(paths, Path{path.score + 1, path.dir, nextPos, nil})
+func smallestPaths(board *Board) (search.Result[Reindeer], error) {
+	return search.Dijkstra(search.Problem[Reindeer]{
+		Starts: []Reindeer{{board.start, East}},
+		Neighbours: func(reindeer Reindeer, visit func(Reindeer, int)) {
+			if nextPos := reindeer.pos.Add(directions[reindeer.dir]); board.isFree(nextPos) {
+				visit(Reindeer{nextPos, reindeer.dir}, 1)
 			}
-		}
-
-		nextDir := nextDirection(path.dir)
-		nextPos = path.pos.Add(directions[nextDir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			if _, ok := visited[nextPos]; !ok {
-				visited[nextPos] = struct{}{}
-				heap.Push(paths, Path{path.score + 1001, nextDir, nextPos, nil})
+			nextDir := nextDirection(reindeer.dir)
+			if nextPos := reindeer.pos.Add(directions[nextDir]); board.isFree(nextPos) {
+				visit(Reindeer{nextPos, nextDir}, 1001)
 			}
-		}
-
-		nextDir = nextDirection(nextDirection(nextDir))
-		nextPos = path.pos.Add(directions[nextDir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			if _, ok := visited[nextPos]; !ok {
-				visited[nextPos] = struct{}{}
-				heap.Push(paths, Path{path.score + 1001, nextDir, nextPos, nil})
+			nextDir = nextDirection(nextDirection(nextDir))
+			if nextPos := reindeer.pos.Add(directions[nextDir]); board.isFree(nextPos) {
+				visit(Reindeer{nextPos, nextDir}, 1001)
 			}
-		}
-	}
-
-	return 0
-}
-
-type PosDir struct {
-	pos image.Point
-	dir Direction
+		},
+		Goal:     func(reindeer Reindeer) bool { return reindeer.pos == board.end },
+		AllPaths: true,
+	})
 }
 
-func getAllOptimalTiles(board *Board, targetScore int64) int {
-	paths := &SmallestPathHeap{}
-	heap.Push(paths, Path{0, East, board.start, []image.Point{board.start}})
-	visited := make(map[PosDir]int64)
-	var smallestPaths []*Path
-
-	for paths.Len() > 0 {
-		path := heap.Pop(paths).(Path)
-
-		if path.score > targetScore {
-			continue
-		}
-
-		visitedKey := PosDir{path.pos, path.dir}
-		if score, exists := visited[visitedKey]; exists && score < path.score {
-			continue
-		}
-		visited[visitedKey] = path.score
-
-		if path.pos == board.end && path.score == targetScore {
-			smallestPaths = append(smallestPaths, &path)
-			continue
-		}
-
-		nextPos := path.pos.Add(directions[path.dir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			newPath := make([]image.Point, len(path.path))
-			copy(newPath, path.path)
-			heap.Push(paths, Path{path.score + 1, path.dir, nextPos, append(newPath, nextPos)})
-		}
-
-		nextDir := nextDirection(path.dir)
-		nextPos = path.pos.Add(directions[nextDir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			newPath := make([]image.Point, len(path.path))
-			copy(newPath, path.path)
-			heap.Push(paths, Path{path.score + 1001, nextDir, nextPos, append(newPath, nextPos)})
-		}
-
-		nextDir = nextDirection(nextDirection(nextDir))
-		nextPos = path.pos.Add(directions[nextDir])
-		if nextPos.In(board.space) && board.cells[nextPos.Y][nextPos.X] != '#' {
-			newPath := make([]image.Point, len(path.path))
-			copy(newPath, path.path)
-			heap.Push(paths, Path{path.score + 1001, nextDir, nextPos, append(newPath, nextPos)})
-		}
+func getResult(ctx context.Context, input io.Reader) (int, error) {
+	board, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	paths, errSearching := smallestPaths(board)
+	if errSearching != nil {
+		return 0, errSearching
 	}
+	aoc.Emit(ctx, aoc.Value{Name: "smallest score", Value: paths.Cost})
 
 	uniqueTiles := make(map[image.Point]struct{})
-	for _, path := range smallestPaths {
-		for _, pos := range path.path {
-			uniqueTiles[pos] = struct{}{}
-		}
+	for _, reindeer := range paths.OnOptimalPaths() {
+		uniqueTiles[reindeer.pos] = struct{}{}
 	}
-
-	return len(uniqueTiles)
-}
-
-func getResult(input io.Reader) int {
-	board := parseInput(input)
-	smallestScore := smallestPath(board)
-	fmt.Printf("Smallest score: %d\n", smallestScore)
-	return getAllOptimalTiles(board, smallestScore)
+	if aoc.Observing(ctx) {
+		aoc.Emit(ctx, board.PathsSnapshot(uniqueTiles))
+	}
+	return len(uniqueTiles), nil
 }
 
 func loadFile() *os.File {
@@ -226,13 +184,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   16,
+	Title: "Reindeer Maze",
+	Input: aoc.Grid{Alphabet: "#.SE", Counts: map[rune]int{'S': 1, 'E': 1}},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -0,0 +1,45 @@
+package day17
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// generateInput writes a program of size+2 instructions at most, shifting
+// register A by 3 bits, outputting a value and jumping back to its start, the
+// other instructions being random. Only the programs getResult supports are
+// written, most of them having no value of A making them output themselves.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	for {
+		nbOthers := rng.Intn(size)
+		body := [][2]Instruction{{Instruction(adv), 3}, {Instruction(out), Instruction(rng.Intn(7))}}
+		for i := 0; i < nbOthers; i++ {
+			opcode := []Opcode{bxl, bst, bxc, bdv, cdv}[rng.Intn(5)]
+			operand := Instruction(rng.Intn(7))
+			if opcode == bxl {
+				operand = Instruction(rng.Intn(8))
+			}
+			body = append(body, [2]Instruction{Instruction(opcode), operand})
+		}
+		rng.Shuffle(len(body), func(i, j int) { body[i], body[j] = body[j], body[i] })
+
+		var instructions []Instruction
+		for _, instruction := range body {
+			instructions = append(instructions, instruction[0], instruction[1])
+		}
+		instructions = append(instructions, Instruction(jnz), 0)
+		if checkProgram(instructions) != nil {
+			continue
+		}
+
+		values := make([]string, len(instructions))
+		for i, instruction := range instructions {
+			values[i] = fmt.Sprint(instruction)
+		}
+		_, errWriting := fmt.Fprintf(w, "Register A: %d\nRegister B: %d\nRegister C: %d\n\n%s%s\n",
+			rng.Intn(1000), rng.Intn(8), rng.Intn(8), programLinePrefix, strings.Join(values, ","))
+		return errWriting
+	}
+}
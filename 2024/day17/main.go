@@ -1,16 +1,18 @@
-package main
+package day17
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"math"
 	"os"
 	"regexp"
 	"slices"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Opcode int8
@@ -38,57 +40,71 @@ const (
 
 var registerLineRegex = regexp.MustCompile(`Register ([A-C]): (\d+)`)
 
-func parseRegisterLine(line string) (Register, int64) {
+func parseRegisterLine(line string) (Register, int64, error) {
 	matches := registerLineRegex.FindStringSubmatch(line)
 	if len(matches) != 3 {
-		log.Fatalf("Unable to parse register line: %s", line)
+		return 0, 0, aoc.Unexpected(line, "'Register <A|B|C>: <value>'")
 	}
 	register := Register(rune(matches[1][0]))
-	value, errValue := strconv.ParseInt(matches[2], 10, 64)
+	value, errValue := aoc.ParseInt(matches[2], 64)
 	if errValue != nil {
-		log.Fatalf("Unable to parse register value: %v", errValue)
+		return 0, 0, errValue
 	}
-	return register, value
+	return register, value, nil
 }
 
 type Instruction int8
 
-func parseProgramLine(line string) []Instruction {
-	lineParts := strings.Split(strings.TrimPrefix(line, "Program: "), ",")
+const programLinePrefix = "Program: "
+
+func parseProgramLine(line string) ([]Instruction, error) {
+	lineParts := strings.Split(strings.TrimPrefix(line, programLinePrefix), ",")
 	if len(lineParts)%2 != 0 {
-		log.Fatalf("Invalid program line: %s", line)
+		return nil, aoc.Unexpected(line, "an even count of program values")
 	}
 	instructions := make([]Instruction, len(lineParts))
+	column := len(programLinePrefix) + 1
 	for i, part := range lineParts {
-		value, errValue := strconv.ParseInt(part, 10, 8)
-		if errValue != nil {
-			log.Fatalf("Unable to parse program value: %v", errValue)
+		if len(part) != 1 || part[0] < '0' || part[0] > '7' {
+			return nil, &aoc.ParseError{Column: column, Text: part, Expected: "a 3-bit value"}
 		}
-		instructions[i] = Instruction(value)
+		instructions[i] = Instruction(part[0] - '0')
+		column += len(part) + 1
 	}
-	return instructions
+	return instructions, nil
 }
 
-func parseInput(input io.Reader) (map[Register]int64, []Instruction) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (map[Register]int64, []Instruction, error) {
+	scanner := aoc.NewScanner(input)
 
 	registers := make(map[Register]int64)
 	var instructions []Instruction
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "Register") {
-			register, value := parseRegisterLine(line)
+			register, value, errParsing := parseRegisterLine(line)
+			if errParsing != nil {
+				return nil, nil, scanner.Wrap(errParsing)
+			}
 			registers[register] = value
 		} else if strings.HasPrefix(line, "Program") {
-			instructions = parseProgramLine(line)
+			var errParsing error
+			instructions, errParsing = parseProgramLine(line)
+			if errParsing != nil {
+				return nil, nil, scanner.Wrap(errParsing)
+			}
 		}
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return registers, instructions
+	if instructions == nil {
+		return nil, nil, scanner.Missing("a program line")
+	}
+
+	return registers, instructions, nil
 }
 
 type Program struct {
@@ -114,18 +130,22 @@ func (p *Program) comboOperand(op Operand) int64 {
 		return p.registers[regB]
 	case 6:
 		return p.registers[regC]
-	default:
-		log.Fatalf("Invalid combo operand: %d", op)
 	}
 	return 0
 }
 
+// process runs the instruction at the pointer and reports whether the program
+// halted. A program jumping to its last value or using the reserved combo
+// operand 7 halts too, checkProgram refusing both.
 func (p *Program) process() bool {
-	if p.pointer < 0 || p.pointer >= len(p.instructions) {
+	if p.pointer < 0 || p.pointer+1 >= len(p.instructions) {
 		return true
 	}
 	opcode := Opcode(p.instructions[p.pointer])
 	operand := Operand(p.instructions[p.pointer+1])
+	if usesCombo(opcode) && operand == 7 {
+		return true
+	}
 	switch opcode {
 	case adv:
 		p.registers[regA] = p.registers[regA] / int64(math.Pow(2, float64(p.comboOperand(operand))))
@@ -159,25 +179,167 @@ func newRegisters(registers map[Register]int64) map[Register]int64 {
 	return nr
 }
 
-func getResult(input io.Reader) int64 {
-	registers, instructions := parseInput(input)
-	var a int64
-	for itr := len(instructions) - 1; itr >= 0; itr-- {
-		a <<= 3
-		for {
-			updatedRegisters := newRegisters(registers)
-			updatedRegisters[regA] = a
-			program := Program{updatedRegisters, instructions, 0, nil}
-			for !program.process() {
-			}
-			if slices.Equal(program.outs, instructions[itr:]) {
-				break
+var (
+	errUnsupportedProgram = errors.New("unsupported program")
+	errNoQuine            = errors.New("no value of register A makes the program output itself")
+)
+
+// checkProgram ensures the program is a single loop consuming 3 bits of
+// register A per output, and not carrying B nor C from a loop to the next, so
+// that each output only depends on the bits of A left at its loop.
+func checkProgram(instructions []Instruction) error {
+	last := len(instructions) - 2
+	if last < 0 || Opcode(instructions[last]) != jnz || instructions[last+1] != 0 {
+		return fmt.Errorf("%w: expected the program to end with a jump to its start", errUnsupportedProgram)
+	}
+	var nbShifts, nbOuts int
+	written := make(map[Register]bool)
+	readFirst := make(map[Register]bool)
+	read := func(register Register) {
+		if !written[register] {
+			readFirst[register] = true
+		}
+	}
+	readCombo := func(op Operand) {
+		switch op {
+		case 5:
+			read(regB)
+		case 6:
+			read(regC)
+		}
+	}
+	for i := 0; i < last; i += 2 {
+		operand := Operand(instructions[i+1])
+		switch Opcode(instructions[i]) {
+		case adv:
+			if operand != 3 {
+				return fmt.Errorf("%w: expected register A to be shifted by 3 bits", errUnsupportedProgram)
 			}
-			a++
+			nbShifts++
+		case bxl:
+			read(regB)
+			written[regB] = true
+		case bst:
+			readCombo(operand)
+			written[regB] = true
+		case jnz:
+			return fmt.Errorf("%w: expected a single jump", errUnsupportedProgram)
+		case bxc:
+			read(regB)
+			read(regC)
+			written[regB] = true
+		case out:
+			readCombo(operand)
+			nbOuts++
+		case bdv:
+			readCombo(operand)
+			written[regB] = true
+		case cdv:
+			readCombo(operand)
+			written[regC] = true
+		}
+		if operand == 7 && Opcode(instructions[i]) != bxl {
+			return fmt.Errorf("%w: invalid combo operand 7", errUnsupportedProgram)
+		}
+	}
+	if nbShifts != 1 || nbOuts != 1 {
+		return fmt.Errorf("%w: expected a single shift of register A and a single output per loop", errUnsupportedProgram)
+	}
+	for register := range readFirst {
+		if written[register] {
+			return fmt.Errorf("%w: register %c carried from a loop to the next", errUnsupportedProgram, register)
 		}
 	}
+	return nil
+}
 
-	return a
+// run returns the outputs of the program started with the given value of
+// register A.
+func run(registers map[Register]int64, instructions []Instruction, a int64) []Instruction {
+	updatedRegisters := newRegisters(registers)
+	updatedRegisters[regA] = a
+	program := Program{updatedRegisters, instructions, 0, nil}
+	for !program.process() {
+	}
+	return program.outs
+}
+
+// outputsItself reports whether the program started with the given value of
+// register A outputs its instructions, stopping at the first wrong output. The
+// registers of the program are overwritten.
+func outputsItself(program *Program, registers map[Register]int64, a int64) bool {
+	for register, value := range registers {
+		program.registers[register] = value
+	}
+	program.registers[regA] = a
+	program.pointer, program.outs = 0, program.outs[:0]
+	instructions := program.instructions
+	for !program.process() {
+		nbOuts := len(program.outs)
+		if nbOuts > len(instructions) || nbOuts > 0 && program.outs[nbOuts-1] != instructions[nbOuts-1] {
+			return false
+		}
+	}
+	return len(program.outs) == len(instructions)
+}
+
+// findA returns the smallest value of register A ending with the bits of a
+// and making the program output its instructions from itr, looking for its
+// 3 next bits from the lowest and backtracking when a value can't be
+// completed.
+func findA(registers map[Register]int64, instructions []Instruction, itr int, a int64) (int64, bool) {
+	if itr < 0 {
+		return a, true
+	}
+	for bits := int64(0); bits < 8; bits++ {
+		candidate := a<<3 | bits
+		if !slices.Equal(run(registers, instructions, candidate), instructions[itr:]) {
+			continue
+		}
+		if result, found := findA(registers, instructions, itr-1, candidate); found {
+			return result, true
+		}
+	}
+	return 0, false
+}
+
+func getResult(input io.Reader) (int64, error) {
+	registers, instructions, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	if errChecking := checkProgram(instructions); errChecking != nil {
+		return 0, errChecking
+	}
+	a, found := findA(registers, instructions, len(instructions)-1, 0)
+	if !found {
+		return 0, errNoQuine
+	}
+	return a, nil
+}
+
+// getResultNaive tries every value of register A below 8^n, n being the
+// length of the program, as the reference of getResult. Larger values would
+// output too much with the programs getResult supports.
+func getResultNaive(ctx context.Context, input io.Reader) (int64, error) {
+	registers, instructions, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	if len(instructions) > 20 {
+		return 0, fmt.Errorf("program of %d values too long to be brute-forced", len(instructions))
+	}
+	checker := aoc.NewChecker(ctx, 1000)
+	program := &Program{registers: newRegisters(registers), instructions: instructions}
+	for a := int64(0); a < 1<<(3*len(instructions)); a++ {
+		if checker.Canceled() {
+			return 0, aoc.Canceled(ctx, aoc.Answer{})
+		}
+		if outputsItself(program, registers, a) {
+			return a, nil
+		}
+	}
+	return 0, errNoQuine
 }
 
 func loadFile() *os.File {
@@ -188,13 +350,26 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   17,
+	Title: "Chronospatial Computer",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`Register [A-C]: \d+`, "'Register <A|B|C>: <value>'"),
+		aoc.LinesMatching(`Program: [0-7](,[0-7])*`, "'Program: <3-bit values>'"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+	Oracle2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResultNaive(ctx, input)
+		return aoc.Int(result), err
+	},
+	Generate: generateInput,
+	Simulate: simulate,
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,30 +1,85 @@
-package main
+package day17
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
 )
 
-const testingInput = `Register A: 2024
+func TestUnsolvable(t *testing.T) {
+	t.Run("no quine", func(t *testing.T) {
+		_, err := getResult(strings.NewReader("Register A: 7\n\nProgram: 0,3,5,1,3,0\n"))
+		if !errors.Is(err, errNoQuine) {
+			t.Errorf("Expected no value of register A to be found, got %v", err)
+		}
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := getResult(strings.NewReader("Register A: 7\n\nProgram: 0,3,5,4\n"))
+		if !errors.Is(err, errUnsupportedProgram) {
+			t.Errorf("Expected the program to be refused, got %v", err)
+		}
+	})
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
+
+func TestDifferential(t *testing.T) {
+	aoctest.Differential(t, Solver, nil, 1)
+}
+
+func TestSimulation(t *testing.T) {
+	const input = `Register A: 729
 Register B: 0
 Register C: 0
 
-Program: 0,3,5,4,3,0
+Program: 0,1,5,4,3,0
 `
+	simulation, err := simulate(context.Background(), 1, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	view := simulation.View()
+	if view.Lines[0] != "next: adv 1" || len(view.Highlights) != 2 || view.Highlights[1].X != 1 {
+		t.Errorf("Unexpected first instruction %q highlighted at %v", view.Lines[0], view.Highlights)
+	}
+	for simulation.Step() {
+	}
+	if view := simulation.View(); view.Lines[1] != "output: 4,6,3,5,6,3,5,2,1,0" || view.Lines[0] != "next: halted" {
+		t.Errorf("Unexpected end of the program:\n%s", strings.Join(view.Lines, "\n"))
+	}
 
-const testingExpectedResult = 117440
+	ctx := aoc.WithParams(context.Background(), aoc.Params{"a": "117440"})
+	simulation, err = simulate(ctx, 1, bytes.NewReader(aoctest.ReadInput(t, "example")))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for simulation.Step() {
+	}
+	if output := simulation.View().Lines[1]; output != "output: 0,3,5,4,3,0" {
+		t.Errorf("Expected the program to output itself, got %q", output)
+	}
 
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput))
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %d, got %d", testingExpectedResult, result)
+	simulation, err = simulate(context.Background(), 1, strings.NewReader("Register A: 1\n\nProgram: 5,7\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if simulation.Step() || simulation.View().Lines[0] != "next: out 7 (invalid combo operand)" {
+		t.Errorf("Expected the invalid combo operand to stop the program, got %q", simulation.View().Lines[0])
 	}
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput))
+			getResult(bytes.NewReader(input))
 		}
 	})
 
@@ -36,3 +91,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
@@ -0,0 +1,120 @@
+package day17
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+var opcodeNames = [...]string{adv: "adv", bxl: "bxl", bst: "bst", jnz: "jnz", bxc: "bxc", out: "out", bdv: "bdv", cdv: "cdv"}
+
+// usesCombo reports whether the operand of the opcode is a combo operand.
+func usesCombo(opcode Opcode) bool {
+	return opcode != bxl && opcode != jnz && opcode != bxc
+}
+
+// disassemble returns the instruction of the given opcode and operand as
+// text, the combo operands 4 to 6 being written as their register.
+func disassemble(opcode Opcode, operand Operand) string {
+	switch {
+	case opcode == bxc:
+		return opcodeNames[opcode]
+	case usesCombo(opcode) && operand >= 4 && operand <= 6:
+		return fmt.Sprintf("%s %c", opcodeNames[opcode], rune(regA)+rune(operand-4))
+	}
+	return fmt.Sprintf("%s %d", opcodeNames[opcode], operand)
+}
+
+// programSimulation processes the instructions of the program one at a time.
+type programSimulation struct {
+	program *Program
+	// invalid is set when the next instruction has the invalid combo
+	// operand 7, which stops the program.
+	invalid bool
+}
+
+// simulate runs the program, register A being set by the a parameter when
+// given, to check a value found by part 2.
+func simulate(ctx context.Context, _ int, input io.Reader) (aoc.Simulation, error) {
+	registers, instructions, errParsing := parseInput(input)
+	if errParsing != nil {
+		return nil, errParsing
+	}
+	a, errParam := aoc.ParamInt(ctx, "a", int(registers[regA]))
+	if errParam != nil {
+		return nil, errParam
+	}
+	registers[regA] = int64(a)
+	return &programSimulation{program: &Program{registers: registers, instructions: instructions}}, nil
+}
+
+func (p *programSimulation) halted() bool {
+	return p.program.pointer < 0 || p.program.pointer+1 >= len(p.program.instructions)
+}
+
+func (p *programSimulation) Step() bool {
+	if p.invalid || p.halted() {
+		return false
+	}
+	if usesCombo(Opcode(p.program.instructions[p.program.pointer])) && p.program.instructions[p.program.pointer+1] == 7 {
+		p.invalid = true
+		return false
+	}
+	p.program.process()
+	return true
+}
+
+func (p *programSimulation) Clone() aoc.Simulation {
+	return &programSimulation{
+		program: &Program{
+			registers:    newRegisters(p.program.registers),
+			instructions: p.program.instructions,
+			pointer:      p.program.pointer,
+			outs:         append([]Instruction(nil), p.program.outs...),
+		},
+		invalid: p.invalid,
+	}
+}
+
+// View shows the program as a row of values, the next instruction being
+// highlighted.
+func (p *programSimulation) View() aoc.View {
+	var row strings.Builder
+	for _, instruction := range p.program.instructions {
+		row.WriteByte(byte('0' + instruction))
+	}
+	outs := make([]string, len(p.program.outs))
+	for i, value := range p.program.outs {
+		outs[i] = fmt.Sprint(value)
+	}
+	view := aoc.View{Grid: []string{row.String()}}
+
+	next := "halted"
+	if !p.halted() {
+		pointer := p.program.pointer
+		view.Highlights = []image.Point{image.Pt(pointer, 0), image.Pt(pointer+1, 0)}
+		next = disassemble(Opcode(p.program.instructions[pointer]), Operand(p.program.instructions[pointer+1]))
+		if p.invalid {
+			next += " (invalid combo operand)"
+		}
+	}
+	view.Lines = []string{"next: " + next, "output: " + strings.Join(outs, ",")}
+
+	last := ""
+	if len(outs) > 0 {
+		last = outs[len(outs)-1]
+	}
+	view.Values = []aoc.Value{
+		{Name: "A", Value: p.program.registers[regA]},
+		{Name: "B", Value: p.program.registers[regB]},
+		{Name: "C", Value: p.program.registers[regC]},
+		{Name: "pointer", Value: p.program.pointer},
+		{Name: "outputs", Value: len(outs)},
+		{Name: "last", Value: last},
+	}
+	return view
+}
@@ -1,123 +1,103 @@
-package main
+package day18
 
 import (
-	"bufio"
-	"container/heap"
+	"context"
 	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/search"
 )
 
-func parseLine(line string) image.Point {
+func parseLine(line string) (image.Point, error) {
 	parts := strings.Split(line, ",")
 	if len(parts) != 2 {
-		log.Fatalf("Invalid line: %s", line)
+		return image.Point{}, aoc.Unexpected(line, "'<x>,<y>'")
 	}
-	x, errX := strconv.Atoi(parts[0])
+	x, errX := aoc.Atoi(parts[0])
 	if errX != nil {
-		log.Fatalf("Invalid x: %s", parts[0])
+		return image.Point{}, errX
 	}
-	y, errY := strconv.Atoi(parts[1])
+	y, errY := aoc.Atoi(parts[1])
 	if errY != nil {
-		log.Fatalf("Invalid y: %s", parts[1])
+		return image.Point{}, errY
 	}
-	return image.Point{X: x, Y: y}
+	return image.Point{X: x, Y: y}, nil
 }
 
-func parseInput(input io.Reader) []image.Point {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader, space image.Rectangle) ([]image.Point, error) {
+	scanner := aoc.NewScanner(input)
 
 	var corruptedBytes []image.Point
 	for scanner.Scan() {
-		corruptedBytes = append(corruptedBytes, parseLine(scanner.Text()))
+		corruptedByte, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		if !corruptedByte.In(space) {
+			return nil, scanner.Unexpected(fmt.Sprintf("a position within %dx%d", space.Dx(), space.Dy()))
+		}
+		corruptedBytes = append(corruptedBytes, corruptedByte)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return corruptedBytes
-}
-
-type Path struct {
-	Length int64
-	Pos    image.Point
-}
-
-type PathHeap []Path
-
-func (h PathHeap) Len() int           { return len(h) }
-func (h PathHeap) Less(i, j int) bool { return h[i].Length < h[j].Length }
-func (h PathHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-
-func (h *PathHeap) Push(x any) {
-	*h = append(*h, x.(Path))
-}
-
-func (h *PathHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+	return corruptedBytes, nil
 }
 
 var directions = []image.Point{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1}}
 
 func shortestPath(space image.Rectangle, corruptedBytes []image.Point) int64 {
-	visited := make(map[image.Point]struct{})
-	visited[space.Min] = struct{}{}
-
-	paths := &PathHeap{Path{Length: 0, Pos: space.Min}}
-	heap.Init(paths)
-
 	corruptedBytesMap := make(map[image.Point]struct{})
 	for _, corruptedByte := range corruptedBytes {
 		corruptedBytesMap[corruptedByte] = struct{}{}
 	}
 
 	dest := space.Max.Sub(image.Point{X: 1, Y: 1})
-	for paths.Len() > 0 {
-		path := heap.Pop(paths).(Path)
-		if path.Pos == dest {
-			return path.Length
-		}
-		for _, dir := range directions {
-			nextPos := path.Pos.Add(dir)
-			if !nextPos.In(space) {
-				continue
-			}
-			if _, ok := corruptedBytesMap[nextPos]; ok {
-				continue
+	result, errSearching := search.BFS(search.Problem[image.Point]{
+		Starts: []image.Point{space.Min},
+		Neighbours: func(pos image.Point, visit func(image.Point, int)) {
+			for _, dir := range directions {
+				nextPos := pos.Add(dir)
+				if !nextPos.In(space) {
+					continue
+				}
+				if _, ok := corruptedBytesMap[nextPos]; ok {
+					continue
+				}
+				visit(nextPos, 1)
 			}
-			if _, ok := visited[nextPos]; ok {
-				continue
-			}
-			visited[nextPos] = struct{}{}
-			heap.Push(paths, Path{Length: path.Length + 1, Pos: nextPos})
-		}
+		},
+		Goal: func(pos image.Point) bool { return pos == dest },
+	})
+	if errSearching != nil {
+		return -1
 	}
-
-	return -1
+	return int64(result.Cost)
 }
 
-func getResult(input io.Reader, space image.Rectangle) string {
-	corruptedBytes := parseInput(input)
+func getResult(input io.Reader, space image.Rectangle) (string, error) {
+	corruptedBytes, errParsing := parseInput(input, space)
+	if errParsing != nil {
+		return "", errParsing
+	}
 
-	i := sort.Search(len(corruptedBytes), func(i int) bool {
+	// i is the number of fallen bytes after which the exit can't be reached.
+	i := sort.Search(len(corruptedBytes)+1, func(i int) bool {
 		return shortestPath(space, corruptedBytes[:i]) < 0
 	})
-	if i-1 < len(corruptedBytes) {
-		return fmt.Sprintf("%d,%d", corruptedBytes[i-1].X, corruptedBytes[i-1].Y)
+	if i > 0 && i <= len(corruptedBytes) {
+		return fmt.Sprintf("%d,%d", corruptedBytes[i-1].X, corruptedBytes[i-1].Y), nil
 	}
 
-	return "unknown"
+	return "unknown", nil
 }
 
 func loadFile() *os.File {
@@ -128,13 +108,22 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile, image.Rectangle{Min: image.Pt(0, 0), Max: image.Pt(71, 71)})
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   18,
+	Title: "RAM Run",
+	Input: aoc.LinesMatching(`\d+,\d+`, "'<x>,<y>'"),
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		// The memory space is smaller in the examples.
+		size, errParam := aoc.ParamPositiveInt(ctx, "size", 71)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResult(input, image.Rect(0, 0, size, size))
+		return aoc.String(result), err
+	},
+}
 
-	log.Printf("Final result: %s", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,53 +1,22 @@
-package main
+package day18
 
 import (
+	"bytes"
 	"image"
-	"strings"
 	"testing"
-)
-
-const testingInput = `5,4
-4,2
-4,5
-3,0
-2,1
-6,3
-2,4
-1,5
-0,6
-3,3
-2,6
-5,1
-1,2
-5,5
-2,5
-6,5
-1,4
-0,4
-6,4
-1,1
-6,1
-1,0
-0,5
-1,6
-2,0
-`
-
-var testingInputSpace = image.Rectangle{Min: image.Pt(0, 0), Max: image.Pt(7, 7)}
 
-const testingExpectedResult = "6,1"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
 
-func TestGetResults(t *testing.T) {
-	result := getResult(strings.NewReader(testingInput), testingInputSpace)
-	if result != testingExpectedResult {
-		t.Errorf("Expected result to be %s, got %s", testingExpectedResult, result)
-	}
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
 }
 
 func BenchmarkGetResult(b *testing.B) {
 	b.Run("small", func(b *testing.B) {
+		input := aoctest.ReadInput(b, "example")
 		for n := 0; n < b.N; n++ {
-			getResult(strings.NewReader(testingInput), testingInputSpace)
+			getResult(bytes.NewReader(input), image.Rect(0, 0, 7, 7))
 		}
 	})
 
@@ -59,3 +28,7 @@ func BenchmarkGetResult(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
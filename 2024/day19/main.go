@@ -1,34 +1,41 @@
-package main
+package day19
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseInventory(line string) map[rune][]string {
+func parseInventory(line string) (map[rune][]string, error) {
 	parts := strings.Split(line, ", ")
 	inventory := make(map[rune][]string, len(parts))
 	for _, part := range parts {
+		if part == "" {
+			return nil, aoc.Unexpected(line, "towel patterns separated by ', '")
+		}
 		inventory[rune(part[0])] = append(inventory[rune(part[0])], part)
 	}
-	return inventory
+	return inventory, nil
 }
 
 func parseModel(line string) string {
 	return line
 }
 
-func parseInput(input io.Reader) (map[rune][]string, []string) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (map[rune][]string, []string, error) {
+	scanner := aoc.NewScanner(input)
 
 	if !scanner.Scan() {
-		log.Fatalf("Unable to scan the input file correctly")
+		return nil, nil, scanner.Missing("the towel patterns")
+	}
+	inventory, errParsing := parseInventory(scanner.Text())
+	if errParsing != nil {
+		return nil, nil, scanner.Wrap(errParsing)
 	}
-	inventory := parseInventory(scanner.Text())
 
 	var models []string
 	for scanner.Scan() {
@@ -40,15 +47,15 @@ func parseInput(input io.Reader) (map[rune][]string, []string) {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return inventory, models
+	return inventory, models, nil
 }
 
-var cache = make(map[string]int64)
-
-func howManyPossibleArrangements(model string, inventory map[rune][]string) int64 {
+// howManyPossibleArrangements counts the ways to make the model with the
+// towels of the inventory, caching the counts of the model suffixes.
+func howManyPossibleArrangements(model string, inventory map[rune][]string, cache map[string]int64) int64 {
 	if model == "" {
 		return 0
 	}
@@ -66,7 +73,7 @@ func howManyPossibleArrangements(model string, inventory map[rune][]string) int6
 			if len(part) == len(model) {
 				nbArrangements++
 			} else {
-				nbArrangements += howManyPossibleArrangements(model[len(part):], inventory)
+				nbArrangements += howManyPossibleArrangements(model[len(part):], inventory, cache)
 			}
 		}
 	}
@@ -74,15 +81,19 @@ func howManyPossibleArrangements(model string, inventory map[rune][]string) int6
 	return nbArrangements
 }
 
-func getResult(input io.Reader) int64 {
-	inventory, models := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	inventory, models, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
+	cache := make(map[string]int64)
 	var nbArrangementsSum int64
 	for _, model := range models {
-		nbArrangementsSum += howManyPossibleArrangements(model, inventory)
+		nbArrangementsSum += howManyPossibleArrangements(model, inventory, cache)
 	}
 
-	return nbArrangementsSum
+	return nbArrangementsSum, nil
 }
 
 func loadFile() *os.File {
@@ -93,13 +104,20 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   19,
+	Title: "Linen Layout",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`[wubrg]+(, [wubrg]+)*`, "towel patterns separated by ', '"),
+		aoc.LinesMatching(`[wubrg]+`, "a design of stripe colors"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,3 +1,7 @@
 module github.com/antitoine/advent-of-code/2024/day20
 
-go 1.23.2
+go 1.25.4
+
+require github.com/antitoine/advent-of-code/aoc v0.0.0
+
+replace github.com/antitoine/advent-of-code/aoc => ../../aoc
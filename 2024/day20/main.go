@@ -1,13 +1,14 @@
-package main
+package day20
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"image"
 	"io"
 	"log"
 	"os"
-	"slices"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Track struct {
@@ -17,35 +18,60 @@ type Track struct {
 	space image.Rectangle
 }
 
-func parseInput(input io.Reader) Track {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (Track, error) {
+	scanner := aoc.NewScanner(input)
 
 	var track [][]rune
 	var start, end image.Point
+	foundStart, foundEnd := false, false
 	for y := 0; scanner.Scan(); y++ {
 		line := []rune(scanner.Text())
-		if startIdx := slices.Index(line, 'S'); startIdx != -1 {
-			start = image.Pt(startIdx, y)
-			line[startIdx] = '.'
+		if y > 0 && len(line) != len(track[0]) {
+			return Track{}, scanner.Unexpected(fmt.Sprintf("a line of %d positions", len(track[0])))
 		}
-		if endIdx := slices.Index(line, 'E'); endIdx != -1 {
-			end = image.Pt(endIdx, y)
-			line[endIdx] = '.'
+		for x, char := range line {
+			switch char {
+			case 'S':
+				if foundStart {
+					return Track{}, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: "S", Expected: "a single start position"}
+				}
+				start = image.Pt(x, y)
+				foundStart = true
+				line[x] = '.'
+			case 'E':
+				if foundEnd {
+					return Track{}, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: "E", Expected: "a single end position"}
+				}
+				end = image.Pt(x, y)
+				foundEnd = true
+				line[x] = '.'
+			case '#', '.':
+			default:
+				return Track{}, &aoc.ParseError{Line: scanner.Line(), Column: x + 1, Text: string(char), Expected: "a position among '#', '.', 'S' or 'E'"}
+			}
 		}
 		track = append(track, line)
 	}
-	space := image.Rect(0, 0, len(track[0]), len(track))
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return Track{}, errScanningFile
 	}
 
+	if !foundStart {
+		return Track{}, scanner.Missing("a start position 'S'")
+	}
+	if !foundEnd {
+		return Track{}, scanner.Missing("an end position 'E'")
+	}
+
+	space := image.Rect(0, 0, len(track[0]), len(track))
+
 	return Track{
 		track: track,
 		start: start,
 		end:   end,
 		space: space,
-	}
+	}, nil
 }
 
 var directions = []image.Point{
@@ -110,12 +136,15 @@ func getAllPossiblePathWithCheat(normalPath []image.Point, nbCheat int, minReduc
 	return result
 }
 
-func getResult(input io.Reader, maxCheats int, nbLeastSavingSteps int) int64 {
-	track := parseInput(input)
+func getResult(input io.Reader, maxCheats int, nbLeastSavingSteps int) (int64, error) {
+	track, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	path := getNormalPath(track)
 
-	return getAllPossiblePathWithCheat(path, maxCheats, nbLeastSavingSteps)
+	return getAllPossiblePathWithCheat(path, maxCheats, nbLeastSavingSteps), nil
 }
 
 func loadFile() *os.File {
@@ -126,13 +155,35 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
+// getLeastSaving returns the least count of steps a cheat must save, which
+// the "saving" parameter overrides for the examples.
+func getLeastSaving(ctx context.Context) (int, error) {
+	return aoc.ParamPositiveInt(ctx, "saving", 100)
+}
 
-	result := getResult(inputFile, 20, 100)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   20,
+	Title: "Race Condition",
+	Input: aoc.Grid{Alphabet: "#.SE", Counts: map[rune]int{'S': 1, 'E': 1}},
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		leastSaving, errParam := getLeastSaving(ctx)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResult(input, 2, leastSaving)
+		return aoc.Int(result), err
+	},
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		leastSaving, errParam := getLeastSaving(ctx)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		result, err := getResult(input, 20, leastSaving)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
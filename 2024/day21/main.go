@@ -1,13 +1,14 @@
-package main
+package day21
 
 import (
-	"bufio"
+	"context"
 	"image"
 	"io"
 	"log"
 	"os"
-	"strconv"
-	"time"
+	"regexp"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Code rune
@@ -62,27 +63,36 @@ var directionalMap = DirectionalMap{
 	DR: image.Pt(2, 0),
 }
 
-func parseLine(line string) []Code {
+var codeRegex = regexp.MustCompile(`^\d{3}A$`)
+
+func parseLine(line string) ([]Code, error) {
+	if !codeRegex.MatchString(line) {
+		return nil, aoc.Unexpected(line, "a code of three digits followed by 'A'")
+	}
 	var codes []Code
 	for _, c := range line {
 		codes = append(codes, Code(c))
 	}
-	return codes
+	return codes, nil
 }
 
-func parseInput(input io.Reader) [][]Code {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([][]Code, error) {
+	scanner := aoc.NewScanner(input)
 
 	var codes [][]Code
 	for scanner.Scan() {
-		codes = append(codes, parseLine(scanner.Text()))
+		code, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		codes = append(codes, code)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return codes
+	return codes, nil
 }
 
 func abs(x int) int {
@@ -252,10 +262,12 @@ func getIndividualSteps(sequence []Direction) [][]Direction {
 	return output
 }
 
+// getDigitsFromCode returns the number written by the three digits codeRegex
+// requires at the start of a code.
 func getDigitsFromCode(code []Code) int64 {
-	digits, errParse := strconv.ParseInt(string(code[:3]), 10, 64)
-	if errParse != nil {
-		log.Fatalf("Unable to parse digits: %v", errParse)
+	var digits int64
+	for _, c := range code[:3] {
+		digits = digits*10 + int64(c-'0')
 	}
 	return digits
 }
@@ -271,9 +283,12 @@ func getSequence(codes [][]Code, numericalMap NumericalMap, directionalMap Direc
 	return count
 }
 
-func getResult(input io.Reader) int64 {
-	codes := parseInput(input)
-	return getSequence(codes, numericalMap, directionalMap, 25)
+func getResult(input io.Reader, robots int) (int64, error) {
+	codes, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+	return getSequence(codes, numericalMap, directionalMap, robots), nil
 }
 
 func loadFile() *os.File {
@@ -284,13 +299,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   21,
+	Title: "Keypad Conundrum",
+	Input: aoc.LinesMatching(`\d{3}A`, "a code of three digits followed by 'A'"),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 2)
+		return aoc.Int(result), err
+	},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input, 25)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,37 +1,36 @@
-package main
+package day22
 
 import (
-	"bufio"
-	"fmt"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseLine(line string) int64 {
-	val, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
-	if err != nil {
-		log.Fatalf("Unable to parse line: %v", err)
-	}
-	return val
+func parseLine(line string) (int64, error) {
+	return aoc.ParseInt(strings.TrimSpace(line), 64)
 }
 
-func parseInput(input io.Reader) []int64 {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([]int64, error) {
+	scanner := aoc.NewScanner(input)
 
 	var secrets []int64
 	for b := 0; scanner.Scan(); b++ {
-		secrets = append(secrets, parseLine(scanner.Text()))
+		secret, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		secrets = append(secrets, secret)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return secrets
+	return secrets, nil
 }
 
 func mixSecret(secret, value int64) int64 {
@@ -70,8 +69,11 @@ func getNumbersOfBananasFromSecret(secret int64) int64 {
 	return secret % 10
 }
 
-func getResult(input io.Reader) int64 {
-	secrets := parseInput(input)
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
+	secrets, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	sequences := make(map[SequenceOfChanges]*NbBananasPerSequence)
 	for b := 0; b < len(secrets); b++ {
@@ -113,10 +115,10 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	fmt.Printf("Max number of bananas: %d\n", maxNbBananas)
-	fmt.Printf("Associated sequence: %v\n", associatedSequence)
+	aoc.Emit(ctx, aoc.Value{Name: "max number of bananas", Value: maxNbBananas})
+	aoc.Emit(ctx, aoc.Value{Name: "associated sequence", Value: associatedSequence})
 
-	return maxNbBananas
+	return maxNbBananas, nil
 }
 
 func loadFile() *os.File {
@@ -127,13 +129,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   22,
+	Title: "Monkey Market",
+	Input: aoc.LinesMatching(`\d+`, "an initial secret number"),
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,40 +1,45 @@
-package main
+package day23
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"sort"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Connections map[string][]string
 
-func parseLine(line string) (string, string) {
+func parseLine(line string) (string, string, error) {
 	parts := strings.Split(line, "-")
 	if len(parts) != 2 {
-		log.Fatalf("Expected 2 parts, got %d for line %s", len(parts), line)
+		return "", "", aoc.Unexpected(line, "'<computer>-<computer>'")
 	}
-	return parts[0], parts[1]
+	return parts[0], parts[1], nil
 }
 
-func parseInput(input io.Reader) Connections {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) (Connections, error) {
+	scanner := aoc.NewScanner(input)
 
 	connections := make(Connections)
 	for scanner.Scan() {
-		nodeA, nodeB := parseLine(scanner.Text())
+		nodeA, nodeB, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
 		connections[nodeA] = append(connections[nodeA], nodeB)
 		connections[nodeB] = append(connections[nodeB], nodeA)
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, errScanningFile
 	}
 
-	return connections
+	return connections, nil
 }
 
 func getGroupId(nodes ...string) string {
@@ -89,8 +94,11 @@ func getGroups(connections Connections, nbNodes int) []string {
 	return clusters
 }
 
-func getResult(input io.Reader) string {
-	connections := parseInput(input)
+func getResult(input io.Reader) (string, error) {
+	connections, errParsing := parseInput(input)
+	if errParsing != nil {
+		return "", errParsing
+	}
 
 	groupSize := 3
 	groups := getGroups(connections, groupSize)
@@ -100,10 +108,10 @@ func getResult(input io.Reader) string {
 	}
 
 	if len(groups) != 1 {
-		log.Fatalf("Expected 1 group, got %d", len(groups))
+		return "", fmt.Errorf("expected a single largest group of computers, got %d", len(groups))
 	}
 
-	return groups[0]
+	return groups[0], nil
 }
 
 func loadFile() *os.File {
@@ -114,13 +122,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   23,
+	Title: "LAN Party",
+	Input: aoc.LinesMatching(`[a-z]{2}-[a-z]{2}`, "'<computer>-<computer>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.String(result), err
+	},
+}
 
-	log.Printf("Final result: %s", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
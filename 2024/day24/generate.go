@@ -0,0 +1,58 @@
+package day24
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// generateInput writes the initial values of size bits for x and y, and a
+// random netlist of gates on them, ending with the z wires. Each gate only
+// reads wires set before it, so that every z wire gets a value, but the gates
+// are written in a random order.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	var wires, gates []string
+	for _, prefix := range []string{"x", "y"} {
+		for bit := 0; bit < size; bit++ {
+			wire := fmt.Sprintf("%s%02d", prefix, bit)
+			wires = append(wires, wire)
+			if _, errWriting := fmt.Fprintf(w, "%s: %d\n", wire, rng.Intn(2)); errWriting != nil {
+				return errWriting
+			}
+		}
+	}
+
+	operations := []Operation{And, Or, Xor}
+	addGate := func(output string) {
+		a, b := rng.Intn(len(wires)), rng.Intn(len(wires)-1)
+		if b >= a {
+			b++
+		}
+		gates = append(gates, fmt.Sprintf("%s %s %s -> %s", wires[a], operations[rng.Intn(len(operations))], wires[b], output))
+		wires = append(wires, output)
+	}
+	used := make(map[string]bool)
+	for len(gates) < 3*size {
+		// The internal wires don't start like the x, y and z ones.
+		name := string([]byte{byte('a' + rng.Intn(23)), byte('a' + rng.Intn(26)), byte('a' + rng.Intn(26))})
+		if !used[name] {
+			used[name] = true
+			addGate(name)
+		}
+	}
+	// The final value is read as a 64-bit integer.
+	for bit := 0; bit < min(size+1, 63); bit++ {
+		addGate(fmt.Sprintf("z%02d", bit))
+	}
+
+	rng.Shuffle(len(gates), func(i, j int) { gates[i], gates[j] = gates[j], gates[i] })
+	if _, errWriting := fmt.Fprintln(w); errWriting != nil {
+		return errWriting
+	}
+	for _, gate := range gates {
+		if _, errWriting := fmt.Fprintln(w, gate); errWriting != nil {
+			return errWriting
+		}
+	}
+	return nil
+}
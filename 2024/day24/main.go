@@ -1,14 +1,15 @@
-package main
+package day24
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Operation string
@@ -32,36 +33,43 @@ type Wire struct {
 }
 
 // Line like "y01: 1"
-func parseInitValue(line string) Wire {
+func parseInitValue(line string) (Wire, error) {
 	parts := strings.Split(line, ": ")
 	if len(parts) != 2 {
-		log.Fatalf("Expected 2 parts, got %d for line %s", len(parts), line)
+		return Wire{}, aoc.Unexpected(line, "'<wire>: <0|1>'")
+	}
+	if parts[1] != "0" && parts[1] != "1" {
+		return Wire{}, &aoc.ParseError{Column: len(parts[0]) + 3, Text: parts[1], Expected: "0 or 1"}
 	}
 	value := parts[1] == "1"
 	return Wire{
 		name:  parts[0],
 		value: &value,
-	}
+	}, nil
 }
 
 // Line like "ntg XOR fgs -> mjb"
-func parseConnection(line string) Wire {
+func parseConnection(line string) (Wire, error) {
 	parts := strings.Split(line, " -> ")
 	if len(parts) != 2 {
-		log.Fatalf("Expected 2 parts, got %d for line %s", len(parts), line)
+		return Wire{}, aoc.Unexpected(line, "'<wire> <operation> <wire> -> <wire>'")
 	}
 	operationParts := strings.Split(parts[0], " ")
 	if len(operationParts) != 3 {
-		log.Fatalf("Expected 3 parts, got %d for line %s", len(operationParts), parts[0])
+		return Wire{}, aoc.Unexpected(parts[0], "'<wire> <operation> <wire>'")
+	}
+	op := Operation(operationParts[1])
+	if op != And && op != Or && op != Xor {
+		return Wire{}, &aoc.ParseError{Column: len(operationParts[0]) + 2, Text: operationParts[1], Expected: "an operation among AND, OR or XOR"}
 	}
 	return Wire{
 		name: parts[1],
 		gate: &Gate{
 			wireA: operationParts[0],
-			op:    Operation(operationParts[1]),
+			op:    op,
 			wireB: operationParts[2],
 		},
-	}
+	}, nil
 }
 
 type System struct {
@@ -70,28 +78,26 @@ type System struct {
 }
 
 func (s System) getFinalValue() (int64, bool) {
-	var binaryStr string
-	for _, wireName := range s.finalWires {
+	var value int64
+	for i, wireName := range s.finalWires {
 		wire := s.wires[wireName]
 		if wire.value == nil {
 			return 0, false
 		}
-		wireValue := *wire.value
-		if wireValue {
-			binaryStr = "1" + binaryStr
-		} else {
-			binaryStr = "0" + binaryStr
+		if *wire.value {
+			value |= 1 << i
 		}
 	}
-	value, err := strconv.ParseInt(binaryStr, 2, 64)
-	if err != nil {
-		log.Fatalf("Unable to parse binary string %s: %v", binaryStr, err)
-	}
 	return value, true
 }
 
-func parseInput(input io.Reader) System {
-	scanner := bufio.NewScanner(input)
+var (
+	errNoFinalWires  = errors.New("no wire starting with z, or more than 63 of them")
+	errFloatingWires = errors.New("some wires starting with z never get a value")
+)
+
+func parseInput(input io.Reader) (System, error) {
+	scanner := aoc.NewScanner(input)
 
 	system := System{
 		wires:      make(map[string]Wire),
@@ -105,10 +111,14 @@ func parseInput(input io.Reader) System {
 			continue
 		}
 		var wire Wire
+		var errParsing error
 		if initValuesForWires {
-			wire = parseInitValue(line)
+			wire, errParsing = parseInitValue(line)
 		} else {
-			wire = parseConnection(line)
+			wire, errParsing = parseConnection(line)
+		}
+		if errParsing != nil {
+			return System{}, scanner.Wrap(errParsing)
 		}
 		system.wires[wire.name] = wire
 		if strings.HasPrefix(wire.name, "z") {
@@ -117,19 +127,27 @@ func parseInput(input io.Reader) System {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return System{}, errScanningFile
 	}
 
 	sort.Strings(system.finalWires)
 
-	return system
+	return system, nil
 }
 
-func getResult(input io.Reader) int64 {
-	system := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	system, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
+
+	if len(system.finalWires) == 0 || len(system.finalWires) > 63 {
+		return 0, errNoFinalWires
+	}
 
 	finalValue, okFinalValue := system.getFinalValue()
 	for !okFinalValue {
+		progressed := false
 		for wireName, wire := range system.wires {
 			if wire.value != nil {
 				continue
@@ -154,18 +172,20 @@ func getResult(input io.Reader) int64 {
 				result = valueA || valueB
 			case Xor:
 				result = valueA != valueB
-			default:
-				log.Fatalf("Unknown operation %s", gate.op)
 			}
 			system.wires[wireName] = Wire{
 				name:  wireName,
 				value: &result,
 			}
+			progressed = true
+		}
+		if !progressed {
+			return 0, errFloatingWires
 		}
 		finalValue, okFinalValue = system.getFinalValue()
 	}
 
-	return finalValue
+	return finalValue, nil
 }
 
 func loadFile() *os.File {
@@ -176,13 +196,21 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   24,
+	Title: "Crossed Wires",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`[a-z0-9]+: [01]`, "an initial value as '<wire>: <0|1>'"),
+		aoc.LinesMatching(`[a-z0-9]+ (AND|OR|XOR) [a-z0-9]+ -> [a-z0-9]+`, "a gate as '<wire> <operation> <wire> -> <wire>'"),
+	),
+	Generate: generateInput,
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,11 +1,13 @@
-package main
+package day25
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"time"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 /*
@@ -40,18 +42,27 @@ func parseKey(lines []string) [5]int {
 	return key
 }
 
-func parseInput(input io.Reader) ([][5]int, [][5]int) {
-	scanner := bufio.NewScanner(input)
+func parseInput(input io.Reader) ([][5]int, [][5]int, error) {
+	scanner := aoc.NewScanner(input)
 
 	var locks [][5]int
 	var keys [][5]int
 
-	parseLines := func(lines []string) {
+	parseLines := func(lines []string, lastLine int) error {
+		if len(lines) != 7 {
+			return &aoc.ParseError{Line: lastLine, Expected: "a schematic of 7 lines"}
+		}
+		for i, line := range lines {
+			if len(line) != 5 || strings.Trim(line, ".#") != "" {
+				return &aoc.ParseError{Line: lastLine - len(lines) + 1 + i, Text: line, Expected: "a line of 5 '.' or '#'"}
+			}
+		}
 		if lines[0][0] == '#' {
 			locks = append(locks, parseLock(lines))
 		} else {
 			keys = append(keys, parseKey(lines))
 		}
+		return nil
 	}
 
 	var currentLines []string
@@ -61,20 +72,28 @@ func parseInput(input io.Reader) ([][5]int, [][5]int) {
 			currentLines = append(currentLines, line)
 			continue
 		}
-		parseLines(currentLines)
+		if errParsing := parseLines(currentLines, scanner.Line()-1); errParsing != nil {
+			return nil, nil, errParsing
+		}
 		currentLines = nil
 	}
-	parseLines(currentLines)
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return nil, nil, errScanningFile
 	}
 
-	return locks, keys
+	if errParsing := parseLines(currentLines, scanner.Line()); errParsing != nil {
+		return nil, nil, errParsing
+	}
+
+	return locks, keys, nil
 }
 
-func getResult(input io.Reader) int64 {
-	locks, keys := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	locks, keys, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	var nbFit int64
 	for _, lock := range locks {
@@ -91,7 +110,7 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	return nbFit
+	return nbFit, nil
 }
 
 func loadFile() *os.File {
@@ -102,13 +121,26 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+// lockOrKey requires a schematic to be a lock, with its top row filled, or a
+// key, with its bottom row filled.
+var lockOrKey = aoc.InputSpecFunc(func(lines []string, first int) []*aoc.ParseError {
+	if len(lines) == 0 || lines[0] == "#####" || lines[len(lines)-1] == "#####" {
+		return nil
+	}
+	return []*aoc.ParseError{{Line: first, Column: 1, Text: lines[0], Expected: "a lock starting or a key ending with '#####'"}}
+})
+
+var Solver = aoc.Solver{
+	Year:  2024,
+	Day:   25,
+	Title: "Code Chronicle",
+	Input: aoc.Blocks(aoc.AllOf(aoc.Grid{Alphabet: ".#", Width: 5, Height: 7}, lockOrKey)),
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
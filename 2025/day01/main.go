@@ -1,40 +1,43 @@
-package main
+package day01
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 const initialValue = 50
 
 var lineRegex = regexp.MustCompile(`^([LR])(\d+)$`)
 
-func parseLine(line string) int {
+func parseLine(line string) (int, error) {
 	matches := lineRegex.FindStringSubmatch(line)
 	if matches == nil || len(matches) != 3 {
-		log.Fatalf("Unable to parse line: %s", line)
+		return 0, aoc.Unexpected(line, "'<L|R><distance>'")
 	}
-	number, err := strconv.Atoi(matches[2])
+	number, err := aoc.Atoi(matches[2])
 	if err != nil {
-		log.Fatalf("Unable to parse number: %v", err)
+		return 0, err
 	}
 	if matches[1] == "L" {
-		return -number
+		return -number, nil
 	}
-	return number
+	return number, nil
 }
 
-func computePassword(input io.Reader) int {
-	scanner := bufio.NewScanner(input)
+func computePassword(input io.Reader) (int, error) {
+	scanner := aoc.NewScanner(input)
 	nbOfZeroes := 0
 	currentValue := initialValue
 	for scanner.Scan() {
-		rotation := parseLine(scanner.Text())
+		rotation, errParsing := parseLine(scanner.Text())
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
 		nbOfZeroes += max(rotation, -rotation) / 100
 
 		newValue := currentValue + rotation%100
@@ -45,13 +48,13 @@ func computePassword(input io.Reader) int {
 	}
 
 	if errScanningFile := scanner.Err(); errScanningFile != nil {
-		log.Fatalf("Unable to scan the input file correctly: %v", errScanningFile)
+		return 0, errScanningFile
 	}
 
-	return nbOfZeroes
+	return nbOfZeroes, nil
 }
 
-func getResult(input io.Reader) int {
+func getResult(input io.Reader) (int, error) {
 	return computePassword(input)
 }
 
@@ -63,13 +66,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   1,
+	Title: "Secret Entrance",
+	Input: aoc.LinesMatching(`[LR]\d+`, "'<L|R><distance>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
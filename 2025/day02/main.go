@@ -1,23 +1,23 @@
-package main
+package day02
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type idRange struct {
 	start, end int
 }
 
-func parseInput(input io.Reader) ([]idRange, int) {
+func parseInput(input io.Reader) ([]idRange, int, error) {
 	var ranges []idRange
 	maxId := 0
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
@@ -31,17 +31,26 @@ func parseInput(input io.Reader) ([]idRange, int) {
 			}
 			rangeParts := strings.Split(part, "-")
 			if len(rangeParts) != 2 {
-				continue
+				return nil, 0, scanner.Wrap(aoc.Unexpected(part, "'<start>-<end>'"))
+			}
+			start, errParsing := aoc.Atoi(rangeParts[0])
+			if errParsing != nil {
+				return nil, 0, scanner.Wrap(errParsing)
+			}
+			end, errParsing := aoc.Atoi(rangeParts[1])
+			if errParsing != nil {
+				return nil, 0, scanner.Wrap(errParsing)
 			}
-			start, _ := strconv.Atoi(rangeParts[0])
-			end, _ := strconv.Atoi(rangeParts[1])
 			if end > maxId {
 				maxId = end
 			}
 			ranges = append(ranges, idRange{start, end})
 		}
 	}
-	return ranges, maxId
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, 0, errScanningFile
+	}
+	return ranges, maxId, nil
 }
 
 func generateInvalidNumbers(maxVal int) []int {
@@ -90,8 +99,11 @@ func intPow(base, exp int) int {
 	return result
 }
 
-func getResult(input io.Reader) int {
-	ranges, maxId := parseInput(input)
+func getResult(input io.Reader) (int, error) {
+	ranges, maxId, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	invalidNumbers := generateInvalidNumbers(maxId)
 
@@ -105,7 +117,7 @@ func getResult(input io.Reader) int {
 		}
 	}
 
-	return sum
+	return sum, nil
 }
 
 func loadFile() *os.File {
@@ -116,13 +128,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   2,
+	Title: "Gift Shop",
+	Input: aoc.LinesMatching(`\d+-\d+(,\d+-\d+)*`, "ranges as '<start>-<end>' separated by commas"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
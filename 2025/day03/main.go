@@ -1,11 +1,13 @@
-package main
+package day03
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 const digitsToSelect = 12
@@ -32,17 +34,28 @@ func maxJoltage(bank string) int64 {
 	return result
 }
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	total := int64(0)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
+		if len(line) < digitsToSelect {
+			return 0, scanner.Unexpected(fmt.Sprintf("a bank of at least %d batteries", digitsToSelect))
+		}
+		for i, c := range line {
+			if c < '0' || c > '9' {
+				return 0, &aoc.ParseError{Line: scanner.Line(), Column: i + 1, Text: string(c), Expected: "a digit"}
+			}
+		}
 		total += maxJoltage(line)
 	}
-	return total
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
+	}
+	return total, nil
 }
 
 func loadFile() *os.File {
@@ -53,13 +66,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   3,
+	Title: "Lobby",
+	Input: aoc.LinesMatching(`\d+`, "a bank of battery digits"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
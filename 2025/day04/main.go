@@ -1,23 +1,37 @@
-package main
+package day04
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func getResult(input io.Reader) int64 {
+func getResult(input io.Reader) (int64, error) {
 	var grid [][]byte
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
-		grid = append(grid, []byte(scanner.Text()))
+		line := scanner.Text()
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			return 0, scanner.Unexpected(fmt.Sprintf("a line of %d places", len(grid[0])))
+		}
+		for i, c := range line {
+			if c != '.' && c != '@' {
+				return 0, &aoc.ParseError{Line: scanner.Line(), Column: i + 1, Text: string(c), Expected: "'.' or '@'"}
+			}
+		}
+		grid = append(grid, []byte(line))
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
 	}
 
 	rows := len(grid)
 	if rows == 0 {
-		return 0
+		return 0, nil
 	}
 	cols := len(grid[0])
 
@@ -60,7 +74,7 @@ func getResult(input io.Reader) int64 {
 		totalRemoved += int64(len(toRemove))
 	}
 
-	return totalRemoved
+	return totalRemoved, nil
 }
 
 func loadFile() *os.File {
@@ -71,13 +85,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   4,
+	Title: "Printing Department",
+	Input: aoc.Grid{Alphabet: ".@"},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,24 +1,20 @@
-package main
+package day05
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"sort"
-	"strconv"
 	"strings"
-	"time"
-)
 
-type Range struct {
-	start, end int64
-}
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/intervals"
+)
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 
-	var ranges []Range
+	var ranges []intervals.Interval
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -28,30 +24,24 @@ func getResult(input io.Reader) int64 {
 		}
 
 		parts := strings.Split(line, "-")
-		start, _ := strconv.ParseInt(parts[0], 10, 64)
-		end, _ := strconv.ParseInt(parts[1], 10, 64)
-		ranges = append(ranges, Range{start: start, end: end})
-	}
-
-	sort.Slice(ranges, func(i, j int) bool {
-		return ranges[i].start < ranges[j].start
-	})
-
-	var merged []Range
-	for _, r := range ranges {
-		if len(merged) == 0 || r.start > merged[len(merged)-1].end+1 {
-			merged = append(merged, r)
-		} else if r.end > merged[len(merged)-1].end {
-			merged[len(merged)-1].end = r.end
+		if len(parts) != 2 {
+			return 0, scanner.Unexpected("'<start>-<end>'")
 		}
+		start, errParsing := aoc.ParseInt(parts[0], 64)
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		end, errParsing := aoc.ParseInt(parts[1], 64)
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		ranges = append(ranges, intervals.Closed(start, end))
 	}
-
-	var totalFresh int64
-	for _, r := range merged {
-		totalFresh += r.end - r.start + 1
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
 	}
 
-	return totalFresh
+	return intervals.NewSet(ranges...).Cardinality(), nil
 }
 
 func loadFile() *os.File {
@@ -62,13 +52,20 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   5,
+	Title: "Cafeteria",
+	Input: aoc.Sections(
+		aoc.LinesMatching(`\d+-\d+`, "a fresh range as '<start>-<end>'"),
+		aoc.LinesMatching(`\d+`, "an ingredient ID"),
+	),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
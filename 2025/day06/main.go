@@ -1,25 +1,40 @@
-package main
+package day06
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	var lines []string
 
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
+	}
 
 	if len(lines) == 0 {
-		return 0
+		return 0, nil
+	}
+
+	for row, line := range lines {
+		allowed, expected := "0123456789 ", "a digit or a space"
+		if row == len(lines)-1 {
+			allowed, expected = "*+ ", "an operator among '*' or '+', or a space"
+		}
+		for col, c := range line {
+			if !strings.ContainsRune(allowed, c) {
+				return 0, &aoc.ParseError{Line: row + 1, Column: col + 1, Text: string(c), Expected: expected}
+			}
+		}
 	}
 
 	maxWidth := 0
@@ -78,6 +93,9 @@ func getResult(input io.Reader) int64 {
 				break
 			}
 		}
+		if op == 0 {
+			return 0, &aoc.ParseError{Line: operatorRow + 1, Column: startCol + 1, Expected: "an operator for the problem"}
+		}
 
 		var numbers []int64
 		for col := endCol - 1; col >= startCol; col-- {
@@ -89,7 +107,10 @@ func getResult(input io.Reader) int64 {
 				}
 			}
 			if numStr != "" {
-				num, _ := strconv.ParseInt(numStr, 10, 64)
+				num, errParsing := aoc.ParseInt(numStr, 64)
+				if errParsing != nil {
+					return 0, errParsing
+				}
 				numbers = append(numbers, num)
 			}
 		}
@@ -111,7 +132,7 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	return total
+	return total, nil
 }
 
 func loadFile() *os.File {
@@ -122,13 +143,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   6,
+	Title: "Trash Compactor",
+	Input: aoc.LinesMatching(`[\d ]+|[*+ ]+`, "a line of numbers or of operators"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
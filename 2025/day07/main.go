@@ -1,25 +1,39 @@
-package main
+package day07
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	var grid []string
 	for scanner.Scan() {
 		line := scanner.Text()
-		if line != "" {
-			grid = append(grid, line)
+		if line == "" {
+			continue
+		}
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			return 0, scanner.Unexpected(fmt.Sprintf("a line of %d places", len(grid[0])))
+		}
+		for col, ch := range line {
+			if ch != '.' && ch != '^' && ch != 'S' {
+				return 0, &aoc.ParseError{Line: scanner.Line(), Column: col + 1, Text: string(ch), Expected: "'.', '^' or 'S'"}
+			}
 		}
+		grid = append(grid, line)
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
 	}
 
 	if len(grid) == 0 {
-		return 0
+		return 0, nil
 	}
 
 	// Find starting position 'S'
@@ -39,7 +53,7 @@ func getResult(input io.Reader) int64 {
 	}
 
 	if startCol == -1 {
-		return 0
+		return 0, scanner.Missing("a starting position 'S'")
 	}
 
 	width := len(grid[0])
@@ -86,7 +100,7 @@ func getResult(input io.Reader) int64 {
 		total += count
 	}
 
-	return total
+	return total, nil
 }
 
 func loadFile() *os.File {
@@ -97,13 +111,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   7,
+	Title: "Laboratories",
+	Input: aoc.Grid{Alphabet: ".^S", Counts: map[rune]int{'S': 1}},
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,15 +1,15 @@
-package main
+package day08
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"math"
 	"os"
 	"slices"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Point struct {
@@ -80,8 +80,8 @@ func distance(a, b Point) float64 {
 	return math.Sqrt(dx*dx + dy*dy + dz*dz)
 }
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	var points []Point
 
 	for scanner.Scan() {
@@ -90,13 +90,27 @@ func getResult(input io.Reader) int64 {
 			continue
 		}
 		parts := strings.Split(line, ",")
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
-		z, _ := strconv.Atoi(parts[2])
-		points = append(points, Point{x, y, z})
+		if len(parts) != 3 {
+			return 0, scanner.Unexpected("'<x>,<y>,<z>'")
+		}
+		var coordinates [3]int
+		for i, part := range parts {
+			coordinate, errParsing := aoc.Atoi(part)
+			if errParsing != nil {
+				return 0, scanner.Wrap(errParsing)
+			}
+			coordinates[i] = coordinate
+		}
+		points = append(points, Point{coordinates[0], coordinates[1], coordinates[2]})
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
 	}
 
 	n := len(points)
+	if n < 2 {
+		return 0, scanner.Missing("at least 2 junction boxes")
+	}
 
 	// Generate all pairs with distances
 	var pairs []Pair
@@ -133,7 +147,7 @@ func getResult(input io.Reader) int64 {
 	}
 
 	// Return the product of X coordinates of the last two boxes connected
-	return int64(points[lastPair.i].x) * int64(points[lastPair.j].x)
+	return int64(points[lastPair.i].x) * int64(points[lastPair.j].x), nil
 }
 
 func loadFile() *os.File {
@@ -144,13 +158,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   8,
+	Title: "Playground",
+	Input: aoc.LinesMatching(`\d+,\d+,\d+`, "a junction box as three comma-separated integers"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,23 +1,23 @@
-package main
+package day09
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Point struct {
 	x, y int
 }
 
-func parseInput(input io.Reader) []Point {
+func parseInput(input io.Reader) ([]Point, error) {
 	var points []Point
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
@@ -25,13 +25,25 @@ func parseInput(input io.Reader) []Point {
 		}
 		parts := strings.Split(line, ",")
 		if len(parts) != 2 {
-			continue
+			return nil, scanner.Unexpected("'<x>,<y>'")
+		}
+		x, errParsing := aoc.Atoi(parts[0])
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		y, errParsing := aoc.Atoi(parts[1])
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
 		}
-		x, _ := strconv.Atoi(parts[0])
-		y, _ := strconv.Atoi(parts[1])
 		points = append(points, Point{x, y})
 	}
-	return points
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, errScanningFile
+	}
+	if len(points) == 0 {
+		return nil, scanner.Missing("'<x>,<y>'")
+	}
+	return points, nil
 }
 
 func minInt(a, b int) int {
@@ -72,8 +84,11 @@ func isPointInsidePolygon(px, py float64, redTiles []Point) bool {
 	return count%2 == 1
 }
 
-func getResult(input io.Reader) int64 {
-	redTiles := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	redTiles, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 
 	// Coordinate compression: extract unique x and y coordinates
 	xSet := make(map[int]bool)
@@ -211,7 +226,7 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
-	return maxArea
+	return maxArea, nil
 }
 
 func loadFile() *os.File {
@@ -222,13 +237,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   9,
+	Title: "Movie Theater",
+	Input: aoc.LinesMatching(`\d+,\d+`, "'<x>,<y>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
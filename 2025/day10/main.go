@@ -1,14 +1,16 @@
-package main
+package day10
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/linalg"
 )
 
 type Machine struct {
@@ -20,17 +22,22 @@ type Machine struct {
 var buttonRegex = regexp.MustCompile(`\(([0-9,]+)\)`)
 var joltageRegex = regexp.MustCompile(`\{([0-9,]+)\}`)
 
-func parseMachine(line string) Machine {
+func parseMachine(line string) (Machine, error) {
 	var m Machine
 
 	joltageMatch := joltageRegex.FindStringSubmatch(line)
-	if joltageMatch != nil {
-		parts := strings.Split(joltageMatch[1], ",")
-		m.numCounters = len(parts)
-		m.targets = make([]int, len(parts))
-		for i, p := range parts {
-			m.targets[i], _ = strconv.Atoi(p)
+	if joltageMatch == nil {
+		return Machine{}, aoc.Unexpected(line, "'{<joltage>,...}' requirements")
+	}
+	parts := strings.Split(joltageMatch[1], ",")
+	m.numCounters = len(parts)
+	m.targets = make([]int, len(parts))
+	for i, p := range parts {
+		target, errParsing := aoc.Atoi(p)
+		if errParsing != nil {
+			return Machine{}, errParsing
 		}
+		m.targets[i] = target
 	}
 
 	matches := buttonRegex.FindAllStringSubmatch(line, -1)
@@ -38,148 +45,67 @@ func parseMachine(line string) Machine {
 		var button []int
 		parts := strings.Split(match[1], ",")
 		for _, p := range parts {
-			idx, _ := strconv.Atoi(p)
+			idx, errParsing := aoc.Atoi(p)
+			if errParsing != nil {
+				return Machine{}, errParsing
+			}
+			if idx >= m.numCounters {
+				return Machine{}, aoc.Unexpected(match[0], fmt.Sprintf("counter indexes below %d", m.numCounters))
+			}
 			button = append(button, idx)
 		}
 		m.buttons = append(m.buttons, button)
 	}
 
-	return m
-}
-
-type Rat struct{ n, d int64 }
-
-func gcd(a, b int64) int64 {
-	if a < 0 {
-		a = -a
-	}
-	if b < 0 {
-		b = -b
-	}
-	for b != 0 {
-		a, b = b, a%b
-	}
-	if a == 0 {
-		return 1
-	}
-	return a
-}
-
-func newRat(n, d int64) Rat {
-	if d == 0 {
-		return Rat{0, 0}
-	}
-	if d < 0 {
-		n, d = -n, -d
-	}
-	if n == 0 {
-		return Rat{0, 1}
-	}
-	g := gcd(n, d)
-	return Rat{n / g, d / g}
-}
-
-func (r Rat) sub(s Rat) Rat { return newRat(r.n*s.d-s.n*r.d, r.d*s.d) }
-func (r Rat) mul(s Rat) Rat { return newRat(r.n*s.n, r.d*s.d) }
-func (r Rat) div(s Rat) Rat {
-	if s.n == 0 {
-		return Rat{0, 0}
-	}
-	return newRat(r.n*s.d, r.d*s.n)
+	return m, nil
 }
 
-func minButtonPresses(m Machine) int {
+// minButtonPresses returns the fewest presses reaching the targets of the
+// machine, or -1 when they can't be reached. Once the checker is canceled, it
+// returns the fewest presses found so far.
+func minButtonPresses(m Machine, checker *aoc.Checker) (int, error) {
 	numButtons := len(m.buttons)
-	numCounters := m.numCounters
-
-	if numButtons == 0 {
-		for _, t := range m.targets {
-			if t != 0 {
-				return -1
-			}
-		}
-		return 0
-	}
-
-	// Build augmented matrix [A | t]
-	matrix := make([][]Rat, numCounters)
-	for i := 0; i < numCounters; i++ {
-		matrix[i] = make([]Rat, numButtons+1)
-		for j := 0; j <= numButtons; j++ {
-			matrix[i][j] = Rat{0, 1}
-		}
-		matrix[i][numButtons] = Rat{int64(m.targets[i]), 1}
-	}
 
+	// Each counter sums the presses of the buttons increasing it
+	counters := linalg.New(m.numCounters, numButtons)
 	for j, button := range m.buttons {
 		for _, idx := range button {
-			matrix[idx][j] = Rat{1, 1}
+			counters.Set(idx, j, linalg.Int(1))
 		}
 	}
-
-	// Gaussian elimination to RREF
-	pivotCol := make([]int, numCounters)
-	for i := range pivotCol {
-		pivotCol[i] = -1
+	targets := make([]linalg.Rat, m.numCounters)
+	for i, t := range m.targets {
+		targets[i] = linalg.Int(int64(t))
 	}
 
-	row := 0
-	for col := 0; col < numButtons && row < numCounters; col++ {
-		pivotRow := -1
-		for i := row; i < numCounters; i++ {
-			if matrix[i][col].n != 0 {
-				pivotRow = i
-				break
-			}
-		}
-		if pivotRow == -1 {
-			continue
-		}
-
-		matrix[row], matrix[pivotRow] = matrix[pivotRow], matrix[row]
-		pivotCol[row] = col
-
-		pivot := matrix[row][col]
-		for j := col; j <= numButtons; j++ {
-			matrix[row][j] = matrix[row][j].div(pivot)
-		}
-
-		for i := 0; i < numCounters; i++ {
-			if i == row || matrix[i][col].n == 0 {
-				continue
-			}
-			factor := matrix[i][col]
-			for j := col; j <= numButtons; j++ {
-				matrix[i][j] = matrix[i][j].sub(factor.mul(matrix[row][j]))
-			}
-		}
-		row++
+	solution, errSolving := counters.Solve(targets)
+	if errSolving != nil {
+		return -1, nil
 	}
-
-	rank := row
-
-	// Check for inconsistency
-	for i := rank; i < numCounters; i++ {
-		if matrix[i][numButtons].n != 0 {
-			return -1
-		}
+	// Integers are enough to enumerate the solutions, and much faster
+	scaled, errScaling := solution.Scaled()
+	if errScaling != nil {
+		return 0, errScaling
 	}
 
-	// Identify pivot and free variables
-	isPivot := make([]bool, numButtons)
-	pivotRowOf := make([]int, numButtons)
-	for i := 0; i < rank; i++ {
-		if pivotCol[i] >= 0 {
-			isPivot[pivotCol[i]] = true
-			pivotRowOf[pivotCol[i]] = i
+	// Helper to count the presses of a solution, which must be natural numbers
+	countPresses := func(presses []int64) (int, bool) {
+		total := 0
+		for _, p := range presses {
+			if p < 0 || p%scaled.Denominator != 0 {
+				return 0, false
+			}
+			total += int(p / scaled.Denominator)
 		}
+		return total, true
 	}
 
-	freeVars := []int{}
-	for j := 0; j < numButtons; j++ {
-		if !isPivot[j] {
-			freeVars = append(freeVars, j)
+	// If no free variables, unique solution
+	if solution.Unique() {
+		if total, valid := countPresses(scaled.Particular); valid {
+			return total, nil
 		}
+		return -1, nil
 	}
 
 	// Compute sum of all targets for upper bound
@@ -188,46 +114,9 @@ func minButtonPresses(m Machine) int {
 		sumTargets += t
 	}
 
-	// If no free variables, unique solution
-	if len(freeVars) == 0 {
-		sum := 0
-		for j := 0; j < numButtons; j++ {
-			if isPivot[j] {
-				r := matrix[pivotRowOf[j]][numButtons]
-				if r.d != 1 || r.n < 0 {
-					return -1
-				}
-				sum += int(r.n)
-			}
-		}
-		return sum
-	}
-
 	// With free variables, enumerate
 	bestSum := int(^uint(0) >> 1)
-	freeVals := make([]int, len(freeVars))
-
-	// Helper to evaluate solution for given free variable values
-	evalSolution := func() (int, bool) {
-		total := 0
-		for i := range freeVars {
-			total += freeVals[i]
-		}
-		for j := 0; j < numButtons; j++ {
-			if isPivot[j] {
-				r := pivotRowOf[j]
-				val := matrix[r][numButtons]
-				for i, fv := range freeVars {
-					val = val.sub(matrix[r][fv].mul(Rat{int64(freeVals[i]), 1}))
-				}
-				if val.d != 1 || val.n < 0 {
-					return 0, false
-				}
-				total += int(val.n)
-			}
-		}
-		return total, true
-	}
+	presses := append([]int64(nil), scaled.Particular...)
 
 	maxFreeVal := sumTargets
 	if maxFreeVal > 500 {
@@ -236,30 +125,41 @@ func minButtonPresses(m Machine) int {
 
 	var enumerate func(idx int)
 	enumerate = func(idx int) {
-		if idx == len(freeVars) {
-			if total, valid := evalSolution(); valid && total < bestSum {
+		if idx == len(scaled.Basis) {
+			if total, valid := countPresses(presses); valid && total < bestSum {
 				bestSum = total
 			}
 			return
 		}
 
+		// Pressing the free button once more adds its basis vector
+		basis := scaled.Basis[idx]
 		for v := 0; v <= maxFreeVal; v++ {
-			freeVals[idx] = v
+			if checker.Canceled() {
+				return
+			}
 			enumerate(idx + 1)
+			for j, b := range basis {
+				presses[j] += b
+			}
+		}
+		for j, b := range basis {
+			presses[j] -= b * int64(maxFreeVal+1)
 		}
 	}
 
 	enumerate(0)
 
 	if bestSum == int(^uint(0)>>1) {
-		return -1
+		return -1, nil
 	}
-	return bestSum
+	return bestSum, nil
 }
 
-func getResult(input io.Reader) int64 {
-	scanner := bufio.NewScanner(input)
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
+	scanner := aoc.NewScanner(input)
 	var totalPresses int64 = 0
+	checker := aoc.NewChecker(ctx, 100000)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -267,14 +167,29 @@ func getResult(input io.Reader) int64 {
 			continue
 		}
 
-		machine := parseMachine(line)
-		presses := minButtonPresses(machine)
+		machine, errParsing := parseMachine(line)
+		if errParsing != nil {
+			return 0, scanner.Wrap(errParsing)
+		}
+		presses, errSolving := minButtonPresses(machine, checker)
+		if errSolving != nil {
+			return 0, scanner.Wrap(errSolving)
+		}
 		if presses >= 0 {
 			totalPresses += int64(presses)
 		}
+		if checker.Canceled() {
+			// The machines left are missing, and the last one may not have
+			// its fewest presses yet.
+			return 0, aoc.Canceled(ctx, aoc.Int(totalPresses))
+		}
 	}
 
-	return totalPresses
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
+	}
+
+	return totalPresses, nil
 }
 
 func loadFile() *os.File {
@@ -285,13 +200,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   10,
+	Title: "Factory",
+	Input: aoc.LinesMatching(`\[[.#]+\]( \(\d+(,\d+)*\))+ \{\d+(,\d+)*\}`, "a machine as '[<lights>] <buttons> {<joltages>}'"),
+	Part2: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -1,28 +1,35 @@
-package main
+package day11
 
 import (
-	"bufio"
+	"context"
 	"io"
 	"log"
 	"os"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
-func parseInput(input io.Reader) map[string][]string {
+func parseInput(input io.Reader) (map[string][]string, error) {
 	graph := make(map[string][]string)
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 		parts := strings.Split(line, ": ")
+		if len(parts) != 2 {
+			return nil, scanner.Unexpected("'<device>: <output> ...'")
+		}
 		device := parts[0]
 		outputs := strings.Split(parts[1], " ")
 		graph[device] = outputs
 	}
-	return graph
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, errScanningFile
+	}
+	return graph, nil
 }
 
 type memoKey struct {
@@ -65,10 +72,13 @@ func countPaths(graph map[string][]string, memo map[memoKey]int64, current, targ
 	return count
 }
 
-func getResult(input io.Reader) int64 {
-	graph := parseInput(input)
+func getResult(input io.Reader) (int64, error) {
+	graph, errParsing := parseInput(input)
+	if errParsing != nil {
+		return 0, errParsing
+	}
 	memo := make(map[memoKey]int64)
-	return countPaths(graph, memo, "svr", "out", false, false)
+	return countPaths(graph, memo, "svr", "out", false, false), nil
 }
 
 func loadFile() *os.File {
@@ -79,13 +89,17 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:  2025,
+	Day:   11,
+	Title: "Reactor",
+	Input: aoc.LinesMatching(`[a-z]+:( [a-z]+)+`, "'<device>: <outputs>'"),
+	Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
@@ -0,0 +1,45 @@
+package day12
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// generateShapes is the number of shapes of the generated inputs, the one of
+// the real inputs.
+const generateShapes = 6
+
+// generateInput writes shapes of five to eight cells in 3×3 grids, followed
+// by size small regions. The presents of each region fill from half to all of
+// its area, so that some of them fit and some don't.
+func generateInput(rng *rand.Rand, size int, w io.Writer) error {
+	var sb strings.Builder
+	for shape := 0; shape < generateShapes; shape++ {
+		cells := rng.Perm(9)[:5+rng.Intn(4)]
+		grid := []byte(".........")
+		for _, cell := range cells {
+			grid[cell] = '#'
+		}
+		fmt.Fprintf(&sb, "%d:\n%s\n%s\n%s\n\n", shape, grid[0:3], grid[3:6], grid[6:9])
+	}
+
+	for region := 0; region < size; region++ {
+		width, height := 3+rng.Intn(6), 3+rng.Intn(6)
+		area := width * height * (50 + rng.Intn(51)) / 100
+		counts := make([]int, generateShapes)
+		// A present covers about 6.5 cells.
+		for presents := area * 2 / 13; presents > 0; presents-- {
+			counts[rng.Intn(generateShapes)]++
+		}
+		fmt.Fprintf(&sb, "%dx%d:", width, height)
+		for _, count := range counts {
+			fmt.Fprintf(&sb, " %d", count)
+		}
+		sb.WriteByte('\n')
+	}
+
+	_, errWriting := io.WriteString(w, sb.String())
+	return errWriting
+}
@@ -1,15 +1,14 @@
-package main
+package day12
 
 import (
-	"bufio"
-	"fmt"
+	"context"
 	"io"
 	"log"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
-	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
 )
 
 type Shape struct {
@@ -157,7 +156,7 @@ func generatePieces(shapeIdx int, grid [][]bool) []Piece {
 }
 
 func parseInput(input io.Reader) ([]Shape, []Region, error) {
-	scanner := bufio.NewScanner(input)
+	scanner := aoc.NewScanner(input)
 	var shapes []Shape
 	var regions []Region
 
@@ -190,31 +189,31 @@ func parseInput(input io.Reader) ([]Shape, []Region, error) {
 			}
 
 			parts := strings.Fields(line)
-			if len(parts) < 3 {
-				continue
+			if len(parts) < 2 || !strings.HasSuffix(parts[0], ":") {
+				return nil, nil, scanner.Unexpected("'<width>x<height>: <count> ...'")
 			}
 
 			sizePart := strings.TrimSuffix(parts[0], ":")
 			sizeParts := strings.Split(sizePart, "x")
 			if len(sizeParts) != 2 {
-				continue
+				return nil, nil, scanner.Wrap(aoc.Unexpected(sizePart, "'<width>x<height>'"))
 			}
 
-			width, err := strconv.Atoi(sizeParts[0])
+			width, err := aoc.Atoi(sizeParts[0])
 			if err != nil {
-				continue
+				return nil, nil, scanner.Wrap(err)
 			}
 
-			height, err := strconv.Atoi(sizeParts[1])
+			height, err := aoc.Atoi(sizeParts[1])
 			if err != nil {
-				continue
+				return nil, nil, scanner.Wrap(err)
 			}
 
 			counts := make([]int, len(parts)-1)
 			for i := 1; i < len(parts); i++ {
-				count, err := strconv.Atoi(parts[i])
+				count, err := aoc.Atoi(parts[i])
 				if err != nil {
-					return nil, nil, fmt.Errorf("invalid count: %v", err)
+					return nil, nil, scanner.Wrap(err)
 				}
 				counts[i-1] = count
 			}
@@ -235,13 +234,17 @@ func parseInput(input io.Reader) ([]Shape, []Region, error) {
 					shapes = append(shapes, *currentShape)
 				}
 				indexStr := strings.TrimSuffix(line, ":")
-				index, err := strconv.Atoi(indexStr)
+				index, err := aoc.Atoi(indexStr)
 				if err != nil {
-					return nil, nil, fmt.Errorf("invalid shape index: %v", err)
+					return nil, nil, scanner.Wrap(err)
 				}
 				currentShape = &Shape{index: index}
 				currentGrid = nil
-			} else if currentShape != nil {
+			} else if currentShape == nil {
+				return nil, nil, scanner.Unexpected("'<index>:'")
+			} else if strings.Trim(line, ".#") != "" {
+				return nil, nil, scanner.Unexpected("a line of '.' or '#'")
+			} else {
 				currentGrid = append(currentGrid, line)
 			}
 		}
@@ -266,7 +269,9 @@ type PieceToPlace struct {
 	instanceID int // for ordering identical pieces
 }
 
-func canFit(region Region, shapes []Shape, allPieces [][]Piece) bool {
+// canFit reports whether the presents fit in the region, giving up with false
+// once the checker is canceled.
+func canFit(region Region, shapes []Shape, allPieces [][]Piece, checker *aoc.Checker) bool {
 	// Quick area check
 	totalCells := 0
 	for shapeIdx, count := range region.counts {
@@ -313,13 +318,16 @@ func canFit(region Region, shapes []Shape, allPieces [][]Piece) bool {
 	// Track last position for each shape to avoid duplicate orderings
 	lastPos := make(map[int]int)
 
-	return backtrack(piecesToPlace, grid, region.width, region.height, 0, allPieces, lastPos)
+	return backtrack(piecesToPlace, grid, region.width, region.height, 0, allPieces, lastPos, checker)
 }
 
-func backtrack(pieces []PieceToPlace, grid []bool, width, height, pieceIdx int, allPieces [][]Piece, lastPos map[int]int) bool {
+func backtrack(pieces []PieceToPlace, grid []bool, width, height, pieceIdx int, allPieces [][]Piece, lastPos map[int]int, checker *aoc.Checker) bool {
 	if pieceIdx >= len(pieces) {
 		return true
 	}
+	if checker.Canceled() {
+		return false
+	}
 
 	piece := pieces[pieceIdx]
 	variants := allPieces[piece.shapeIdx]
@@ -349,7 +357,7 @@ func backtrack(pieces []PieceToPlace, grid []bool, width, height, pieceIdx int,
 					oldPos := lastPos[piece.shapeIdx]
 					lastPos[piece.shapeIdx] = pos
 
-					if backtrack(pieces, grid, width, height, pieceIdx+1, allPieces, lastPos) {
+					if backtrack(pieces, grid, width, height, pieceIdx+1, allPieces, lastPos, checker) {
 						return true
 					}
 
@@ -387,10 +395,10 @@ func unplace(piece Piece, grid []bool, width, row, col int) {
 	}
 }
 
-func getResult(input io.Reader) int64 {
+func getResult(ctx context.Context, input io.Reader) (int64, error) {
 	shapes, regions, err := parseInput(input)
 	if err != nil {
-		log.Fatalf("Error parsing input: %v", err)
+		return 0, err
 	}
 
 	maxIndex := 0
@@ -414,14 +422,20 @@ func getResult(input io.Reader) int64 {
 		}
 	}
 
+	checker := aoc.NewChecker(ctx, 1000)
 	count := 0
 	for _, region := range regions {
-		if canFit(region, shapes, allPieces) {
+		fits := canFit(region, shapes, allPieces, checker)
+		if checker.Canceled() {
+			// The regions already known to fit are a lower bound.
+			return 0, aoc.Canceled(ctx, aoc.Int(count))
+		}
+		if fits {
 			count++
 		}
 	}
 
-	return int64(count)
+	return int64(count), nil
 }
 
 func loadFile() *os.File {
@@ -432,13 +446,18 @@ func loadFile() *os.File {
 	return inputFile
 }
 
-func main() {
-	start := time.Now()
-	inputFile := loadFile()
-	defer inputFile.Close()
-
-	result := getResult(inputFile)
+var Solver = aoc.Solver{
+	Year:     2025,
+	Day:      12,
+	Title:    "Christmas Tree Farm",
+	Input:    aoc.LinesMatching(`|\d+:|[.#]+|\d+x\d+:( \d+)+`, "a shape index, a shape line, a region or an empty line"),
+	Generate: generateInput,
+	Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(ctx, input)
+		return aoc.Int(result), err
+	},
+}
 
-	log.Printf("Final result: %d", result)
-	log.Printf("Execution took %s", time.Since(start))
+func init() {
+	aoc.Register(Solver)
 }
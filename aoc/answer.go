@@ -0,0 +1,83 @@
+package aoc
+
+import (
+	"strconv"
+)
+
+// Kind is the type of value held by an Answer.
+type Kind uint8
+
+const (
+	KindNone Kind = iota
+	KindInt
+	KindUint
+	KindString
+)
+
+// Answer is the typed result of a puzzle part. Puzzles mostly expect integers
+// but some of them (coordinates, passwords...) expect a string.
+type Answer struct {
+	kind Kind
+	i    int64
+	u    uint64
+	s    string
+}
+
+// Integer is the set of integer types returned by solutions.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Int returns an answer holding a signed integer.
+func Int[T Integer](value T) Answer {
+	return Answer{kind: KindInt, i: int64(value)}
+}
+
+// Uint returns an answer holding an unsigned integer.
+func Uint(value uint64) Answer {
+	return Answer{kind: KindUint, u: value}
+}
+
+// String returns an answer holding a string.
+func String(value string) Answer {
+	return Answer{kind: KindString, s: value}
+}
+
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+// Int64 returns the answer as a signed integer, if it holds one that fits.
+func (a Answer) Int64() (int64, bool) {
+	switch a.kind {
+	case KindInt:
+		return a.i, true
+	case KindUint:
+		return int64(a.u), a.u <= 1<<63-1
+	}
+	return 0, false
+}
+
+// IsZero reports whether the answer holds no value at all.
+func (a Answer) IsZero() bool {
+	return a.kind == KindNone
+}
+
+// String returns the answer as it is expected to be submitted.
+func (a Answer) String() string {
+	switch a.kind {
+	case KindInt:
+		return strconv.FormatInt(a.i, 10)
+	case KindUint:
+		return strconv.FormatUint(a.u, 10)
+	case KindString:
+		return a.s
+	}
+	return ""
+}
+
+// Equal reports whether both answers would be submitted the same way, so an
+// int64 answer is equal to the same value as an uint64 or a string.
+func (a Answer) Equal(other Answer) bool {
+	return a.kind != KindNone && other.kind != KindNone && a.String() == other.String()
+}
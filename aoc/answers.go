@@ -0,0 +1,88 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// AnswersFile is the name of the file, in each year directory, recording the
+// known-correct answers of the puzzles of that year.
+const AnswersFile = "answers.txt"
+
+// PartKey identifies a puzzle part within a year.
+type PartKey struct {
+	Day  int
+	Part int
+}
+
+// Answers are the known-correct answers of the puzzles of a year.
+type Answers map[PartKey]Answer
+
+// ReadAnswers parses an answers file. Each line holds a day, a part and its
+// answer separated by spaces, empty lines and lines starting with # are
+// ignored:
+//
+//	# day part answer
+//	01 2 21328497
+//	18 2 60,21
+func ReadAnswers(input io.Reader) (Answers, error) {
+	answers := make(Answers)
+	scanner := NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, scanner.Unexpected("'<day> <part> <answer>'")
+		}
+		day, errParsing := Atoi(fields[0])
+		if errParsing != nil {
+			return nil, scanner.Wrap(errParsing)
+		}
+		if day < 1 || day > 25 {
+			return nil, scanner.Wrap(Unexpected(fields[0], "a day between 1 and 25"))
+		}
+		if fields[1] != "1" && fields[1] != "2" {
+			return nil, scanner.Wrap(Unexpected(fields[1], "a part among 1 or 2"))
+		}
+		key := PartKey{Day: day, Part: int(fields[1][0] - '0')}
+		if _, exists := answers[key]; exists {
+			return nil, scanner.Wrap(Unexpected(fields[0]+" "+fields[1], "a single answer per part"))
+		}
+		answers[key] = String(fields[2])
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, errScanningFile
+	}
+	return answers, nil
+}
+
+// Keys returns the recorded parts sorted by day then part.
+func (a Answers) Keys() []PartKey {
+	keys := make([]PartKey, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Day != keys[j].Day {
+			return keys[i].Day < keys[j].Day
+		}
+		return keys[i].Part < keys[j].Part
+	})
+	return keys
+}
+
+// WriteTo writes the answers in the format read by ReadAnswers.
+func (a Answers) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	sb.WriteString("# day part answer\n")
+	for _, key := range a.Keys() {
+		fmt.Fprintf(&sb, "%02d %d %s\n", key.Day, key.Part, a[key])
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
@@ -0,0 +1,57 @@
+package aoc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadAnswers(t *testing.T) {
+	input := "# day part answer\n01 1 42\n\n18 2 60,21\n01 2 abc\n"
+	answers, err := ReadAnswers(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(answers) != 3 {
+		t.Fatalf("Expected 3 answers, got %d", len(answers))
+	}
+	if answer := answers[PartKey{Day: 18, Part: 2}]; answer.String() != "60,21" {
+		t.Errorf("Expected 18 part 2 to be 60,21, got %s", answer)
+	}
+	if !answers[PartKey{Day: 1, Part: 1}].Equal(Int(42)) {
+		t.Errorf("Expected 01 part 1 to be equal to the integer 42")
+	}
+
+	var sb strings.Builder
+	if _, err := answers.WriteTo(&sb); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "# day part answer\n01 1 42\n01 2 abc\n18 2 60,21\n"
+	if sb.String() != expected {
+		t.Errorf("Expected answers to be written as %q, got %q", expected, sb.String())
+	}
+}
+
+func TestReadAnswersErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"01 1\n", 1, 1},
+		{"01 1 42\n26 1 3\n", 2, 1},
+		{"01 3 42\n", 1, 4},
+		{"01 1 42\n01 1 43\n", 2, 1},
+	}
+	for _, test := range tests {
+		_, err := ReadAnswers(strings.NewReader(test.input))
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a ParseError for %q, got %v", test.input, err)
+			continue
+		}
+		if parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("Expected error at %d:%d for %q, got %v", test.line, test.column, test.input, err)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Package aoctest runs the solvers against the example inputs of their
+// testdata directory, against the inputs of their generator, and against
+// their oracles on those inputs. The counterexamples found against the
+// oracles are shrunk, and written as examples by running the tests with the
+// -shrink flag.
+//
+// Each example is a .txt file, next to an .expected file of the same name
+// giving the expected answer of some parts, along with the parameters of the
+// solver if it needs any:
+//
+//	# part answer, or parameter value
+//	part1 12
+//	width 11
+//	height 7
+package aoctest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// Dir is the directory of the examples, relative to the package being tested.
+const Dir = "testdata"
+
+// Example is an example input along with its expected answers.
+type Example struct {
+	Name  string
+	Input []byte
+	// Answers are the expected answers, by part.
+	Answers map[int]string
+	Params  aoc.Params
+}
+
+// ReadExpected parses the expected answers and the parameters of an example.
+func ReadExpected(input io.Reader) (map[int]string, aoc.Params, error) {
+	answers := make(map[int]string)
+	params := make(aoc.Params)
+	scanner := aoc.NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		if !found || value == "" {
+			return nil, nil, scanner.Unexpected("'<part1|part2|parameter> <value>'")
+		}
+		var duplicated bool
+		switch key {
+		case "part1", "part2":
+			part := int(key[4] - '0')
+			_, duplicated = answers[part]
+			answers[part] = value
+		default:
+			_, duplicated = params[key]
+			params[key] = value
+		}
+		if duplicated {
+			return nil, nil, scanner.Wrap(aoc.Unexpected(key, "a single value per key"))
+		}
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, nil, errScanningFile
+	}
+	if len(answers) == 0 {
+		return nil, nil, aoc.Unexpected("", "an expected answer for part1 or part2")
+	}
+	return answers, params, nil
+}
+
+// ReadExamples returns the examples of a directory, sorted by name. Every
+// example must have its expected answers.
+func ReadExamples(dir string) ([]Example, error) {
+	paths, errGlobbing := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if errGlobbing != nil {
+		return nil, errGlobbing
+	}
+	sort.Strings(paths)
+	var examples []Example
+	for _, path := range paths {
+		input, errReading := os.ReadFile(path)
+		if errReading != nil {
+			return nil, errReading
+		}
+		expectedPath := strings.TrimSuffix(path, ".txt") + ".expected"
+		expected, errOpening := os.Open(expectedPath)
+		if errOpening != nil {
+			return nil, errOpening
+		}
+		answers, params, errParsing := ReadExpected(expected)
+		expected.Close()
+		if errParsing != nil {
+			return nil, fmt.Errorf("%s: %w", expectedPath, errParsing)
+		}
+		examples = append(examples, Example{
+			Name:    strings.TrimSuffix(filepath.Base(path), ".txt"),
+			Input:   input,
+			Answers: answers,
+			Params:  params,
+		})
+	}
+	return examples, nil
+}
+
+// WriteExample writes an example and its expected answers to the directory,
+// as read by ReadExamples.
+func WriteExample(dir string, example Example) error {
+	if errCreating := os.MkdirAll(dir, 0o755); errCreating != nil {
+		return errCreating
+	}
+	var expected strings.Builder
+	for part := 1; part <= 2; part++ {
+		if answer, ok := example.Answers[part]; ok {
+			fmt.Fprintf(&expected, "part%d %s\n", part, answer)
+		}
+	}
+	names := make([]string, 0, len(example.Params))
+	for name := range example.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&expected, "%s %s\n", name, example.Params[name])
+	}
+	path := filepath.Join(dir, example.Name)
+	if errWriting := os.WriteFile(path+".txt", example.Input, 0o644); errWriting != nil {
+		return errWriting
+	}
+	return os.WriteFile(path+".expected", []byte(expected.String()), 0o644)
+}
+
+// ReadInput returns the input of an example of the testdata directory, for
+// the benchmarks.
+func ReadInput(tb testing.TB, name string) []byte {
+	tb.Helper()
+	input, errReading := os.ReadFile(filepath.Join(Dir, name+".txt"))
+	if errReading != nil {
+		tb.Fatalf("Unable to read the example: %v", errReading)
+	}
+	return input
+}
+
+// InputFile is the real input of a day, next to its package.
+const InputFile = "input.txt"
+
+// Bench benchmarks each part of the solver with the real input of its day,
+// in a sub-benchmark per part as run by the bench command of aoc. It skips
+// the benchmark when the input is missing.
+func Bench(b *testing.B, solver aoc.Solver) {
+	b.Helper()
+	input, errReading := os.ReadFile(InputFile)
+	if errors.Is(errReading, fs.ErrNotExist) {
+		b.Skipf("No %s", InputFile)
+	}
+	if errReading != nil {
+		b.Fatalf("Unable to read the input: %v", errReading)
+	}
+	for _, part := range solver.Parts() {
+		part := part
+		b.Run(fmt.Sprintf("part%d", part), func(b *testing.B) {
+			if _, err := solver.Solve(part, bytes.NewReader(input)); err != nil {
+				b.Fatalf("Unexpected error: %v", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				solver.Solve(part, bytes.NewReader(input))
+			}
+		})
+	}
+}
+
+// Run solves the examples of the testdata directory with the solver, each
+// expected answer in its own subtest. It skips the test when there is no
+// example.
+func Run(t *testing.T, solver aoc.Solver) {
+	t.Helper()
+	RunDir(t, solver, Dir)
+}
+
+// RunDir solves the examples of a directory with the solver.
+func RunDir(t *testing.T, solver aoc.Solver, dir string) {
+	t.Helper()
+	examples, errReading := ReadExamples(dir)
+	if errReading != nil {
+		t.Fatalf("Unable to read the examples: %v", errReading)
+	}
+	if len(examples) == 0 {
+		t.Skipf("No example in %s", dir)
+	}
+	for _, example := range examples {
+		for part := 1; part <= 2; part++ {
+			expected, ok := example.Answers[part]
+			if !ok {
+				continue
+			}
+			example := example
+			t.Run(fmt.Sprintf("%s/part%d", example.Name, part), func(t *testing.T) {
+				ctx := aoc.WithParams(context.Background(), example.Params)
+				answer, err := solver.SolveContext(ctx, part, bytes.NewReader(example.Input))
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if answer.String() != expected {
+					t.Errorf("Expected answer to be %s, got %s", expected, answer)
+				}
+			})
+		}
+	}
+}
+
+// GeneratedSeeds is the number of seeds generating inputs for each size.
+const GeneratedSeeds = 5
+
+// GeneratedTimeout is the time after which solving a generated input fails.
+const GeneratedTimeout = 10 * time.Second
+
+// Generated solves the inputs generated by the solver for a few seeds and
+// each size. Their answers are unknown, the inputs must only fit the input
+// spec of the solver and be solved without error nor timeout.
+func Generated(t *testing.T, solver aoc.Solver, sizes ...int) {
+	t.Helper()
+	for _, size := range sizes {
+		for seed := int64(1); seed <= GeneratedSeeds; seed++ {
+			size, seed := size, seed
+			t.Run(fmt.Sprintf("size%d/seed%d", size, seed), func(t *testing.T) {
+				input, errGenerating := solver.GenerateInput(seed, size)
+				if errGenerating != nil {
+					t.Fatalf("Unable to generate the input: %v", errGenerating)
+				}
+				if solver.Input != nil {
+					violations, errChecking := aoc.CheckInput(solver.Input, bytes.NewReader(input))
+					if errChecking != nil {
+						t.Fatalf("Unexpected error: %v", errChecking)
+					}
+					for _, violation := range violations {
+						t.Errorf("Generated input not fitting the spec: %v", violation)
+					}
+				}
+				for _, part := range solver.Parts() {
+					ctx, cancel := context.WithTimeout(context.Background(), GeneratedTimeout)
+					_, err := solver.SolveContext(ctx, part, bytes.NewReader(input))
+					cancel()
+					if err != nil {
+						t.Errorf("Unexpected error solving part %d: %v", part, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+var shrink = flag.Bool("shrink", false, "write the shrunk counterexamples of the differential tests to "+Dir)
+
+// ShrinkTimeout bounds the time spent shrinking a counterexample.
+const ShrinkTimeout = time.Minute
+
+// DifferentialSeeds is the number of seeds generating inputs for each size
+// when comparing the solver with its oracles.
+const DifferentialSeeds = 100
+
+// Differential compares the answers of the solver with the ones of its
+// oracles, on the inputs it generates for many seeds and each size. The
+// parameters are given to both, so that the inputs can be kept small enough
+// for the oracles. Each disagreement is reported as a counterexample along
+// with its seed and size, and with its input shrunk. With the -shrink flag,
+// the shrunk input is also written to the testdata directory along with the
+// answer of the oracle, so that it becomes an example once the solver is
+// fixed.
+func Differential(t *testing.T, solver aoc.Solver, params aoc.Params, sizes ...int) {
+	t.Helper()
+	var parts []int
+	for _, part := range solver.Parts() {
+		if solver.Oracle(part) != nil {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		t.Fatalf("No oracle for %s", solver)
+	}
+	for _, size := range sizes {
+		for seed := int64(1); seed <= DifferentialSeeds; seed++ {
+			size, seed := size, seed
+			t.Run(fmt.Sprintf("size%d/seed%d", size, seed), func(t *testing.T) {
+				input, errGenerating := solver.GenerateInput(seed, size)
+				if errGenerating != nil {
+					t.Fatalf("Unable to generate the input: %v", errGenerating)
+				}
+				for _, part := range parts {
+					ctx, cancel := context.WithTimeout(aoc.WithParams(context.Background(), params), GeneratedTimeout)
+					err := solver.CompareOracle(ctx, part, input)
+					cancel()
+					if err == nil {
+						continue
+					}
+					example, shrunk := shrinkCounterexample(solver, params, part, input, fmt.Sprintf("shrunk-part%d-size%d-seed%d", part, size, seed))
+					if !shrunk {
+						t.Errorf("Counterexample of size %d and seed %d: %v\n%s", size, seed, err, input)
+						continue
+					}
+					t.Errorf("Counterexample of size %d and seed %d, shrunk: %v\n%s", size, seed, err, example.Input)
+					if *shrink {
+						if errWriting := WriteExample(Dir, example); errWriting != nil {
+							t.Fatalf("Unable to write the counterexample: %v", errWriting)
+						}
+						t.Logf("Counterexample written to %s", filepath.Join(Dir, example.Name+".txt"))
+					}
+				}
+			})
+		}
+	}
+}
+
+// shrinkCounterexample shrinks an input on which the part of the solver
+// disagrees with its oracle, and returns it as an example expecting the
+// answer of the oracle. It reports false when the input can't be shrunk into
+// an example, the oracle failing on it or the disagreement being a timeout.
+func shrinkCounterexample(solver aoc.Solver, params aoc.Params, part int, input []byte, name string) (Example, bool) {
+	ctx, cancel := context.WithTimeout(aoc.WithParams(context.Background(), params), ShrinkTimeout)
+	defer cancel()
+	shrunk, mismatch := solver.ShrinkMismatch(ctx, part, input, GeneratedTimeout)
+	if mismatch == nil || mismatch.OracleErr != nil {
+		return Example{}, false
+	}
+	return Example{
+		Name:    name,
+		Input:   shrunk,
+		Answers: map[int]string{part: mismatch.Expected},
+		Params:  params,
+	}, true
+}
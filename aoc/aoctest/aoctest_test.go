@@ -0,0 +1,200 @@
+package aoctest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestReadExpected(t *testing.T) {
+	answers, params, err := ReadExpected(strings.NewReader("# part answer, or parameter value\npart2 60,21\n\nwidth 11\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(answers) != 1 || answers[2] != "60,21" {
+		t.Errorf("Expected the answer 60,21 for part 2, got %v", answers)
+	}
+	if len(params) != 1 || params["width"] != "11" {
+		t.Errorf("Expected the width parameter to be 11, got %v", params)
+	}
+
+	for _, invalid := range []string{"", "width 11\n", "part1\n", "part1 1\npart1 2\n", "width 1\nwidth 2\npart1 3\n"} {
+		if _, _, err := ReadExpected(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected %q to be refused", invalid)
+		}
+	}
+}
+
+func TestRunDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.txt":      "abc",
+		"a.expected": "part1 3\npart2 6\n",
+		"b.txt":      "abcd",
+		"b.expected": "part2 12\nfactor 3\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	examples, err := ReadExamples(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(examples) != 2 || examples[0].Name != "a" || string(examples[1].Input) != "abcd" {
+		t.Fatalf("Unexpected examples: %+v", examples)
+	}
+
+	length := func(factor int) aoc.PartFunc {
+		return func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+			factor, errParam := aoc.ParamInt(ctx, "factor", factor)
+			if errParam != nil {
+				return aoc.Answer{}, errParam
+			}
+			content, err := io.ReadAll(input)
+			return aoc.Int(factor * len(content)), err
+		}
+	}
+	RunDir(t, aoc.Solver{Year: 1999, Day: 1, Part1: length(1), Part2: length(2)}, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "c.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadExamples(dir); err == nil {
+		t.Errorf("Expected an example without its expected answers to be refused")
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	solver := aoc.Solver{
+		Year: 1999,
+		Day:  2,
+		Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+			content, err := io.ReadAll(input)
+			return aoc.Int(len(content)), err
+		},
+		Input: aoc.LinesMatching(`a+`, "a line of 'a'"),
+		Generate: func(rng *rand.Rand, size int, w io.Writer) error {
+			for i := 0; i < size; i++ {
+				fmt.Fprintln(w, strings.Repeat("a", 1+rng.Intn(5)))
+			}
+			return nil
+		},
+	}
+	Generated(t, solver, 1, 10)
+}
+
+func TestDifferential(t *testing.T) {
+	solver := aoc.Solver{
+		Year: 1999,
+		Day:  3,
+		Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+			content, err := io.ReadAll(input)
+			if err != nil {
+				return aoc.Answer{}, err
+			}
+			factor, errParam := aoc.ParamInt(ctx, "factor", 1)
+			return aoc.Int(factor * strings.Count(string(content), "a")), errParam
+		},
+		Oracle1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+			content, err := io.ReadAll(input)
+			if err != nil {
+				return aoc.Answer{}, err
+			}
+			factor, errParam := aoc.ParamInt(ctx, "factor", 1)
+			var count int
+			for _, char := range string(content) {
+				if char == 'a' {
+					count += factor
+				}
+			}
+			return aoc.Int(count), errParam
+		},
+		Generate: func(rng *rand.Rand, size int, w io.Writer) error {
+			for i := 0; i < size; i++ {
+				fmt.Fprintln(w, strings.Repeat("a", rng.Intn(5)))
+			}
+			return nil
+		},
+	}
+	Differential(t, solver, aoc.Params{"factor": "3"}, 1, 10)
+}
+
+func TestWriteExample(t *testing.T) {
+	dir := t.TempDir()
+	example := Example{
+		Name:    "shrunk",
+		Input:   []byte("1\n2\n"),
+		Answers: map[int]string{2: "3"},
+		Params:  aoc.Params{"width": "11", "height": "7"},
+	}
+	if err := WriteExample(dir, example); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "shrunk.expected"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(content) != "part2 3\nheight 7\nwidth 11\n" {
+		t.Errorf("Expected the answers then the sorted parameters, got %q", content)
+	}
+	examples, err := ReadExamples(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(examples) != 1 || string(examples[0].Input) != "1\n2\n" || examples[0].Answers[2] != "3" || examples[0].Params["width"] != "11" {
+		t.Errorf("Expected to read the written example back, got %+v", examples)
+	}
+}
+
+func TestShrinkCounterexample(t *testing.T) {
+	sum := func(ctx context.Context, input io.Reader, wrong bool) (aoc.Answer, error) {
+		content, err := io.ReadAll(input)
+		if err != nil {
+			return aoc.Answer{}, err
+		}
+		factor, errParam := aoc.ParamInt(ctx, "factor", 1)
+		var result int
+		for _, line := range strings.Fields(string(content)) {
+			value, errParsing := aoc.Atoi(line)
+			if errParsing != nil {
+				return aoc.Answer{}, errParsing
+			}
+			// The solution forgets the values above 7.
+			if !wrong || value <= 7 {
+				result += factor * value
+			}
+		}
+		return aoc.Int(result), errParam
+	}
+	solver := aoc.Solver{
+		Year: 1999,
+		Day:  4,
+		Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+			return sum(ctx, input, true)
+		},
+		Oracle1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+			return sum(ctx, input, false)
+		},
+	}
+	params := aoc.Params{"factor": "2"}
+	example, shrunk := shrinkCounterexample(solver, params, 1, []byte("3\n5\n9\n1\n4\n"), "shrunk")
+	if !shrunk {
+		t.Fatalf("Expected the counterexample to be shrunk")
+	}
+	// "9" can't be shrunk into "" as the solution would then agree with the
+	// oracle.
+	if string(example.Input) != "9\n" || example.Answers[1] != "18" || example.Params["factor"] != "2" {
+		t.Errorf("Expected the example of \"9\\n\" expecting 18, got %+v", example)
+	}
+	if _, shrunk := shrinkCounterexample(solver, params, 1, []byte("3\n"), "agreeing"); shrunk {
+		t.Errorf("Expected an agreement not to be shrunk")
+	}
+}
@@ -0,0 +1,58 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+)
+
+// PartialError is returned by a solution stopped by its context before it
+// found the answer, along with the best answer found until then.
+type PartialError struct {
+	// Partial is the best answer found before being stopped, an answer of
+	// kind KindNone when there is none.
+	Partial Answer
+	Err     error
+}
+
+// Canceled returns the PartialError of a solution stopped by the context.
+func Canceled(ctx context.Context, partial Answer) error {
+	return &PartialError{Partial: partial, Err: context.Cause(ctx)}
+}
+
+func (e *PartialError) Error() string {
+	if e.Partial.Kind() == KindNone {
+		return fmt.Sprintf("stopped without a partial answer: %v", e.Err)
+	}
+	return fmt.Sprintf("stopped with the partial answer %s: %v", e.Partial, e.Err)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// Checker polls a context from a hot loop, only looking at it once every so
+// many calls to stay cheap.
+type Checker struct {
+	ctx      context.Context
+	every    int
+	calls    int
+	canceled bool
+}
+
+// NewChecker returns a checker looking at the context once every calls.
+func NewChecker(ctx context.Context, every int) *Checker {
+	return &Checker{ctx: ctx, every: max(every, 1)}
+}
+
+// Canceled reports whether the context was seen done. Once it returns true,
+// it always does.
+func (c *Checker) Canceled() bool {
+	if c.canceled {
+		return true
+	}
+	c.calls++
+	if c.calls%c.every == 0 && c.ctx.Err() != nil {
+		c.canceled = true
+	}
+	return c.canceled
+}
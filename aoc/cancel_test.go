@@ -0,0 +1,41 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	checker := NewChecker(ctx, 3)
+	for i := 0; i < 4; i++ {
+		if checker.Canceled() {
+			t.Fatalf("Expected the checker not to be canceled before the context")
+		}
+	}
+	cancel()
+	if checker.Canceled() {
+		t.Errorf("Expected the context to be checked once every three calls only")
+	}
+	if !checker.Canceled() || !checker.Canceled() {
+		t.Errorf("Expected the checker to stay canceled")
+	}
+}
+
+func TestPartialError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	err := Canceled(ctx, Int(42))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the error to wrap the context one, got %v", err)
+	}
+	var partialErr *PartialError
+	if !errors.As(err, &partialErr) || partialErr.Partial.String() != "42" {
+		t.Errorf("Expected the partial answer 42, got %v", err)
+	}
+	if expected := "stopped with the partial answer 42: context deadline exceeded"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
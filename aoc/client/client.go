@@ -0,0 +1,194 @@
+// Package client talks to the Advent of Code website on behalf of a logged in
+// user, to download puzzle inputs and submit answers.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the address of the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+	// DefaultInterval is the minimum delay between two requests sent to the
+	// website, to stay polite with its servers.
+	DefaultInterval = 3 * time.Second
+	// SessionEnv is the environment variable holding the session token.
+	SessionEnv = "AOC_SESSION"
+	userAgent  = "github.com/antitoine/advent-of-code"
+)
+
+// ErrNoSession is returned when no session token can be found.
+var ErrNoSession = errors.New("no session token found, set " + SessionEnv + " or write it to the aoc/session file of the user config directory")
+
+// HTTPError reports a request answered with an unexpected status.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	message := fmt.Sprintf("%s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		message += ": " + e.Body
+	}
+	return message
+}
+
+// Client sends requests to the website, never more often than once per
+// Interval.
+type Client struct {
+	BaseURL    string
+	Session    string
+	Interval   time.Duration
+	HTTPClient *http.Client
+
+	mutex       sync.Mutex
+	lastRequest time.Time
+}
+
+// New returns a client of the website authenticated by the given session.
+func New(session string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Session:    session,
+		Interval:   DefaultInterval,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// LoadSession returns the session token from the AOC_SESSION environment
+// variable, or from the session file of the user config directory.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+	configDir, errConfigDir := os.UserConfigDir()
+	if errConfigDir != nil {
+		return "", ErrNoSession
+	}
+	content, errReading := os.ReadFile(filepath.Join(configDir, "aoc", "session"))
+	if errors.Is(errReading, os.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if errReading != nil {
+		return "", errReading
+	}
+	session := strings.TrimSpace(string(content))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
+
+// wait blocks until a new request can be sent, then books its slot.
+func (c *Client) wait(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.lastRequest.IsZero() {
+		if delay := c.Interval - time.Since(c.lastRequest); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	c.lastRequest = time.Now()
+	return nil
+}
+
+// do sends an authenticated request and returns the body of its response.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, contentType string) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	url := strings.TrimSuffix(c.BaseURL, "/") + path
+	request, errRequest := http.NewRequestWithContext(ctx, method, url, body)
+	if errRequest != nil {
+		return nil, errRequest
+	}
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	request.Header.Set("User-Agent", userAgent)
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	if errWaiting := c.wait(ctx); errWaiting != nil {
+		return nil, errWaiting
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, errSending := httpClient.Do(request)
+	if errSending != nil {
+		return nil, errSending
+	}
+	defer response.Body.Close()
+
+	content, errReading := io.ReadAll(response.Body)
+	if errReading != nil {
+		return nil, fmt.Errorf("%s: %w", url, errReading)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &HTTPError{URL: url, StatusCode: response.StatusCode, Body: strings.TrimSpace(string(content))}
+	}
+	return content, nil
+}
+
+// FetchInput downloads the puzzle input of a day.
+func (c *Client) FetchInput(ctx context.Context, year, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil, "")
+}
+
+// Input returns the puzzle input of a day cached at the given path. It is only
+// downloaded when the file doesn't exist yet, and written atomically so an
+// interrupted download never leaves a truncated input behind.
+func (c *Client) Input(ctx context.Context, path string, year, day int) ([]byte, error) {
+	content, errReading := os.ReadFile(path)
+	if errReading == nil {
+		return content, nil
+	}
+	if !errors.Is(errReading, os.ErrNotExist) {
+		return nil, errReading
+	}
+
+	content, errFetching := c.FetchInput(ctx, year, day)
+	if errFetching != nil {
+		return nil, errFetching
+	}
+	if errWriting := writeFileAtomic(path, content); errWriting != nil {
+		return nil, errWriting
+	}
+	return content, nil
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	file, errCreating := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if errCreating != nil {
+		return errCreating
+	}
+	_, errWriting := file.Write(content)
+	if errClosing := file.Close(); errWriting == nil {
+		errWriting = errClosing
+	}
+	if errWriting == nil {
+		errWriting = os.Rename(file.Name(), path)
+	}
+	if errWriting != nil {
+		os.Remove(file.Name())
+	}
+	return errWriting
+}
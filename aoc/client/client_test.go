@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := New("secret")
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Interval = 0
+	return c
+}
+
+func TestInput(t *testing.T) {
+	var nbRequests atomic.Int32
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		nbRequests.Add(1)
+		if r.URL.Path != "/2024/day/7/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.UserAgent() != userAgent {
+			t.Errorf("Expected user agent %q, got %q", userAgent, r.UserAgent())
+		}
+		w.Write([]byte("190: 10 19\n"))
+	})
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	for i := 0; i < 2; i++ {
+		content, err := c.Input(context.Background(), path, 2024, 7)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(content) != "190: 10 19\n" {
+			t.Errorf("Unexpected input %q", content)
+		}
+	}
+	if n := nbRequests.Load(); n != 1 {
+		t.Errorf("Expected the cached input to be requested once, got %d requests", n)
+	}
+	if content, err := os.ReadFile(path); err != nil || string(content) != "190: 10 19\n" {
+		t.Errorf("Expected the input to be cached, got %q (%v)", content, err)
+	}
+
+	c.Session = "expired"
+	missingPath := filepath.Join(t.TempDir(), "input.txt")
+	_, err := c.Input(context.Background(), missingPath, 2024, 7)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected a bad request error, got %v", err)
+	}
+	if _, errStat := os.Stat(missingPath); !errors.Is(errStat, os.ErrNotExist) {
+		t.Errorf("Expected nothing to be cached after a failure, got %v", errStat)
+	}
+
+	c.Session = ""
+	if _, err := c.FetchInput(context.Background(), 2024, 7); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Expected ErrNoSession, got %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input"))
+	})
+	c.Interval = 100 * time.Millisecond
+
+	start := time.Now()
+	for day := 1; day <= 3; day++ {
+		if _, err := c.FetchInput(context.Background(), 2024, day); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*c.Interval {
+		t.Errorf("Expected 3 requests to take at least %s, took %s", 2*c.Interval, elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.FetchInput(ctx, 2024, 4); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled wait to fail, got %v", err)
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+
+	t.Setenv(SessionEnv, "")
+	if _, err := LoadSession(); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Expected ErrNoSession, got %v", err)
+	}
+
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Skipf("No user config directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(userConfigDir, "aoc"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(userConfigDir, "aoc", "session"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if session, err := LoadSession(); err != nil || session != "from-file" {
+		t.Errorf("Expected the session of the config file, got %q (%v)", session, err)
+	}
+
+	t.Setenv(SessionEnv, "from-env")
+	if session, err := LoadSession(); err != nil || session != "from-env" {
+		t.Errorf("Expected the session of the environment, got %q (%v)", session, err)
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// HistoryFile is the name of the file, in each day directory, recording the
+// answers submitted for the puzzle of that day.
+const HistoryFile = "guesses.txt"
+
+// ErrRuledOut is returned when an answer is known to be wrong from previous
+// submissions.
+var ErrRuledOut = errors.New("answer ruled out by a previous submission")
+
+// Guess is an answer submitted for a puzzle part, with the verdict of the
+// website.
+type Guess struct {
+	Part    int
+	Answer  string
+	Verdict Verdict
+	Time    time.Time
+}
+
+// History is the list of answers submitted for the puzzle of a day, in
+// submission order.
+type History []Guess
+
+// ReadHistory parses a history file. Each line holds the time of a
+// submission, its part, verdict and answer separated by spaces:
+//
+//	2024-12-17T06:12:03Z 2 too-low 1234
+func ReadHistory(input io.Reader) (History, error) {
+	var history History
+	scanner := aoc.NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, scanner.Unexpected("'<time> <part> <verdict> <answer>'")
+		}
+		submittedAt, errParsing := time.Parse(time.RFC3339, fields[0])
+		if errParsing != nil {
+			return nil, scanner.Wrap(aoc.Unexpected(fields[0], "an RFC 3339 time"))
+		}
+		if fields[1] != "1" && fields[1] != "2" {
+			return nil, scanner.Wrap(aoc.Unexpected(fields[1], "a part among 1 or 2"))
+		}
+		verdict, known := ParseVerdict(fields[2])
+		if !known || !verdict.Checked() {
+			return nil, scanner.Wrap(aoc.Unexpected(fields[2], "a verdict among correct, wrong, too-high or too-low"))
+		}
+		history = append(history, Guess{
+			Part:    int(fields[1][0] - '0'),
+			Answer:  fields[3],
+			Verdict: verdict,
+			Time:    submittedAt,
+		})
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, errScanningFile
+	}
+	return history, nil
+}
+
+// WriteTo writes the history in the format read by ReadHistory.
+func (h History) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	sb.WriteString("# time part verdict answer\n")
+	for _, guess := range h {
+		fmt.Fprintf(&sb, "%s %d %s %s\n", guess.Time.UTC().Format(time.RFC3339), guess.Part, guess.Verdict, guess.Answer)
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// Solution returns the correct answer of a part, if it has been found.
+func (h History) Solution(part int) (string, bool) {
+	for _, guess := range h {
+		if guess.Part == part && guess.Verdict == VerdictCorrect {
+			return guess.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an ErrRuledOut error when submitting the answer of a part
+// would be useless: the part is already solved, the same answer was already
+// rejected, or the answer is out of the bounds given by previous too high or
+// too low verdicts.
+func (h History) Check(part int, answer string) error {
+	if solution, solved := h.Solution(part); solved {
+		return fmt.Errorf("%w: part %d is already solved with %s", ErrRuledOut, part, solution)
+	}
+	value, errValue := strconv.ParseInt(answer, 10, 64)
+	isNumber := errValue == nil
+	for _, guess := range h {
+		if guess.Part != part {
+			continue
+		}
+		if guess.Answer == answer {
+			return fmt.Errorf("%w: %s was already submitted on %s and was %s", ErrRuledOut, answer, guess.Time.Format(time.DateTime), guess.Verdict)
+		}
+		if !isNumber || (guess.Verdict != VerdictTooHigh && guess.Verdict != VerdictTooLow) {
+			continue
+		}
+		bound, errBound := strconv.ParseInt(guess.Answer, 10, 64)
+		if errBound != nil {
+			continue
+		}
+		if guess.Verdict == VerdictTooHigh && value >= bound {
+			return fmt.Errorf("%w: %s is not below %s which was too high", ErrRuledOut, answer, guess.Answer)
+		}
+		if guess.Verdict == VerdictTooLow && value <= bound {
+			return fmt.Errorf("%w: %s is not above %s which was too low", ErrRuledOut, answer, guess.Answer)
+		}
+	}
+	return nil
+}
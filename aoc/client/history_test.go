@@ -0,0 +1,73 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryCheck(t *testing.T) {
+	submittedAt := time.Date(2024, 12, 17, 6, 0, 0, 0, time.UTC)
+	history := History{
+		{Part: 1, Answer: "4,6,3", Verdict: VerdictWrong, Time: submittedAt},
+		{Part: 2, Answer: "1000", Verdict: VerdictTooHigh, Time: submittedAt},
+		{Part: 2, Answer: "100", Verdict: VerdictTooLow, Time: submittedAt},
+		{Part: 2, Answer: "500", Verdict: VerdictWrong, Time: submittedAt},
+	}
+	tests := []struct {
+		part    int
+		answer  string
+		allowed bool
+	}{
+		{1, "4,6,3", false},
+		{1, "4,6,4", true},
+		{2, "1000", false},
+		{2, "1001", false},
+		{2, "999", true},
+		{2, "100", false},
+		{2, "-3", false},
+		{2, "101", true},
+		{2, "500", false},
+		{2, "abc", true},
+	}
+	for _, test := range tests {
+		err := history.Check(test.part, test.answer)
+		if (err == nil) != test.allowed {
+			t.Errorf("Expected part %d answer %s to be allowed=%t, got %v", test.part, test.answer, test.allowed, err)
+		}
+		if err != nil && !errors.Is(err, ErrRuledOut) {
+			t.Errorf("Expected ErrRuledOut, got %v", err)
+		}
+	}
+
+	history = append(history, Guess{Part: 2, Answer: "117", Verdict: VerdictCorrect, Time: submittedAt})
+	if err := history.Check(2, "118"); !errors.Is(err, ErrRuledOut) {
+		t.Errorf("Expected a solved part to be ruled out, got %v", err)
+	}
+	if solution, solved := history.Solution(2); !solved || solution != "117" {
+		t.Errorf("Expected part 2 to be solved with 117, got %s", solution)
+	}
+}
+
+func TestReadHistory(t *testing.T) {
+	input := "# time part verdict answer\n2024-12-17T06:12:03Z 2 too-low 1234\n2024-12-17T06:14:00Z 2 correct 2345\n"
+	history, err := ReadHistory(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(history) != 2 || history[0].Verdict != VerdictTooLow || history[1].Answer != "2345" {
+		t.Errorf("Unexpected history %+v", history)
+	}
+	var sb strings.Builder
+	if _, err := history.WriteTo(&sb); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sb.String() != input {
+		t.Errorf("Expected the history to be written as %q, got %q", input, sb.String())
+	}
+
+	if _, err := ReadHistory(strings.NewReader("2024-12-17T06:12:03Z 2 too-recent 1234\n")); err == nil {
+		t.Errorf("Expected unchecked verdicts to be rejected")
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the outcome of an answer submission.
+type Verdict uint8
+
+const (
+	VerdictUnknown Verdict = iota
+	VerdictCorrect
+	VerdictWrong
+	VerdictTooHigh
+	VerdictTooLow
+	// VerdictTooRecent means the answer hasn't been checked because the
+	// previous submission was too recent.
+	VerdictTooRecent
+	// VerdictAlreadySolved means the answer hasn't been checked because the
+	// part is already solved or still locked.
+	VerdictAlreadySolved
+)
+
+var verdictNames = [...]string{
+	VerdictUnknown:       "unknown",
+	VerdictCorrect:       "correct",
+	VerdictWrong:         "wrong",
+	VerdictTooHigh:       "too-high",
+	VerdictTooLow:        "too-low",
+	VerdictTooRecent:     "too-recent",
+	VerdictAlreadySolved: "already-solved",
+}
+
+func (v Verdict) String() string {
+	if int(v) < len(verdictNames) {
+		return verdictNames[v]
+	}
+	return "verdict(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ParseVerdict returns the verdict named by its String method.
+func ParseVerdict(name string) (Verdict, bool) {
+	for v, verdictName := range verdictNames {
+		if verdictName == name {
+			return Verdict(v), true
+		}
+	}
+	return VerdictUnknown, false
+}
+
+// Checked reports whether the answer has been checked against the solution.
+func (v Verdict) Checked() bool {
+	return v >= VerdictCorrect && v <= VerdictTooLow
+}
+
+// Result is the response of the website to an answer submission.
+type Result struct {
+	Verdict Verdict
+	// Wait is the delay to respect before submitting again, when known.
+	Wait    time.Duration
+	Message string
+}
+
+var (
+	articleRegex    = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex        = regexp.MustCompile(`<[^>]*>`)
+	spacesRegex     = regexp.MustCompile(`\s+`)
+	leftToWaitRegex = regexp.MustCompile(`(?i)you have (?:(\d+)m )?(\d+)s left to wait`)
+	pleaseWaitRegex = regexp.MustCompile(`(?i)please wait (\w+) (second|minute)s?`)
+)
+
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// ParseResult extracts the verdict of a submission from the page returned by
+// the website.
+func ParseResult(page string) Result {
+	message := page
+	if match := articleRegex.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagRegex.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spacesRegex.ReplaceAllString(message, " "))
+	result := Result{Message: message, Wait: parseWait(message)}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "that's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(lower, "too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(lower, "too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(lower, "not the right answer"):
+		result.Verdict = VerdictWrong
+	case strings.Contains(lower, "answer too recently"):
+		result.Verdict = VerdictTooRecent
+	case strings.Contains(lower, "solving the right level"):
+		result.Verdict = VerdictAlreadySolved
+	}
+	return result
+}
+
+func parseWait(message string) time.Duration {
+	if match := leftToWaitRegex.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+	if match := pleaseWaitRegex.FindStringSubmatch(message); match != nil {
+		amount, isWord := numberWords[strings.ToLower(match[1])]
+		if !isWord {
+			var errParsing error
+			if amount, errParsing = strconv.Atoi(match[1]); errParsing != nil {
+				return 0
+			}
+		}
+		unit := time.Second
+		if strings.EqualFold(match[2], "minute") {
+			unit = time.Minute
+		}
+		return time.Duration(amount) * unit
+	}
+	return 0
+}
+
+// Submit posts the answer of a puzzle part and returns the verdict of the
+// website.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Result, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	page, errPosting := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day),
+		strings.NewReader(form.Encode()), "application/x-www-form-urlencoded")
+	if errPosting != nil {
+		return Result{}, errPosting
+	}
+	return ParseResult(string(page)), nil
+}
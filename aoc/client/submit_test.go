@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{`<main><article><p>That's the right answer!  You are <em>one gold star</em> closer to finding the Chief Historian. <a href="/2024/day/17#part2">[Continue to Part Two]</a></p></article></main>`, VerdictCorrect, 0},
+		{`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>, or you can ask for hints on the <a href="https://www.reddit.com/r/adventofcode/" target="_blank">subreddit</a>.  Please wait one minute before trying again. <a href="/2024/day/17">[Return to Day 17]</a></p></article>`, VerdictTooHigh, time.Minute},
+		{`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`, VerdictTooLow, 5 * time.Minute},
+		{`<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.  Please wait 30 seconds before trying again.</p></article>`, VerdictWrong, 30 * time.Second},
+		{`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 38s left to wait. <a href="/2024/day/17">[Return to Day 17]</a></p></article>`, VerdictTooRecent, time.Minute + 38*time.Second},
+		{`<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/17">[Return to Day 17]</a></p></article>`, VerdictAlreadySolved, 0},
+		{`<html>Internal error</html>`, VerdictUnknown, 0},
+	}
+	for _, test := range tests {
+		result := ParseResult(test.page)
+		if result.Verdict != test.verdict || result.Wait != test.wait {
+			t.Errorf("Expected %s waiting %s, got %s waiting %s for %q", test.verdict, test.wait, result.Verdict, result.Wait, result.Message)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/17/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" || r.FormValue("answer") != "117440" {
+			t.Errorf("Unexpected form %v", r.Form)
+		}
+		w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+	})
+	result, err := c.Submit(context.Background(), 2024, 17, 2, "117440")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Verdict != VerdictCorrect || result.Message != "That's the right answer!" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// BenchHistoryFile is the name of the file, at the root of the repository,
+// recording the benchmarks of every commit.
+const BenchHistoryFile = "benchmarks.json"
+
+// BenchResult is the measure of one puzzle part solved with its real input.
+type BenchResult struct {
+	Year        int   `json:"year"`
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+}
+
+func (r BenchResult) key() [3]int {
+	return [3]int{r.Year, r.Day, r.Part}
+}
+
+// BenchRun gathers the results measured on a commit.
+type BenchRun struct {
+	Commit  string        `json:"commit"`
+	Time    time.Time     `json:"time"`
+	Results []BenchResult `json:"results"`
+}
+
+// BenchHistory is the list of the runs, from the oldest to the latest, with a
+// single run per commit.
+type BenchHistory []BenchRun
+
+// Record adds the results of a run, merging them with the ones already
+// measured on the same commit, and moves the run of that commit last.
+func (h BenchHistory) Record(run BenchRun) BenchHistory {
+	var recorded BenchHistory
+	for _, previous := range h {
+		if previous.Commit != run.Commit {
+			recorded = append(recorded, previous)
+			continue
+		}
+		measured := make(map[[3]int]bool)
+		for _, result := range run.Results {
+			measured[result.key()] = true
+		}
+		for _, result := range previous.Results {
+			if !measured[result.key()] {
+				run.Results = append(run.Results, result)
+			}
+		}
+	}
+	sort.Slice(run.Results, func(i, j int) bool {
+		a, b := run.Results[i], run.Results[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		return a.Part < b.Part
+	})
+	return append(recorded, run)
+}
+
+// Previous returns the latest result of a part measured on another commit.
+func (h BenchHistory) Previous(commit string, result BenchResult) (BenchResult, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Commit == commit {
+			continue
+		}
+		for _, previous := range h[i].Results {
+			if previous.key() == result.key() {
+				return previous, true
+			}
+		}
+	}
+	return BenchResult{}, false
+}
+
+// benchName is the benchmark of every day module measuring each part with
+// its real input, as written by aoctest.Bench.
+const benchName = "BenchmarkSolver"
+
+// benchLineRegex matches a result of benchName printed by go test -benchmem.
+var benchLineRegex = regexp.MustCompile(`^` + benchName + `/part([12])(?:-\d+)?\s+\d+\s+([\d.]+) ns/op\s+(\d+) B/op\s+(\d+) allocs/op`)
+
+// benchModule runs the benchmarks of the module in dir matching the pattern
+// and returns the output of go test, replaced in tests to get stable results.
+var benchModule = func(dir, pattern string) ([]byte, error) {
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", pattern, "-benchmem", ".")
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// benchCommand benchmarks every selected solver with its real input, running
+// go test -bench in the workspace module of each day, records the results in
+// the history under the current commit and prints them as a markdown table,
+// flagging the parts slower than on the previous commit.
+func benchCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to benchmark, every implemented part if not set")
+	threshold := flags.Float64("threshold", 10, "percentage above which an increase is a regression")
+	commit := flags.String("commit", "", "commit to record the results under, the current one if not set")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+	if *threshold < 0 {
+		return usageError{fmt.Errorf("invalid threshold %g", *threshold)}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+	if *commit == "" {
+		var errCommit error
+		if *commit, errCommit = currentCommit(root); errCommit != nil {
+			return errCommit
+		}
+	}
+	historyPath := filepath.Join(root, BenchHistoryFile)
+	history, errHistory := loadBenchHistory(historyPath)
+	if errHistory != nil {
+		return errHistory
+	}
+
+	run := BenchRun{Commit: *commit, Time: time.Now().UTC()}
+	var failed int
+	for _, solver := range solvers {
+		parts := solver.Parts()
+		if *part != 0 {
+			parts = []int{*part}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+
+		_, errStat := os.Stat(dayInputPath(root, solver))
+		if errors.Is(errStat, fs.ErrNotExist) {
+			fmt.Fprintf(stdout, "%s: no input, skipped\n", solver)
+			continue
+		}
+		if errStat != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errStat)
+			failed++
+			continue
+		}
+
+		results, errBenchmarking := benchDay(root, solver, *part)
+		if errBenchmarking != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errBenchmarking)
+			failed += len(parts)
+			continue
+		}
+		for _, p := range parts {
+			result, found := results[p]
+			if !found {
+				fmt.Fprintf(stdout, "%s part %d: no result of %s\n", solver, p, benchName)
+				failed++
+				continue
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	regressions := writeBenchTable(stdout, history, run, *threshold)
+	if len(run.Results) > 0 {
+		if errSaving := saveBenchHistory(historyPath, history.Record(run)); errSaving != nil {
+			return errSaving
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d part(s) failed", failed)
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%d regression(s) above %g%%", regressions, *threshold)
+	}
+	return nil
+}
+
+// benchDay runs the benchmark of the module of a day, for a single part or
+// every part when part is 0, and returns its results by part.
+func benchDay(root string, solver aoc.Solver, part int) (map[int]BenchResult, error) {
+	pattern := "^" + benchName + "$"
+	if part != 0 {
+		pattern += fmt.Sprintf("/^part%d$", part)
+	}
+	output, errRunning := benchModule(dayDir(root, solver.Year, solver.Day), pattern)
+	if errRunning != nil {
+		return nil, fmt.Errorf("%w\n%s", errRunning, bytes.TrimSpace(output))
+	}
+	results := make(map[int]BenchResult)
+	for _, line := range strings.Split(string(output), "\n") {
+		matches := benchLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		result := BenchResult{Year: solver.Year, Day: solver.Day, Part: int(matches[1][0] - '0')}
+		nsPerOp, errParsing := strconv.ParseFloat(matches[2], 64)
+		if errParsing != nil {
+			return nil, fmt.Errorf("unexpected benchmark result %q: %w", line, errParsing)
+		}
+		result.NsPerOp = int64(math.Round(nsPerOp))
+		result.BytesPerOp, _ = strconv.ParseInt(matches[3], 10, 64)
+		result.AllocsPerOp, _ = strconv.ParseInt(matches[4], 10, 64)
+		results[result.Part] = result
+	}
+	return results, nil
+}
+
+// writeBenchTable prints the results of a run as a markdown table and returns
+// the number of parts having regressed compared to the history.
+func writeBenchTable(w io.Writer, history BenchHistory, run BenchRun, threshold float64) int {
+	fmt.Fprintf(w, "| Day | Part | ns/op | B/op | allocs/op | Change | |\n")
+	fmt.Fprintf(w, "|---|---|---:|---:|---:|---:|---|\n")
+	var regressions int
+	for _, result := range run.Results {
+		change, note := "new", ""
+		if previous, found := history.Previous(run.Commit, result); found {
+			change = formatChange(previous.NsPerOp, result.NsPerOp)
+			var regressed []string
+			for _, metric := range []struct {
+				name              string
+				previous, current int64
+			}{
+				{"time", previous.NsPerOp, result.NsPerOp},
+				{"memory", previous.BytesPerOp, result.BytesPerOp},
+				{"allocs", previous.AllocsPerOp, result.AllocsPerOp},
+			} {
+				if float64(metric.current) > float64(metric.previous)*(1+threshold/100) {
+					regressed = append(regressed, metric.name)
+				}
+			}
+			if len(regressed) > 0 {
+				note = "regression: " + strings.Join(regressed, ", ")
+				regressions++
+			}
+		}
+		fmt.Fprintf(w, "| %d/%02d | %d | %d | %d | %d | %s | %s |\n",
+			result.Year, result.Day, result.Part, result.NsPerOp, result.BytesPerOp, result.AllocsPerOp, change, note)
+	}
+	return regressions
+}
+
+func formatChange(previous, current int64) string {
+	if previous == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", float64(current-previous)*100/float64(previous))
+}
+
+// currentCommit returns the commit checked out in the repository, suffixed
+// with -dirty when the working tree has uncommitted changes.
+func currentCommit(root string) (string, error) {
+	head, errHead := exec.Command("git", "-C", root, "rev-parse", "--short", "HEAD").Output()
+	if errHead != nil {
+		return "", fmt.Errorf("unable to get the current commit, set --commit: %w", errHead)
+	}
+	commit := strings.TrimSpace(string(head))
+	status, errStatus := exec.Command("git", "-C", root, "status", "--porcelain").Output()
+	if errStatus != nil {
+		return "", fmt.Errorf("unable to get the status of the working tree: %w", errStatus)
+	}
+	if len(bytes.TrimSpace(status)) > 0 {
+		commit += "-dirty"
+	}
+	return commit, nil
+}
+
+// loadBenchHistory reads the benchmark history, a missing file meaning no
+// benchmark has been recorded yet.
+func loadBenchHistory(path string) (BenchHistory, error) {
+	content, errReading := os.ReadFile(path)
+	if errors.Is(errReading, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if errReading != nil {
+		return nil, errReading
+	}
+	var history BenchHistory
+	if errDecoding := json.Unmarshal(content, &history); errDecoding != nil {
+		return nil, fmt.Errorf("%s: %w", path, errDecoding)
+	}
+	return history, nil
+}
+
+func saveBenchHistory(path string, history BenchHistory) error {
+	content, errEncoding := json.MarshalIndent(history, "", "  ")
+	if errEncoding != nil {
+		return errEncoding
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
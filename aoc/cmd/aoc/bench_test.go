@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestBenchCommand(t *testing.T) {
+	length := func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		content, err := io.ReadAll(input)
+		return aoc.Int(len(content)), err
+	}
+	aoc.Register(aoc.Solver{Year: 1995, Day: 1, Part1: length, Part2: length})
+
+	var nsPerOp int64
+	var patterns []string
+	defer func(original func(string, string) ([]byte, error)) { benchModule = original }(benchModule)
+	benchModule = func(dir, pattern string) ([]byte, error) {
+		if expected := filepath.Join(os.Getenv("AOC_ROOT"), "1995", "day01"); dir != expected {
+			t.Errorf("Expected the benchmarks to run in %s, got %s", expected, dir)
+		}
+		patterns = append(patterns, pattern)
+		var output strings.Builder
+		output.WriteString("goos: linux\ngoarch: amd64\n")
+		for part := 1; part <= 2; part++ {
+			if pattern == "^BenchmarkSolver$" || strings.HasSuffix(pattern, fmt.Sprintf("/^part%d$", part)) {
+				fmt.Fprintf(&output, "BenchmarkSolver/part%d-8  \t      10\t%d ns/op\t      64 B/op\t       2 allocs/op\n", part, nsPerOp)
+			}
+		}
+		output.WriteString("PASS\nok  \tgithub.com/antitoine/advent-of-code/1995/day01\t0.012s\n")
+		return []byte(output.String()), nil
+	}
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	if err := os.MkdirAll(filepath.Join(root, "1995", "day01"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "1995", "day01", "input.txt"), []byte("abcd"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	nsPerOp = 1000
+	var stdout strings.Builder
+	if err := benchCommand([]string{"1995", "--commit", "first"}, &stdout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "| 1995/01 | 2 | 1000 | 64 | 2 | new |  |") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	nsPerOp = 1050
+	stdout.Reset()
+	if err := benchCommand([]string{"1995", "--commit", "second"}, &stdout); err != nil {
+		t.Fatalf("Expected a 5%% increase to be tolerated, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "| 1995/01 | 1 | 1050 | 64 | 2 | +5.0% |  |") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	nsPerOp = 1200
+	stdout.Reset()
+	if err := benchCommand([]string{"1995", "1", "--part", "2", "--commit", "third"}, &stdout); err == nil || !strings.Contains(err.Error(), "1 regression(s)") {
+		t.Errorf("Expected a regression, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "| 1995/01 | 2 | 1200 | 64 | 2 | +14.3% | regression: time |") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	// Benchmarking the same commit again replaces its results.
+	nsPerOp = 1000
+	if err := benchCommand([]string{"1995", "1", "--part", "2", "--commit", "third"}, io.Discard); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	history, err := loadBenchHistory(filepath.Join(root, BenchHistoryFile))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(history) != 3 || history[2].Commit != "third" || len(history[2].Results) != 1 || history[2].Results[0].NsPerOp != 1000 {
+		t.Errorf("Expected the third run to be replaced, got %+v", history)
+	}
+	if expected := []string{"^BenchmarkSolver$", "^BenchmarkSolver$", "^BenchmarkSolver$/^part2$", "^BenchmarkSolver$/^part2$"}; strings.Join(patterns, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected the benchmarks %v, got %v", expected, patterns)
+	}
+}
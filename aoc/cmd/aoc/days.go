@@ -0,0 +1,66 @@
+package main
+
+import (
+	_ "github.com/antitoine/advent-of-code/2023/day01"
+	_ "github.com/antitoine/advent-of-code/2023/day02"
+	_ "github.com/antitoine/advent-of-code/2023/day03"
+	_ "github.com/antitoine/advent-of-code/2023/day04"
+	_ "github.com/antitoine/advent-of-code/2023/day05"
+	_ "github.com/antitoine/advent-of-code/2023/day06"
+	_ "github.com/antitoine/advent-of-code/2023/day07"
+	_ "github.com/antitoine/advent-of-code/2023/day08"
+	_ "github.com/antitoine/advent-of-code/2023/day09"
+	_ "github.com/antitoine/advent-of-code/2023/day10"
+	_ "github.com/antitoine/advent-of-code/2023/day11"
+	_ "github.com/antitoine/advent-of-code/2023/day12"
+	_ "github.com/antitoine/advent-of-code/2023/day13"
+	_ "github.com/antitoine/advent-of-code/2023/day14"
+	_ "github.com/antitoine/advent-of-code/2023/day15"
+	_ "github.com/antitoine/advent-of-code/2023/day16"
+	_ "github.com/antitoine/advent-of-code/2023/day17"
+	_ "github.com/antitoine/advent-of-code/2023/day18"
+	_ "github.com/antitoine/advent-of-code/2023/day19"
+	_ "github.com/antitoine/advent-of-code/2023/day20"
+	_ "github.com/antitoine/advent-of-code/2023/day21"
+	_ "github.com/antitoine/advent-of-code/2023/day22"
+	_ "github.com/antitoine/advent-of-code/2023/day23"
+	_ "github.com/antitoine/advent-of-code/2023/day24"
+	_ "github.com/antitoine/advent-of-code/2023/day25"
+	_ "github.com/antitoine/advent-of-code/2024/day01"
+	_ "github.com/antitoine/advent-of-code/2024/day02"
+	_ "github.com/antitoine/advent-of-code/2024/day03"
+	_ "github.com/antitoine/advent-of-code/2024/day04"
+	_ "github.com/antitoine/advent-of-code/2024/day05"
+	_ "github.com/antitoine/advent-of-code/2024/day06"
+	_ "github.com/antitoine/advent-of-code/2024/day07"
+	_ "github.com/antitoine/advent-of-code/2024/day08"
+	_ "github.com/antitoine/advent-of-code/2024/day09"
+	_ "github.com/antitoine/advent-of-code/2024/day10"
+	_ "github.com/antitoine/advent-of-code/2024/day11"
+	_ "github.com/antitoine/advent-of-code/2024/day12"
+	_ "github.com/antitoine/advent-of-code/2024/day13"
+	_ "github.com/antitoine/advent-of-code/2024/day14"
+	_ "github.com/antitoine/advent-of-code/2024/day15"
+	_ "github.com/antitoine/advent-of-code/2024/day16"
+	_ "github.com/antitoine/advent-of-code/2024/day17"
+	_ "github.com/antitoine/advent-of-code/2024/day18"
+	_ "github.com/antitoine/advent-of-code/2024/day19"
+	_ "github.com/antitoine/advent-of-code/2024/day20"
+	_ "github.com/antitoine/advent-of-code/2024/day21"
+	_ "github.com/antitoine/advent-of-code/2024/day22"
+	_ "github.com/antitoine/advent-of-code/2024/day23"
+	_ "github.com/antitoine/advent-of-code/2024/day24"
+	_ "github.com/antitoine/advent-of-code/2024/day25"
+	_ "github.com/antitoine/advent-of-code/2025/day01"
+	_ "github.com/antitoine/advent-of-code/2025/day02"
+	_ "github.com/antitoine/advent-of-code/2025/day03"
+	_ "github.com/antitoine/advent-of-code/2025/day04"
+	_ "github.com/antitoine/advent-of-code/2025/day05"
+	_ "github.com/antitoine/advent-of-code/2025/day06"
+	_ "github.com/antitoine/advent-of-code/2025/day07"
+	_ "github.com/antitoine/advent-of-code/2025/day08"
+	_ "github.com/antitoine/advent-of-code/2025/day09"
+	_ "github.com/antitoine/advent-of-code/2025/day10"
+	_ "github.com/antitoine/advent-of-code/2025/day11"
+	_ "github.com/antitoine/advent-of-code/2025/day12"
+)
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/tui"
+)
+
+// debugCommand steps through the simulation of a part of a day, reading the
+// commands of the debugger from stdin.
+func debugCommand(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("debug", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to simulate, the last implemented one if not set")
+	inputPath := flags.String("input", "", "input file, the one of the day if not set")
+	breakpoint := flags.String("break", "", "breakpoint, as <name> <operator> <value>")
+	noColor := flags.Bool("no-color", false, "show the states without ANSI colors nor clearing the screen")
+	params := paramsFlag(flags)
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) != 2 || positional[0] == "all" {
+		return usageError{errors.New("expected <year> <day>")}
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+	// The commands are read from stdin, the input can't be.
+	if *inputPath == "-" {
+		return usageError{errors.New("--input can't be read from stdin, which the commands are read from")}
+	}
+	var condition *tui.Condition
+	if *breakpoint != "" {
+		parsed, errParsing := tui.ParseCondition(*breakpoint)
+		if errParsing != nil {
+			return usageError{errParsing}
+		}
+		condition = &parsed
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	solver := solvers[0]
+	if *part == 0 {
+		if parts := solver.Parts(); len(parts) > 0 {
+			*part = parts[len(parts)-1]
+		}
+	}
+
+	path := *inputPath
+	if path == "" {
+		root, errRoot := findRoot()
+		if errRoot != nil {
+			return errRoot
+		}
+		path = dayInputPath(root, solver)
+	}
+	content, errReading := os.ReadFile(path)
+	if errReading != nil {
+		return errReading
+	}
+	if errLinting := lintInput(solver, content); errLinting != nil {
+		return fmt.Errorf("%s: %w", solver, errLinting)
+	}
+
+	ctx := aoc.WithParams(context.Background(), params)
+	simulation, errSimulating := solver.StartSimulation(ctx, *part, bytes.NewReader(content))
+	if errSimulating != nil {
+		return fmt.Errorf("%s part %d: %w", solver, *part, errSimulating)
+	}
+	debugger := tui.New(simulation)
+	debugger.Color = !*noColor
+	debugger.Breakpoint = condition
+	return debugger.Run(stdin, stdout)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// walk moves a robot to the right of a corridor, up to its wall.
+type walk struct {
+	corridor []byte
+	robot    int
+}
+
+func (w *walk) Step() bool {
+	if w.corridor[w.robot+1] == '#' {
+		return false
+	}
+	w.robot++
+	return true
+}
+
+func (w *walk) Clone() aoc.Simulation {
+	clone := *w
+	return &clone
+}
+
+func (w *walk) View() aoc.View {
+	corridor := []byte(string(w.corridor))
+	corridor[w.robot] = '@'
+	return aoc.View{Grid: []string{string(corridor)}, Values: []aoc.Value{{Name: "x", Value: w.robot}}}
+}
+
+func TestDebugCommand(t *testing.T) {
+	aoc.Register(aoc.Solver{
+		Year: 1988,
+		Day:  1,
+		Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+			return aoc.Int(0), nil
+		},
+		Simulate: func(ctx context.Context, part int, input io.Reader) (aoc.Simulation, error) {
+			content, errReading := io.ReadAll(input)
+			start, errParam := aoc.ParamInt(ctx, "start", 0)
+			if errReading != nil || errParam != nil {
+				return nil, errors.Join(errReading, errParam)
+			}
+			return &walk{corridor: []byte(strings.TrimSpace(string(content))), robot: start}, nil
+		},
+	})
+	aoc.Register(aoc.Solver{Year: 1988, Day: 2, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}})
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	if err := os.MkdirAll(filepath.Join(root, "1988", "day01"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "1988", "day01", "input.txt"), []byte("........#\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout strings.Builder
+	args := []string{"1988", "1", "--no-color", "--param", "start=2", "--break", "x == 5"}
+	if err := debugCommand(args, strings.NewReader("c\nb 2\nc\nc\nq\n"), &stdout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"step 0, breakpoint x == 5\n..@.....#\nx = 2\n",
+		"step 3, breakpoint x == 5\n.....@..#\nx = 5\nbreakpoint x == 5 hit after 3 step(s)\n",
+		"step 1, breakpoint x == 5\n...@....#\n",
+		"step 5 (over), breakpoint x == 5\n.......@#\nx = 7\nsimulation over after 2 step(s)\n",
+	} {
+		if !strings.Contains(stdout.String(), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, stdout.String())
+		}
+	}
+
+	if err := debugCommand([]string{"1988", "2", "--input", filepath.Join(root, "1988", "day01", "input.txt")}, strings.NewReader(""), io.Discard); !errors.Is(err, aoc.ErrNoSimulation) {
+		t.Errorf("Expected a day without simulation to be refused, got %v", err)
+	}
+	if err := debugCommand([]string{"1988", "1", "--input", "-"}, strings.NewReader(""), io.Discard); err == nil {
+		t.Errorf("Expected the input not to be read from stdin")
+	}
+	if err := debugCommand([]string{"1988", "1", "--break", "x"}, strings.NewReader(""), io.Discard); err == nil {
+		t.Errorf("Expected an invalid breakpoint to be refused")
+	}
+}
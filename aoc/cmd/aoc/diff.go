@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
+
+// diffCommand compares the solutions of a day with its oracles on the inputs
+// generated for seeds following the given one, and reports every
+// counterexample. They are also written to the output directory if set, to
+// be investigated or turned into examples. With --shrink, each counterexample
+// is first shrunk into a smaller input on which the part still disagrees
+// with its oracle, and written as an example expecting the answer of the
+// oracle.
+func diffCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to compare, every part with an oracle if not set")
+	seed := flags.Int64("seed", 1, "seed of the first input")
+	size := flags.Int("size", 10, "size of the inputs, its meaning depends on the day")
+	count := flags.Int("count", 100, "number of inputs, each one with the next seed")
+	timeout := flags.Duration("timeout", 10*time.Second, "time after which comparing a part on an input fails")
+	outDir := flags.String("out", "", "directory of the counterexample files, not written if not set")
+	shrink := flags.Bool("shrink", false, "shrink the counterexamples, printing them")
+	params := paramsFlag(flags)
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) != 2 || positional[0] == "all" {
+		return usageError{errors.New("expected <year> <day>")}
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+	if *size < 1 {
+		return usageError{fmt.Errorf("invalid size %d", *size)}
+	}
+	if *count < 1 {
+		return usageError{fmt.Errorf("invalid count %d", *count)}
+	}
+	if *timeout <= 0 {
+		return usageError{fmt.Errorf("invalid timeout %s", *timeout)}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	solver := solvers[0]
+	var parts []int
+	for _, solverPart := range solver.Parts() {
+		if (*part == 0 || *part == solverPart) && solver.Oracle(solverPart) != nil {
+			parts = append(parts, solverPart)
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("%s: %w", solver, aoc.ErrNoOracle)
+	}
+
+	if *outDir != "" {
+		if errCreating := os.MkdirAll(*outDir, 0o755); errCreating != nil {
+			return errCreating
+		}
+	}
+	var nbCounterexamples int
+	for s := *seed; s < *seed+int64(*count); s++ {
+		input, errGenerating := solver.GenerateInput(s, *size)
+		if errGenerating != nil {
+			return fmt.Errorf("%s: %w", solver, errGenerating)
+		}
+		var failed bool
+		for _, solverPart := range parts {
+			ctx, cancel := context.WithTimeout(aoc.WithParams(context.Background(), params), *timeout)
+			errComparing := solver.CompareOracle(ctx, solverPart, input)
+			cancel()
+			if errComparing == nil {
+				continue
+			}
+			fmt.Fprintf(stdout, "%s seed %d: %v\n", solver, s, errComparing)
+			nbCounterexamples++
+			failed = true
+			if !*shrink {
+				continue
+			}
+			ctx, cancel = context.WithTimeout(aoc.WithParams(context.Background(), params), aoctest.ShrinkTimeout)
+			shrunk, mismatch := solver.ShrinkMismatch(ctx, solverPart, input, *timeout)
+			cancel()
+			if mismatch == nil {
+				continue
+			}
+			fmt.Fprintf(stdout, "  shrunk: %v\n    %s\n", mismatch, strings.ReplaceAll(strings.TrimSuffix(string(shrunk), "\n"), "\n", "\n    "))
+			if *outDir != "" {
+				example := aoctest.Example{
+					Name:   fmt.Sprintf("shrunk-part%d-size%d-seed%d", solverPart, *size, s),
+					Input:  shrunk,
+					Params: params,
+				}
+				if mismatch.OracleErr == nil {
+					example.Answers = map[int]string{solverPart: mismatch.Expected}
+				}
+				if errWriting := aoctest.WriteExample(*outDir, example); errWriting != nil {
+					return errWriting
+				}
+				fmt.Fprintf(stdout, "%s\n", filepath.Join(*outDir, example.Name+".txt"))
+			}
+		}
+		if failed && !*shrink && *outDir != "" {
+			path := filepath.Join(*outDir, fmt.Sprintf("size%d-seed%d.txt", *size, s))
+			if errWriting := os.WriteFile(path, input, 0o644); errWriting != nil {
+				return errWriting
+			}
+			fmt.Fprintf(stdout, "%s\n", path)
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d input(s) compared, %d counterexample(s)\n", *count, nbCounterexamples)
+	if nbCounterexamples > 0 {
+		return fmt.Errorf("%d counterexample(s)", nbCounterexamples)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
+
+func TestDiffCommand(t *testing.T) {
+	countLines := func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		scanner := bufio.NewScanner(input)
+		var count int
+		for scanner.Scan() {
+			count++
+		}
+		return aoc.Int(count), scanner.Err()
+	}
+	// The solution is wrong past 3 lines.
+	solution := func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		answer, err := countLines(ctx, input)
+		if count, _ := answer.Int64(); count > 3 {
+			return aoc.Int(3), err
+		}
+		return answer, err
+	}
+	generate := func(rng *rand.Rand, size int, w io.Writer) error {
+		for i := rng.Intn(size); i >= 0; i-- {
+			fmt.Fprintln(w, i)
+		}
+		return nil
+	}
+	aoc.Register(aoc.Solver{Year: 1989, Day: 1, Part1: solution, Oracle1: countLines, Generate: generate})
+	aoc.Register(aoc.Solver{Year: 1989, Day: 2, Part1: solution, Generate: generate})
+
+	var stdout strings.Builder
+	if err := diffCommand([]string{"1989", "1", "--size", "3"}, &stdout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(stdout.String(), "100 input(s) compared, 0 counterexample(s)\n") {
+		t.Errorf("Expected no counterexample, got:\n%s", stdout.String())
+	}
+
+	dir := filepath.Join(t.TempDir(), "counterexamples")
+	stdout.Reset()
+	err := diffCommand([]string{"1989", "1", "--size", "10", "--count", "20", "--out", dir}, &stdout)
+	if err == nil || !strings.HasSuffix(err.Error(), "counterexample(s)") {
+		t.Fatalf("Expected counterexamples, got %v", err)
+	}
+	first := strings.SplitN(stdout.String(), "\n", 2)[0]
+	var seed int64
+	if _, errScanning := fmt.Sscanf(first, "1989/01 seed %d:", &seed); errScanning != nil {
+		t.Fatalf("Expected a counterexample line, got %q", first)
+	}
+	if !strings.HasSuffix(first, "part 1 answered 3, the oracle answered "+fmt.Sprint(countOf(t, dir, seed))) {
+		t.Errorf("Expected the mismatch of the written counterexample, got %q", first)
+	}
+
+	// Any 4 lines are enough for the solution to be wrong, emptied as their
+	// content doesn't matter.
+	stdout.Reset()
+	shrunkDir := t.TempDir()
+	err = diffCommand([]string{"1989", "1", "--size", "10", "--count", "20", "--shrink", "--out", shrunkDir}, &stdout)
+	if err == nil {
+		t.Fatalf("Expected counterexamples")
+	}
+	if !strings.Contains(stdout.String(), "  shrunk: part 1 answered 3, the oracle answered 4\n") {
+		t.Errorf("Expected the shrunk mismatch, got:\n%s", stdout.String())
+	}
+	examples, err := aoctest.ReadExamples(shrunkDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	name := fmt.Sprintf("shrunk-part1-size10-seed%d", seed)
+	found := false
+	for _, example := range examples {
+		if example.Name != name {
+			continue
+		}
+		found = true
+		if string(example.Input) != "\n\n\n\n" {
+			t.Errorf("Expected the counterexample to be shrunk to 4 empty lines, got %q", example.Input)
+		}
+		if example.Answers[1] != "4" {
+			t.Errorf("Expected the example to expect the answer of the oracle, got %v", example.Answers)
+		}
+	}
+	if !found {
+		t.Errorf("Expected the example %s among %d examples", name, len(examples))
+	}
+
+	if err := diffCommand([]string{"1989", "2"}, io.Discard); !errors.Is(err, aoc.ErrNoOracle) {
+		t.Errorf("Expected ErrNoOracle, got %v", err)
+	}
+	if err := diffCommand([]string{"1989"}, io.Discard); err == nil {
+		t.Errorf("Expected a missing day to be refused")
+	}
+}
+
+// countOf returns the number of lines of the counterexample written for the
+// seed.
+func countOf(t *testing.T, dir string, seed int64) int {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("size10-seed%d.txt", seed)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return strings.Count(string(content), "\n")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/antitoine/advent-of-code/aoc/client"
+)
+
+// fetchCommand downloads the input of every selected day which doesn't have
+// one yet, into its day directory.
+func fetchCommand(args []string, stdout io.Writer) error {
+	positional, errArgs := parseArgs(flag.NewFlagSet("fetch", flag.ContinueOnError), args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+
+	var c *client.Client
+	var failed int
+	for _, solver := range solvers {
+		path := dayInputPath(root, solver)
+		if _, errStat := os.Stat(path); errStat == nil {
+			continue
+		}
+		if c == nil {
+			session, errSession := client.LoadSession()
+			if errSession != nil {
+				return errSession
+			}
+			c = newClient(session)
+		}
+		if _, errFetching := c.Input(context.Background(), path, solver.Year, solver.Day); errFetching != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errFetching)
+			failed++
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: input saved to %s\n", solver, path)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d input(s) not fetched", failed)
+	}
+	return nil
+}
+
+// newClient returns a client of the website, or of the server at AOC_BASE_URL
+// when it is set.
+func newClient(session string) *client.Client {
+	c := client.New(session)
+	if baseURL := os.Getenv("AOC_BASE_URL"); baseURL != "" {
+		c.BaseURL = baseURL
+	}
+	return c
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestFetchCommand(t *testing.T) {
+	aoc.Register(aoc.Solver{Year: 1997, Day: 1, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}})
+	aoc.Register(aoc.Solver{Year: 1997, Day: 2, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}})
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Write([]byte("input of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	t.Setenv("AOC_BASE_URL", server.URL)
+	t.Setenv("AOC_SESSION", "secret")
+	cachedPath := filepath.Join(root, "1997", "day01", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(cachedPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachedPath, []byte("cached"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "1997", "day02"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout strings.Builder
+	if err := fetchCommand([]string{"1997"}, &stdout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(requested, " ") != "/1997/day/2/input" {
+		t.Errorf("Expected only the missing input to be requested, got %v", requested)
+	}
+	content, err := os.ReadFile(filepath.Join(root, "1997", "day02", "input.txt"))
+	if err != nil || string(content) != "input of /1997/day/2/input" {
+		t.Errorf("Expected the input to be saved, got %q (%v)", content, err)
+	}
+}
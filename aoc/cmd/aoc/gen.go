@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// genCommand writes inputs generated by the generator of a day, for seeds
+// following the given one. A single input is written to stdout, several ones
+// to files of the output directory.
+func genCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	seed := flags.Int64("seed", 1, "seed of the first input")
+	size := flags.Int("size", 10, "size of the inputs, its meaning depends on the day")
+	count := flags.Int("count", 1, "number of inputs, each one with the next seed")
+	outDir := flags.String("out", "", "directory of the input files, stdout if not set")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) != 2 || positional[0] == "all" {
+		return usageError{errors.New("expected <year> <day>")}
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *size < 1 {
+		return usageError{fmt.Errorf("invalid size %d", *size)}
+	}
+	if *count < 1 {
+		return usageError{fmt.Errorf("invalid count %d", *count)}
+	}
+	if *count > 1 && *outDir == "" {
+		return usageError{errors.New("--count requires --out")}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	solver := solvers[0]
+
+	if *outDir != "" {
+		if errCreating := os.MkdirAll(*outDir, 0o755); errCreating != nil {
+			return errCreating
+		}
+	}
+	for s := *seed; s < *seed+int64(*count); s++ {
+		input, errGenerating := solver.GenerateInput(s, *size)
+		if errGenerating != nil {
+			return fmt.Errorf("%s: %w", solver, errGenerating)
+		}
+		if *outDir == "" {
+			_, errWriting := stdout.Write(input)
+			return errWriting
+		}
+		path := filepath.Join(*outDir, fmt.Sprintf("size%d-seed%d.txt", *size, s))
+		if errWriting := os.WriteFile(path, input, 0o644); errWriting != nil {
+			return errWriting
+		}
+		fmt.Fprintf(stdout, "%s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestGenCommand(t *testing.T) {
+	part := func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}
+	aoc.Register(aoc.Solver{Year: 1990, Day: 1, Part1: part, Generate: func(rng *rand.Rand, size int, w io.Writer) error {
+		for i := 0; i < size; i++ {
+			fmt.Fprintln(w, rng.Intn(100))
+		}
+		return nil
+	}})
+	aoc.Register(aoc.Solver{Year: 1990, Day: 2, Part1: part})
+
+	var first, again strings.Builder
+	if err := genCommand([]string{"1990", "1", "--seed", "7", "--size", "3"}, &first); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := genCommand([]string{"1990", "1", "--seed", "7", "--size", "3"}, &again); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Count(first.String(), "\n") != 3 || first.String() != again.String() {
+		t.Errorf("Expected the same 3 lines for the same seed, got %q and %q", first.String(), again.String())
+	}
+
+	dir := filepath.Join(t.TempDir(), "inputs")
+	var stdout strings.Builder
+	if err := genCommand([]string{"1990", "1", "--seed", "7", "--size", "3", "--count", "2", "--out", dir}, &stdout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "size3-seed7.txt"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(content) != first.String() {
+		t.Errorf("Expected the first file to hold the input of the seed 7, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "size3-seed8.txt")); err != nil {
+		t.Errorf("Expected the input of the seed 8, got %v", err)
+	}
+
+	if err := genCommand([]string{"1990", "2"}, io.Discard); !errors.Is(err, aoc.ErrNoGenerator) {
+		t.Errorf("Expected ErrNoGenerator, got %v", err)
+	}
+	if err := genCommand([]string{"1990", "1", "--count", "2"}, io.Discard); err == nil {
+		t.Errorf("Expected several inputs without a directory to be refused")
+	}
+}
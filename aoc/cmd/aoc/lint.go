@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// lintInputCommand checks the inputs of the selected days against the input
+// spec of their solver, and reports every violation. Days without a spec are
+// reported as unchecked, failing the command with --strict, and days without
+// an input are skipped.
+func lintInputCommand(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("lint-input", flag.ContinueOnError)
+	inputPath := flags.String("input", "", "input file, - to read it from stdin")
+	strict := flags.Bool("strict", false, "fail when a day has no input spec")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *inputPath != "" && selection.Day == 0 {
+		return usageError{errors.New("--input requires a single day")}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	var root string
+	if *inputPath == "" {
+		var errRoot error
+		if root, errRoot = findRoot(); errRoot != nil {
+			return errRoot
+		}
+	}
+
+	var nbValid, nbInvalid, nbUnchecked, nbSkipped int
+	for _, solver := range solvers {
+		if solver.Input == nil {
+			fmt.Fprintf(stdout, "%s: no input spec, unchecked\n", solver)
+			nbUnchecked++
+			continue
+		}
+
+		var content []byte
+		var errReading error
+		switch *inputPath {
+		case "-":
+			content, errReading = io.ReadAll(stdin)
+		case "":
+			content, errReading = os.ReadFile(dayInputPath(root, solver))
+			if errors.Is(errReading, fs.ErrNotExist) {
+				fmt.Fprintf(stdout, "%s: no input, skipped\n", solver)
+				nbSkipped++
+				continue
+			}
+		default:
+			content, errReading = os.ReadFile(*inputPath)
+		}
+		if errReading != nil {
+			return errReading
+		}
+
+		if errLinting := lintInput(solver, content); errLinting != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errLinting)
+			nbInvalid++
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: ok\n", solver)
+		nbValid++
+	}
+
+	fmt.Fprintf(stdout, "%d valid, %d invalid, %d unchecked, %d skipped\n", nbValid, nbInvalid, nbUnchecked, nbSkipped)
+	if nbInvalid > 0 {
+		return fmt.Errorf("%d invalid input(s)", nbInvalid)
+	}
+	if *strict && nbUnchecked > 0 {
+		return fmt.Errorf("%d day(s) without an input spec", nbUnchecked)
+	}
+	return nil
+}
+
+// invalidInputError lists the violations of the input spec of a solver.
+type invalidInputError []*aoc.ParseError
+
+func (e invalidInputError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d violation(s) of the input spec", len(e))
+	for _, violation := range e {
+		sb.WriteString("\n  ")
+		sb.WriteString(violation.Error())
+	}
+	return sb.String()
+}
+
+// lintInput checks the input against the spec of the solver, if it has one.
+func lintInput(solver aoc.Solver, content []byte) error {
+	if solver.Input == nil {
+		return nil
+	}
+	violations, errChecking := aoc.CheckInput(solver.Input, bytes.NewReader(content))
+	if errChecking != nil {
+		return errChecking
+	}
+	if len(violations) > 0 {
+		return invalidInputError(violations)
+	}
+	return nil
+}
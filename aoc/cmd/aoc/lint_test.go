@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestLintInputCommand(t *testing.T) {
+	part := func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}
+	spec := aoc.LinesMatching(`\d+`, "an integer")
+	aoc.Register(aoc.Solver{Year: 1991, Day: 1, Part1: part, Input: spec})
+	aoc.Register(aoc.Solver{Year: 1991, Day: 2, Part1: part, Input: spec})
+	aoc.Register(aoc.Solver{Year: 1991, Day: 3, Part1: part})
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	for day, input := range map[string]string{"day01": "1\n2\n", "day02": "1\ntwo\n3\nfour\n", "day03": "x\n"} {
+		if err := os.MkdirAll(filepath.Join(root, "1991", day), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "1991", day, "input.txt"), []byte(input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout strings.Builder
+	if err := lintInputCommand([]string{"1991"}, nil, &stdout); err == nil || err.Error() != "1 invalid input(s)" {
+		t.Errorf("Expected an invalid input, got %v", err)
+	}
+	expected := `1991/01: ok
+1991/02: 2 violation(s) of the input spec
+  line 2, column 1: unexpected "two", expected an integer
+  line 4, column 1: unexpected "four", expected an integer
+1991/03: no input spec, unchecked
+1 valid, 1 invalid, 1 unchecked, 0 skipped
+`
+	if stdout.String() != expected {
+		t.Errorf("Expected the output:\n%s\ngot:\n%s", expected, stdout.String())
+	}
+
+	// An invalid input isn't solved.
+	stdout.Reset()
+	if err := runCommand([]string{"1991", "2"}, nil, &stdout, io.Discard); err == nil {
+		t.Errorf("Expected the invalid input to fail")
+	}
+	if !strings.HasPrefix(stdout.String(), "1991/02: 2 violation(s)") {
+		t.Errorf("Expected the violations instead of an answer, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := lintInputCommand([]string{"1991", "2", "--input", "-"}, strings.NewReader("4\n2\n"), &stdout); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	stdout.Reset()
+	if err := lintInputCommand([]string{"1991", "3", "--strict"}, nil, &stdout); err == nil || err.Error() != "1 day(s) without an input spec" {
+		t.Errorf("Expected the day without an input spec to fail, got %v", err)
+	}
+}
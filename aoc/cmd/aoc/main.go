@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+const usage = `Usage: aoc <command> [arguments]
+
+Commands:
+  run <year|all> [day] [--part 1|2] [--input path|-] [--json] [--timeout duration]
+      [--events quiet|text|json] [--param name=value]... [--render dir] [--scale n]
+  run-all <year|all> [day] [--part 1|2] [--jobs n] [--timeout duration]
+  verify <year|all> [day] [--part 1|2]
+  lint-input <year|all> [day] [--input path|-] [--strict]
+  fetch <year|all> [day]
+  submit <year> <day> <part> [--answer value]
+  new <year> <day> [--title title]
+  gen <year> <day> [--seed n] [--size n] [--count n] [--out dir]
+  diff <year> <day> [--part 1|2] [--seed n] [--size n] [--count n] [--timeout duration]
+      [--shrink] [--out dir] [--param name=value]...
+  debug <year> <day> [--part 1|2] [--input path] [--break condition] [--no-color]
+      [--param name=value]...
+  serve [--addr host:port] [--timeout duration] [--max-body bytes] [--jobs n]
+  bench <year|all> [day] [--part 1|2] [--threshold percent] [--commit id]
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+	case "run-all":
+		err = runAllCommand(os.Args[2:], os.Stdout)
+	case "verify":
+		err = verifyCommand(os.Args[2:], os.Stdout)
+	case "lint-input":
+		err = lintInputCommand(os.Args[2:], os.Stdin, os.Stdout)
+	case "fetch":
+		err = fetchCommand(os.Args[2:], os.Stdout)
+	case "submit":
+		err = submitCommand(os.Args[2:], os.Stdout)
+	case "new":
+		err = newCommand(os.Args[2:], os.Stdout)
+	case "gen":
+		err = genCommand(os.Args[2:], os.Stdout)
+	case "diff":
+		err = diffCommand(os.Args[2:], os.Stdout)
+	case "debug":
+		err = debugCommand(os.Args[2:], os.Stdin, os.Stdout)
+	case "serve":
+		err = serveCommand(os.Args[2:], os.Stderr)
+	case "bench":
+		err = benchCommand(os.Args[2:], os.Stdout)
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", os.Args[1])
+	}
+
+	if err != nil {
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(os.Stderr, "aoc: %v\n\n%s", err, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// parseArgs parses flags which can be placed anywhere among the positional
+// arguments, so both "run 2024 17 --part 1" and "run --part 1 2024 17" work.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	flags.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if errParsing := flags.Parse(args); errParsing != nil {
+			return nil, usageError{errParsing}
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// Selection is a set of puzzles targeted by a command: every year, one year
+// or a single day.
+type Selection struct {
+	Year int
+	Day  int
+}
+
+func (s Selection) Match(year, day int) bool {
+	return (s.Year == 0 || s.Year == year) && (s.Day == 0 || s.Day == day)
+}
+
+// Solvers returns the registered solvers matching the selection.
+func (s Selection) Solvers() ([]aoc.Solver, error) {
+	var solvers []aoc.Solver
+	for _, solver := range aoc.Solvers() {
+		if s.Match(solver.Year, solver.Day) {
+			solvers = append(solvers, solver)
+		}
+	}
+	if len(solvers) == 0 {
+		return nil, errors.New("no solver registered for this selection")
+	}
+	return solvers, nil
+}
+
+func parseSelection(args []string) (Selection, error) {
+	var selection Selection
+	if len(args) == 0 || len(args) > 2 {
+		return selection, usageError{errors.New("expected <year|all> [day]")}
+	}
+	if args[0] == "all" {
+		if len(args) > 1 {
+			return selection, usageError{errors.New("a day can't be selected with all")}
+		}
+		return selection, nil
+	}
+	year, errYear := strconv.Atoi(args[0])
+	if errYear != nil {
+		return selection, usageError{fmt.Errorf("invalid year %q", args[0])}
+	}
+	selection.Year = year
+	if len(args) == 2 {
+		day, errDay := strconv.Atoi(args[1])
+		if errDay != nil || day < 1 || day > 25 {
+			return selection, usageError{fmt.Errorf("invalid day %q", args[1])}
+		}
+		selection.Day = day
+	}
+	return selection, nil
+}
+
+// findRoot returns the repository root, either from the AOC_ROOT environment
+// variable or by walking up from the working directory.
+func findRoot() (string, error) {
+	if root := os.Getenv("AOC_ROOT"); root != "" {
+		return root, nil
+	}
+	dir, errDir := os.Getwd()
+	if errDir != nil {
+		return "", errDir
+	}
+	for {
+		if _, errStat := os.Stat(filepath.Join(dir, "aoc", "go.mod")); errStat == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("unable to find the repository root, set AOC_ROOT")
+		}
+		dir = parent
+	}
+}
+
+func dayDir(root string, year, day int) string {
+	return filepath.Join(root, strconv.Itoa(year), fmt.Sprintf("day%02d", day))
+}
+
+func dayInputPath(root string, solver aoc.Solver) string {
+	return filepath.Join(dayDir(root, solver.Year, solver.Day), "input.txt")
+}
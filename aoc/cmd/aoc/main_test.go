@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	part := flags.Int("part", 0, "")
+	positional, err := parseArgs(flags, []string{"2024", "--part", "1", "17"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *part != 1 {
+		t.Errorf("Expected part to be 1, got %d", *part)
+	}
+	if !slices.Equal(positional, []string{"2024", "17"}) {
+		t.Errorf("Expected positional arguments to be [2024 17], got %v", positional)
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected Selection
+		valid    bool
+	}{
+		{[]string{"all"}, Selection{}, true},
+		{[]string{"2024"}, Selection{Year: 2024}, true},
+		{[]string{"2024", "17"}, Selection{Year: 2024, Day: 17}, true},
+		{[]string{"2024", "26"}, Selection{}, false},
+		{[]string{"all", "1"}, Selection{}, false},
+		{[]string{}, Selection{}, false},
+	}
+	for _, test := range tests {
+		selection, err := parseSelection(test.args)
+		if (err == nil) != test.valid {
+			t.Errorf("Expected %v validity to be %t, got error %v", test.args, test.valid, err)
+			continue
+		}
+		if test.valid && selection != test.expected {
+			t.Errorf("Expected %v to select %+v, got %+v", test.args, test.expected, selection)
+		}
+	}
+}
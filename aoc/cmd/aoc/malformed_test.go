@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// TestSolversMalformedInput checks that the solutions report an empty, a
+// ragged or a well-formed but meaningless input as an error instead of
+// panicking or running past their timeout.
+func TestSolversMalformedInput(t *testing.T) {
+	inputs := map[string]string{
+		"empty":     "",
+		"ragged":    "#####\n#...\n#\n",
+		"undefined": "L\n\nAAA = (BBZ, CCC)\n",
+		"no start":  "..\n..\n",
+		"open":      "....\n....\n....\n",
+	}
+	for _, solver := range aoc.Solvers() {
+		if solver.Year < 2015 {
+			continue
+		}
+		for _, part := range solver.Parts() {
+			for name, input := range inputs {
+				solver, part, input := solver, part, input
+				t.Run(fmt.Sprintf("%s/part%d/%s", solver, part, name), func(t *testing.T) {
+					ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+					defer cancel()
+					panicked := make(chan any, 1)
+					go func() {
+						defer func() { panicked <- recover() }()
+						solver.SolveContext(ctx, part, strings.NewReader(input))
+					}()
+					select {
+					case value := <-panicked:
+						if value != nil {
+							t.Errorf("Unexpected panic: %v", value)
+						}
+					case <-time.After(2 * time.Second):
+						t.Errorf("Still running after its timeout")
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const modulePrefix = "github.com/antitoine/advent-of-code/"
+
+var dayTemplates = map[string]*template.Template{
+	"go.mod": template.Must(template.New("go.mod").Parse(`module ` + modulePrefix + `{{.Year}}/day{{printf "%02d" .Day}}
+
+go {{.GoVersion}}
+
+require ` + modulePrefix + `aoc v0.0.0
+
+replace ` + modulePrefix + `aoc => ../../aoc
+`)),
+	"main.go": template.Must(template.New("main.go").Parse(`package day{{printf "%02d" .Day}}
+
+import (
+	"context"
+	"io"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func getResult(input io.Reader) (int, error) {
+	scanner := aoc.NewScanner(input)
+
+	for scanner.Scan() {
+		_ = scanner.Text()
+	}
+
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return 0, errScanningFile
+	}
+
+	return 0, nil
+}
+
+var Solver = aoc.Solver{
+	Year:  {{.Year}},
+	Day:   {{.Day}},
+	Title: {{printf "%q" .Title}},
+	Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		result, err := getResult(input)
+		return aoc.Int(result), err
+	},
+}
+
+func init() {
+	aoc.Register(Solver)
+}
+`)),
+	"main_test.go": template.Must(template.New("main_test.go").Parse(`package day{{printf "%02d" .Day}}
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Run(t, Solver)
+}
+
+func BenchmarkGetResult(b *testing.B) {
+	input := aoctest.ReadInput(b, "example")
+	for n := 0; n < b.N; n++ {
+		getResult(bytes.NewReader(input))
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Bench(b, Solver)
+}
+`)),
+	// The example of the puzzle replaces the empty one, along with its answer.
+	"testdata/example.txt":      template.Must(template.New("example.txt").Parse("")),
+	"testdata/example.expected": template.Must(template.New("example.expected").Parse("part1 0\n")),
+}
+
+// newCommand creates the module of a day from the templates, and adds it to
+// the workspaces, to the requirements of the aoc module and to the solvers
+// imported by the command. It refuses to touch a day directory which already
+// exists.
+func newCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	title := flags.String("title", "", "title of the puzzle")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) != 2 || positional[0] == "all" {
+		return usageError{errors.New("expected <year> <day>")}
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+	year, day := selection.Year, selection.Day
+
+	dir := dayDir(root, year, day)
+	if _, errStat := os.Stat(dir); errStat == nil {
+		return fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(errStat, fs.ErrNotExist) {
+		return errStat
+	}
+
+	rootWorkPath := filepath.Join(root, "go.work")
+	rootWork, errReading := os.ReadFile(rootWorkPath)
+	if errReading != nil {
+		return errReading
+	}
+	yearWorkPath := filepath.Join(root, strconv.Itoa(year), "go.work")
+	yearWork, errReading := os.ReadFile(yearWorkPath)
+	if errors.Is(errReading, fs.ErrNotExist) {
+		// The first day of a year starts its workspace.
+		yearWork = []byte(fmt.Sprintf("go %s\n\nuse (\n\t../aoc\n)\n", goVersion(rootWork)))
+	} else if errReading != nil {
+		return errReading
+	}
+	modPath := filepath.Join(root, "aoc", "go.mod")
+	mod, errReading := os.ReadFile(modPath)
+	if errReading != nil {
+		return errReading
+	}
+	daysPath := filepath.Join(root, "aoc", "cmd", "aoc", "days.go")
+	days, errReading := os.ReadFile(daysPath)
+	if errReading != nil {
+		return errReading
+	}
+
+	dayName := fmt.Sprintf("day%02d", day)
+	rootWork, errAdding := addToBlock(rootWork, "use", fmt.Sprintf("./%d/%s", year, dayName))
+	if errAdding != nil {
+		return fmt.Errorf("%s: %w", rootWorkPath, errAdding)
+	}
+	yearWork, errAdding = addToBlock(yearWork, "use", "./"+dayName)
+	if errAdding != nil {
+		return fmt.Errorf("%s: %w", yearWorkPath, errAdding)
+	}
+	modulePath := fmt.Sprintf("%s%d/%s", modulePrefix, year, dayName)
+	mod, errAdding = addToBlock(mod, "require", modulePath+" v0.0.0")
+	if errAdding != nil {
+		return fmt.Errorf("%s: %w", modPath, errAdding)
+	}
+	mod, errAdding = addToBlock(mod, "replace", fmt.Sprintf("%s => ../%d/%s", modulePath, year, dayName))
+	if errAdding != nil {
+		return fmt.Errorf("%s: %w", modPath, errAdding)
+	}
+	days, errAdding = addToBlock(days, "import", fmt.Sprintf("_ %q", modulePath))
+	if errAdding != nil {
+		return fmt.Errorf("%s: %w", daysPath, errAdding)
+	}
+
+	if errCreating := os.MkdirAll(filepath.Join(dir, "testdata"), 0o755); errCreating != nil {
+		return errCreating
+	}
+	data := struct {
+		Year, Day int
+		Title     string
+		GoVersion string
+	}{year, day, *title, goVersion(yearWork)}
+	for name, tmpl := range dayTemplates {
+		var content bytes.Buffer
+		if errExecuting := tmpl.Execute(&content, data); errExecuting != nil {
+			return errExecuting
+		}
+		if errWriting := os.WriteFile(filepath.Join(dir, name), content.Bytes(), 0o644); errWriting != nil {
+			return errWriting
+		}
+	}
+	for path, content := range map[string][]byte{rootWorkPath: rootWork, yearWorkPath: yearWork, modPath: mod, daysPath: days} {
+		if errWriting := os.WriteFile(path, content, 0o644); errWriting != nil {
+			return errWriting
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d/%02d created in %s\n", year, day, dir)
+	return nil
+}
+
+var goDirectiveRegex = regexp.MustCompile(`(?m)^go (\S+)$`)
+
+// goVersion returns the Go version required by a go.mod or go.work file,
+// defaulting to the version of the aoc module.
+func goVersion(content []byte) string {
+	if matches := goDirectiveRegex.FindSubmatch(content); matches != nil {
+		return string(matches[1])
+	}
+	return "1.21.3"
+}
+
+// addToBlock adds an entry to the parenthesised block of a Go file, such as
+// the use block of a go.work file, unless it is already there. The entry is
+// placed after the last one sharing its directory and sorting before it, so
+// that the entries of each year stay grouped and ordered.
+func addToBlock(content []byte, keyword, entry string) ([]byte, error) {
+	lines := strings.Split(string(content), "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == keyword+" (" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no %s block", keyword)
+	}
+
+	// The entries are grouped by the directory of their module path, the
+	// target of a replace directive left aside.
+	group := func(entry string) string {
+		modulePath, _, _ := strings.Cut(entry, " => ")
+		return modulePath[:strings.LastIndex(modulePath, "/")+1]
+	}
+	insertAt, firstInGroup := -1, -1
+	end := start + 1
+	for ; end < len(lines) && strings.TrimSpace(lines[end]) != ")"; end++ {
+		existing := strings.TrimSpace(lines[end])
+		if existing == entry {
+			return content, nil
+		}
+		if group(existing) != group(entry) {
+			continue
+		}
+		if firstInGroup < 0 {
+			firstInGroup = end
+		}
+		if existing < entry {
+			insertAt = end + 1
+		}
+	}
+	if end == len(lines) {
+		return nil, fmt.Errorf("unterminated %s block", keyword)
+	}
+	if insertAt < 0 && firstInGroup >= 0 {
+		insertAt = firstInGroup
+	} else if insertAt < 0 {
+		insertAt = end
+	}
+
+	lines = append(lines[:insertAt], append([]string{"\t" + entry}, lines[insertAt:]...)...)
+	return []byte(strings.Join(lines, "\n")), nil
+}
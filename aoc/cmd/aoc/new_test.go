@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewCommand(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	writeFile := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readFile := func(path string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+	writeFile("go.work", "go 1.25.4\n\nuse (\n\t./aoc\n\t./1994/day02\n)\n")
+	writeFile("1994/go.work", "go 1.23.2\n\nuse (\n\t../aoc\n\t./day02\n)\n")
+	writeFile("aoc/go.mod", "module "+modulePrefix+"aoc\n\ngo 1.25.4\n\nrequire (\n\t"+modulePrefix+"1994/day02 v0.0.0\n)\n\nrequire example.com/indirect v1.0.0 // indirect\n\nreplace (\n\t"+modulePrefix+"1994/day02 => ../1994/day02\n)\n")
+	writeFile("aoc/cmd/aoc/days.go", "package main\n\nimport (\n\t_ \""+modulePrefix+"1994/day02\"\n)\n")
+
+	if err := newCommand([]string{"1994", "1", "--title", "First"}, io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for path, expected := range map[string]string{
+		"go.work":             "go 1.25.4\n\nuse (\n\t./aoc\n\t./1994/day01\n\t./1994/day02\n)\n",
+		"1994/go.work":        "go 1.23.2\n\nuse (\n\t../aoc\n\t./day01\n\t./day02\n)\n",
+		"aoc/cmd/aoc/days.go": "package main\n\nimport (\n\t_ \"" + modulePrefix + "1994/day01\"\n\t_ \"" + modulePrefix + "1994/day02\"\n)\n",
+		"aoc/go.mod": "module " + modulePrefix + "aoc\n\ngo 1.25.4\n\nrequire (\n\t" + modulePrefix + "1994/day01 v0.0.0\n\t" + modulePrefix + "1994/day02 v0.0.0\n)\n\n" +
+			"require example.com/indirect v1.0.0 // indirect\n\nreplace (\n\t" + modulePrefix + "1994/day01 => ../1994/day01\n\t" + modulePrefix + "1994/day02 => ../1994/day02\n)\n",
+		"1994/day01/go.mod":                    "module " + modulePrefix + "1994/day01\n\ngo 1.23.2\n\nrequire " + modulePrefix + "aoc v0.0.0\n\nreplace " + modulePrefix + "aoc => ../../aoc\n",
+		"1994/day01/testdata/example.txt":      "",
+		"1994/day01/testdata/example.expected": "part1 0\n",
+	} {
+		if content := readFile(path); content != expected {
+			t.Errorf("Expected %s to be:\n%s\ngot:\n%s", path, expected, content)
+		}
+	}
+	main := readFile("1994/day01/main.go")
+	if !strings.Contains(main, "package day01") || !strings.Contains(main, `Title: "First",`) {
+		t.Errorf("Unexpected main.go:\n%s", main)
+	}
+	if test := readFile("1994/day01/main_test.go"); !strings.Contains(test, "func BenchmarkGetResult") || !strings.Contains(test, "func BenchmarkSolver") {
+		t.Errorf("Unexpected main_test.go:\n%s", test)
+	}
+
+	writeFile("1994/day01/main.go", "work in progress")
+	if err := newCommand([]string{"1994", "1"}, io.Discard); err == nil {
+		t.Errorf("Expected an existing day to be refused")
+	}
+	if main := readFile("1994/day01/main.go"); main != "work in progress" {
+		t.Errorf("Expected the existing day to be kept, got:\n%s", main)
+	}
+
+	// The first day of a year starts its workspace.
+	if err := newCommand([]string{"1993", "5"}, io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if work := readFile("1993/go.work"); work != "go 1.25.4\n\nuse (\n\t../aoc\n\t./day05\n)\n" {
+		t.Errorf("Unexpected go.work for a new year:\n%s", work)
+	}
+	if work := readFile("go.work"); !strings.HasSuffix(work, "\t./1994/day02\n\t./1993/day05\n)\n") {
+		t.Errorf("Expected a new year to be added last, got:\n%s", work)
+	}
+}
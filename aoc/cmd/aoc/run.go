@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/render"
+)
+
+func runCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to solve, every implemented part if not set")
+	inputPath := flags.String("input", "", "input file, - to read it from stdin")
+	jsonReports := flags.Bool("json", false, "print a JSON report per part, with its time and memory usage")
+	timeout := flags.Duration("timeout", 0, "time after which a part is stopped, no limit if not set")
+	events := flags.String("events", "quiet", "events of the solutions written to stderr: quiet, text or json")
+	renderDir := flags.String("render", "", "directory to draw the grids of the solutions into, as GIF or PNG files")
+	scale := flags.Int("scale", 4, "size in pixels of the cells of the rendered grids")
+	params := paramsFlag(flags)
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+	if *timeout < 0 {
+		return usageError{fmt.Errorf("invalid timeout %s", *timeout)}
+	}
+	if *scale < 1 {
+		return usageError{fmt.Errorf("invalid scale %d", *scale)}
+	}
+	if *inputPath != "" && selection.Day == 0 {
+		return usageError{errors.New("--input requires a single day")}
+	}
+	observer, errObserver := newObserver(*events, stderr)
+	if errObserver != nil {
+		return errObserver
+	}
+	ctx := aoc.WithParams(aoc.WithObserver(context.Background(), observer), params)
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+
+	var root string
+	if *inputPath == "" {
+		var errRoot error
+		if root, errRoot = findRoot(); errRoot != nil {
+			return errRoot
+		}
+	}
+
+	var stdinContent []byte
+	var failed int
+	for _, solver := range solvers {
+		parts := solver.Parts()
+		if *part != 0 {
+			parts = []int{*part}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		// A part the day doesn't provide is skipped, not failed, so that a
+		// selection of days can be solved for a single part.
+		if solver.Part(parts[0]) == nil {
+			if !*jsonReports {
+				fmt.Fprintf(stdout, "%s part %d: not implemented, skipped\n", solver, parts[0])
+				continue
+			}
+			report := PartReport{Year: solver.Year, Day: solver.Day, Part: parts[0], Skipped: true}
+			if errEncoding := json.NewEncoder(stdout).Encode(report); errEncoding != nil {
+				return errEncoding
+			}
+			continue
+		}
+
+		var content []byte
+		var errReading error
+		switch *inputPath {
+		case "-":
+			if stdinContent == nil {
+				if stdinContent, errReading = io.ReadAll(stdin); errReading != nil {
+					return fmt.Errorf("unable to read stdin: %w", errReading)
+				}
+			}
+			content = stdinContent
+		case "":
+			content, errReading = os.ReadFile(dayInputPath(root, solver))
+		default:
+			content, errReading = os.ReadFile(*inputPath)
+		}
+		if errReading != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errReading)
+			failed++
+			continue
+		}
+		// An input not fitting the spec of the day isn't solved.
+		if errLinting := lintInput(solver, content); errLinting != nil {
+			failed += len(parts)
+			if !*jsonReports {
+				fmt.Fprintf(stdout, "%s: %v\n", solver, errLinting)
+				continue
+			}
+			encoder := json.NewEncoder(stdout)
+			for _, p := range parts {
+				report := PartReport{Year: solver.Year, Day: solver.Day, Part: p, Error: errLinting.Error()}
+				if errEncoding := encoder.Encode(report); errEncoding != nil {
+					return errEncoding
+				}
+			}
+			continue
+		}
+
+		if *jsonReports {
+			encoder := json.NewEncoder(stdout)
+			for _, p := range parts {
+				recordingCtx, recorder := recordingContext(ctx, *renderDir, *scale)
+				report := measurePart(recordingCtx, solver, p, content, *timeout)
+				if report.Error != "" {
+					failed++
+				}
+				if errRendering := writeRendering(recorder, *renderDir, solver, p, stderr); errRendering != nil {
+					failed++
+				}
+				if errEncoding := encoder.Encode(report); errEncoding != nil {
+					return errEncoding
+				}
+			}
+			continue
+		}
+
+		for _, p := range parts {
+			recordingCtx, recorder := recordingContext(ctx, *renderDir, *scale)
+			partCtx, cancel := partContext(recordingCtx, *timeout)
+			start := time.Now()
+			answer, errSolving := solver.SolveContext(partCtx, p, bytes.NewReader(content))
+			cancel()
+			if errRendering := writeRendering(recorder, *renderDir, solver, p, stderr); errRendering != nil {
+				failed++
+			}
+			if errSolving != nil {
+				fmt.Fprintf(stdout, "%s part %d: %v\n", solver, p, errSolving)
+				failed++
+				continue
+			}
+			fmt.Fprintf(stdout, "%s part %d: %s (%s)\n", solver, p, answer, time.Since(start))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d part(s) failed", failed)
+	}
+	return nil
+}
+
+// partContext returns the context of a part solved within the timeout, if
+// there is one.
+func partContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// recordingContext returns the context of a part whose grids are recorded
+// when they are rendered into a directory, along with the recorder, nil when
+// they aren't.
+func recordingContext(ctx context.Context, dir string, scale int) (context.Context, *render.Recorder) {
+	if dir == "" {
+		return ctx, nil
+	}
+	recorder := render.NewRecorder(render.Default(), scale)
+	return aoc.WithObserver(ctx, aoc.Observers(aoc.ObserverFrom(ctx), recorder)), recorder
+}
+
+// writeRendering writes the grids recorded while solving the part, reporting
+// the files written or the failure on the writer.
+func writeRendering(recorder *render.Recorder, dir string, solver aoc.Solver, part int, writer io.Writer) error {
+	if recorder == nil {
+		return nil
+	}
+	paths, errWriting := recorder.WriteFiles(dir, fmt.Sprintf("%d-%02d-part%d", solver.Year, solver.Day, part))
+	for _, path := range paths {
+		fmt.Fprintf(writer, "%s part %d: rendered %s\n", solver, part, path)
+	}
+	if errWriting != nil {
+		fmt.Fprintf(writer, "%s part %d: %v\n", solver, part, errWriting)
+	}
+	return errWriting
+}
+
+// paramsFlag defines the repeatable --param flag, returning the parameters it
+// fills.
+func paramsFlag(flags *flag.FlagSet) aoc.Params {
+	params := make(aoc.Params)
+	flags.Func("param", "parameter of the solutions as name=value, can be repeated", func(value string) error {
+		name, paramValue, found := strings.Cut(value, "=")
+		if !found || name == "" {
+			return fmt.Errorf("expected name=value, got %q", value)
+		}
+		params[name] = paramValue
+		return nil
+	})
+	return params
+}
+
+// newObserver returns the observer writing the events of the solutions in
+// the given format.
+func newObserver(format string, writer io.Writer) (aoc.Observer, error) {
+	switch format {
+	case "quiet":
+		return aoc.Quiet, nil
+	case "text":
+		return aoc.NewTextObserver(writer), nil
+	case "json":
+		return aoc.NewJSONObserver(writer), nil
+	}
+	return nil, usageError{fmt.Errorf("invalid events format %q", format)}
+}
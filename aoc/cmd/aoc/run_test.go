@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestRunCommandEvents(t *testing.T) {
+	aoc.Register(aoc.Solver{Year: 1992, Day: 1, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		content, err := io.ReadAll(input)
+		aoc.Emit(ctx, aoc.Value{Name: "length", Value: len(content)})
+		return aoc.Int(len(content)), err
+	}})
+
+	var stdout, stderr strings.Builder
+	if err := runCommand([]string{"1992", "1", "--input", "-", "--events", "json"}, strings.NewReader("abcd"), &stdout, &stderr); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "1992/01 part 1: 4 (") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+	if expected := `{"event":"value","name":"length","value":4}` + "\n"; stderr.String() != expected {
+		t.Errorf("Expected the events:\n%s\ngot:\n%s", expected, stderr.String())
+	}
+
+	stderr.Reset()
+	if err := runCommand([]string{"1992", "1", "--input", "-"}, strings.NewReader("abcd"), io.Discard, &stderr); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("Expected no events by default, got:\n%s", stderr.String())
+	}
+
+	aoc.Register(aoc.Solver{Year: 1992, Day: 2, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.String(aoc.ParamsFrom(ctx)["name"]), nil
+	}})
+	stdout.Reset()
+	if err := runCommand([]string{"1992", "2", "--input", "-", "--param", "name=value"}, strings.NewReader(""), &stdout, io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "1992/02 part 1: value (") {
+		t.Errorf("Expected the parameter to be passed, got:\n%s", stdout.String())
+	}
+
+	if err := runCommand([]string{"1992", "1", "--events", "xml"}, nil, io.Discard, io.Discard); err == nil {
+		t.Errorf("Expected an invalid events format to be refused")
+	}
+}
+
+func TestRunCommandRender(t *testing.T) {
+	aoc.Register(aoc.Solver{Year: 1992, Day: 3, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		for i := 1; i <= 3; i++ {
+			aoc.Emit(ctx, aoc.GridSnapshot{Name: "robot", Rows: []string{strings.Repeat(".", i) + "@"}})
+		}
+		aoc.Emit(ctx, aoc.GridSnapshot{Name: "final map", Rows: []string{"#."}})
+		return aoc.Int(3), nil
+	}})
+
+	dir := t.TempDir()
+	var stdout, stderr strings.Builder
+	args := []string{"1992", "3", "--input", "-", "--render", dir, "--scale", "2", "--events", "text"}
+	if err := runCommand(args, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "1992/03 part 1: 3 (") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+	// The events are still written along with the rendering.
+	for _, expected := range []string{
+		"robot:\n.@\n",
+		"1992/03 part 1: rendered " + filepath.Join(dir, "1992-03-part1-robot.gif") + "\n",
+		"1992/03 part 1: rendered " + filepath.Join(dir, "1992-03-part1-final-map.png") + "\n",
+	} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, stderr.String())
+		}
+	}
+	file, err := os.Open(filepath.Join(dir, "1992-03-part1-robot.gif"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer file.Close()
+	animation, err := gif.DecodeAll(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(animation.Image) != 3 || animation.Config.Width != 8 {
+		t.Errorf("Expected 3 frames 8 pixels wide, got %d frames %d pixels wide", len(animation.Image), animation.Config.Width)
+	}
+
+	if err := runCommand([]string{"1992", "3", "--input", "-", "--scale", "0"}, strings.NewReader(""), io.Discard, io.Discard); err == nil {
+		t.Errorf("Expected an invalid scale to be refused")
+	}
+}
+
+func TestRunCommandNotImplemented(t *testing.T) {
+	aoc.Register(aoc.Solver{Year: 1992, Day: 4, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(1), nil
+	}})
+
+	var stdout strings.Builder
+	if err := runCommand([]string{"1992", "4", "--input", "-", "--part", "2"}, strings.NewReader(""), &stdout, io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "1992/04 part 2: not implemented, skipped\n"; stdout.String() != expected {
+		t.Errorf("Expected the output:\n%s\ngot:\n%s", expected, stdout.String())
+	}
+
+	stdout.Reset()
+	if err := runCommand([]string{"1992", "4", "--input", "-", "--part", "2", "--json"}, strings.NewReader(""), &stdout, io.Discard); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"skipped":true`) {
+		t.Errorf("Expected a skipped report, got:\n%s", stdout.String())
+	}
+}
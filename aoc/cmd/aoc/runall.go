@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// PartReport is the outcome of solving a puzzle part, along with the
+// resources it used.
+type PartReport struct {
+	Year   int    `json:"year"`
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Answer string `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Duration is the wall time of the part, in nanoseconds.
+	Duration time.Duration `json:"duration_ns"`
+	// PeakHeap is the greatest size of the heap seen while solving the part.
+	PeakHeap uint64 `json:"peak_heap_bytes"`
+	// Allocs is the number of heap objects allocated by the part.
+	Allocs uint64 `json:"allocs"`
+	// Partial is the best answer found by a part stopped before its end.
+	Partial  string `json:"partial,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+	Skipped  bool   `json:"skipped,omitempty"`
+}
+
+// heapSamplingPeriod is the period at which the heap size is sampled to
+// find its peak while a part is solved.
+const heapSamplingPeriod = 5 * time.Millisecond
+
+// measurePart solves a part within the timeout, if there is one, and reports
+// its answer and resource usage. The usage is read from the whole process,
+// which must not do anything else.
+func measurePart(ctx context.Context, solver aoc.Solver, part int, content []byte, timeout time.Duration) PartReport {
+	report := PartReport{Year: solver.Year, Day: solver.Day, Part: part}
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	peak := before.HeapAlloc
+	done := make(chan struct{})
+	sampled := make(chan uint64)
+	go func() {
+		ticker := time.NewTicker(heapSamplingPeriod)
+		defer ticker.Stop()
+		var stats runtime.MemStats
+		for {
+			select {
+			case <-ticker.C:
+				runtime.ReadMemStats(&stats)
+				peak = max(peak, stats.HeapAlloc)
+			case <-done:
+				sampled <- peak
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := partContext(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	answer, errSolving := solver.SolveContext(ctx, part, bytes.NewReader(content))
+	report.Duration = time.Since(start)
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	close(done)
+	report.PeakHeap = max(<-sampled, after.HeapAlloc)
+	report.Allocs = after.Mallocs - before.Mallocs
+
+	var partialErr *aoc.PartialError
+	if errors.As(errSolving, &partialErr) {
+		report.Partial = partialErr.Partial.String()
+	}
+	switch {
+	case errors.Is(errSolving, context.DeadlineExceeded):
+		report.TimedOut = true
+	case errSolving != nil:
+		report.Error = errSolving.Error()
+	default:
+		report.Answer = answer.String()
+	}
+	return report
+}
+
+// killDelay is the time given to a part to stop by itself once its timeout is
+// over, before its process is killed.
+const killDelay = 5 * time.Second
+
+// partJob is a part to solve in a child process.
+type partJob struct {
+	solver aoc.Solver
+	part   int
+}
+
+// runAllCommand solves every selected part concurrently, each one in its own
+// process so that its resources are measured alone and a part running past
+// the timeout can be killed without stopping the others.
+func runAllCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("run-all", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to solve, every implemented part if not set")
+	jobs := flags.Int("jobs", runtime.NumCPU(), "number of parts solved at the same time")
+	timeout := flags.Duration("timeout", 5*time.Minute, "time after which a part is stopped")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+	if *jobs < 1 {
+		return usageError{fmt.Errorf("invalid number of jobs %d", *jobs)}
+	}
+	if *timeout <= 0 {
+		return usageError{fmt.Errorf("invalid timeout %s", *timeout)}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+	executable, errExecutable := os.Executable()
+	if errExecutable != nil {
+		return errExecutable
+	}
+
+	var queue []partJob
+	for _, solver := range solvers {
+		parts := solver.Parts()
+		if *part != 0 {
+			parts = []int{*part}
+		}
+		for _, p := range parts {
+			queue = append(queue, partJob{solver: solver, part: p})
+		}
+	}
+
+	start := time.Now()
+	reports := make([]PartReport, len(queue))
+	indexes := make(chan int)
+	var workers sync.WaitGroup
+	for w := 0; w < min(*jobs, len(queue)); w++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range indexes {
+				reports[i] = runPartProcess(executable, root, queue[i], *timeout)
+			}
+		}()
+	}
+	for i := range queue {
+		indexes <- i
+	}
+	close(indexes)
+	workers.Wait()
+
+	var nbOk, nbFailures, nbTimeouts, nbSkipped int
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "Day\tPart\tAnswer\tTime\tPeak heap\tAllocs\tStatus\t")
+	for i, report := range reports {
+		status, heap, allocs := "ok", "-", "-"
+		switch {
+		case report.Skipped && queue[i].solver.Part(report.Part) == nil:
+			status = "not implemented, skipped"
+			nbSkipped++
+		case report.Skipped:
+			status = "no input, skipped"
+			nbSkipped++
+		case report.TimedOut && report.Partial != "":
+			status = "timed out, partial answer " + report.Partial
+			nbTimeouts++
+		case report.TimedOut:
+			status = "timed out"
+			nbTimeouts++
+		case report.Error != "":
+			status = report.Error
+			nbFailures++
+		default:
+			nbOk++
+		}
+		// The resources of a killed process are unknown.
+		if !report.Skipped && report.Allocs > 0 {
+			heap, allocs = formatBytes(report.PeakHeap), strconv.FormatUint(report.Allocs, 10)
+		}
+		fmt.Fprintf(table, "%d/%02d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", report.Year, report.Day, report.Part,
+			report.Answer, report.Duration.Round(time.Microsecond), heap, allocs, status)
+	}
+	if errFlushing := table.Flush(); errFlushing != nil {
+		return errFlushing
+	}
+
+	fmt.Fprintf(stdout, "%d ok, %d failure(s), %d timeout(s), %d skipped in %s\n",
+		nbOk, nbFailures, nbTimeouts, nbSkipped, time.Since(start).Round(time.Millisecond))
+	if nbFailures > 0 || nbTimeouts > 0 {
+		return fmt.Errorf("%d part(s) failed and %d timed out", nbFailures, nbTimeouts)
+	}
+	return nil
+}
+
+// runPartProcess solves a part with the run command of the executable. The
+// part is given the timeout to stop with its partial answer, and its process
+// is killed when it ignores it.
+func runPartProcess(executable, root string, job partJob, timeout time.Duration) PartReport {
+	report := PartReport{Year: job.solver.Year, Day: job.solver.Day, Part: job.part}
+	if job.solver.Part(job.part) == nil {
+		report.Skipped = true
+		return report
+	}
+	if _, errStat := os.Stat(dayInputPath(root, job.solver)); errors.Is(errStat, fs.ErrNotExist) {
+		report.Skipped = true
+		return report
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+killDelay)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, executable, "run", strconv.Itoa(job.solver.Year), strconv.Itoa(job.solver.Day),
+		"--part", strconv.Itoa(job.part), "--json", "--timeout", timeout.String())
+	cmd.Env = append(os.Environ(), "AOC_ROOT="+root)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	start := time.Now()
+	errRunning := cmd.Run()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		report.TimedOut = true
+		report.Duration = time.Since(start)
+		return report
+	}
+	// Solutions may print their own lines, the report is the JSON one.
+	scanner := bufio.NewScanner(&stdout)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var parsed PartReport
+		if bytes.HasPrefix(line, []byte("{")) && json.Unmarshal(line, &parsed) == nil {
+			return parsed
+		}
+	}
+	report.Error = "no report"
+	if errRunning != nil {
+		report.Error = errRunning.Error()
+	}
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		report.Error += ": " + message
+	}
+	return report
+}
+
+// formatBytes returns a size with a binary unit.
+func formatBytes(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exponent := float64(size)/unit, 0
+	for value >= unit && exponent < 3 {
+		value /= unit
+		exponent++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exponent])
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// TestMain lets the test binary stand for the aoc command, as run-all solves
+// the parts with child processes of its own executable.
+func TestMain(m *testing.M) {
+	if os.Getenv("AOC_TEST_AS_COMMAND") == "1" {
+		registerRunAllSolvers()
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func registerRunAllSolvers() {
+	aoc.Register(aoc.Solver{
+		Year: 1994,
+		Day:  1,
+		Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+			content, err := io.ReadAll(input)
+			return aoc.Int(len(content)), err
+		},
+		Part2: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+			time.Sleep(time.Minute)
+			return aoc.Int(0), nil
+		},
+	})
+	aoc.Register(aoc.Solver{Year: 1994, Day: 2, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Answer{}, errors.New("broken")
+	}})
+	aoc.Register(aoc.Solver{Year: 1994, Day: 3, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Int(0), nil
+	}})
+}
+
+func TestRunAllCommand(t *testing.T) {
+	registerRunAllSolvers()
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	t.Setenv("AOC_TEST_AS_COMMAND", "1")
+	for _, day := range []string{"day01", "day02"} {
+		if err := os.MkdirAll(filepath.Join(root, "1994", day), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "1994", day, "input.txt"), []byte("abcd"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout strings.Builder
+	start := time.Now()
+	err := runAllCommand([]string{"1994", "--jobs", "2", "--timeout", "2s"}, &stdout)
+	if err == nil || err.Error() != "1 part(s) failed and 1 timed out" {
+		t.Errorf("Expected a failure and a timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("Expected the slow part to be stopped, took %s", elapsed)
+	}
+	output := stdout.String()
+	for _, expected := range []string{"timed out", "broken", "no input, skipped", "1 ok, 1 failure(s), 1 timeout(s), 1 skipped"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in the output:\n%s", expected, output)
+		}
+	}
+	if !strings.Contains(output, "1994/01     1       4") {
+		t.Errorf("Expected the answer of 1994/01 part 1 in the output:\n%s", output)
+	}
+}
+
+func TestMeasurePart(t *testing.T) {
+	solver := aoc.Solver{Year: 1994, Day: 4, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		var chunks [][]byte
+		for i := 0; i < 100; i++ {
+			chunks = append(chunks, make([]byte, 1<<20))
+		}
+		return aoc.Int(len(chunks)), nil
+	}}
+	report := measurePart(context.Background(), solver, 1, nil, 0)
+	if report.Answer != "100" || report.Error != "" {
+		t.Errorf("Expected the answer 100, got %+v", report)
+	}
+	if report.Allocs < 100 || report.PeakHeap < 100<<20 {
+		t.Errorf("Expected at least 100 allocations and 100 MiB of heap, got %+v", report)
+	}
+
+	solver.Part1 = func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		<-ctx.Done()
+		return aoc.Answer{}, aoc.Canceled(ctx, aoc.Int(42))
+	}
+	report = measurePart(context.Background(), solver, 1, nil, 10*time.Millisecond)
+	if !report.TimedOut || report.Partial != "42" || report.Answer != "" || report.Error != "" {
+		t.Errorf("Expected a timeout with the partial answer 42, got %+v", report)
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// partialGrace is the time left to a timed out solution to return its
+// partial answer before the response is sent without it.
+const partialGrace = time.Second
+
+// serveCommand serves the solvers over HTTP until the server fails.
+func serveCommand(args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	timeout := flags.Duration("timeout", 30*time.Second, "time after which a part is stopped")
+	maxBody := flags.Int64("max-body", 1<<20, "size in bytes of the largest input accepted")
+	jobs := flags.Int("jobs", runtime.NumCPU(), "number of parts solved at once, the others waiting for their turn")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) > 0 {
+		return usageError{fmt.Errorf("unexpected argument %q", positional[0])}
+	}
+	if *timeout <= 0 {
+		return usageError{fmt.Errorf("invalid timeout %s", *timeout)}
+	}
+	if *maxBody < 1 {
+		return usageError{fmt.Errorf("invalid body size %d", *maxBody)}
+	}
+	if *jobs < 1 {
+		return usageError{fmt.Errorf("invalid jobs %d", *jobs)}
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newServer(*timeout, *maxBody, *jobs),
+		ReadHeaderTimeout: 10 * time.Second,
+		ErrorLog:          log.New(stderr, "", log.LstdFlags),
+	}
+	fmt.Fprintf(stderr, "serving %d solver(s) on http://%s\n", len(aoc.Solvers()), *addr)
+	return server.ListenAndServe()
+}
+
+// SolverInfo describes a solver listed by GET /solvers.
+type SolverInfo struct {
+	Year  int    `json:"year"`
+	Day   int    `json:"day"`
+	Title string `json:"title,omitempty"`
+	Parts []int  `json:"parts"`
+}
+
+// ParseErrorInfo is a malformed part of an input, as found by the input spec
+// of the day or by its solution.
+type ParseErrorInfo struct {
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Message  string `json:"message"`
+}
+
+// SolveResponse is the outcome of POST /solve/{year}/{day}/{part}.
+type SolveResponse struct {
+	Year   int    `json:"year"`
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Answer string `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// ParseErrors are the violations of the input spec of the day, or the
+	// parse error returned by the solution.
+	ParseErrors []ParseErrorInfo `json:"parse_errors,omitempty"`
+	// SpecDuration is the time spent checking the input against the spec of
+	// the day, 0 when it has none. The solutions parse the input themselves,
+	// within SolveDuration.
+	SpecDuration  time.Duration `json:"spec_duration_ns"`
+	SolveDuration time.Duration `json:"solve_duration_ns"`
+	// Partial is the best answer found by a part stopped before its end.
+	Partial  string `json:"partial,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+}
+
+// errorResponse is the body of the requests refused before solving.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// server solves the parts asked over HTTP, jobs at a time.
+type server struct {
+	timeout time.Duration
+	// grace is the time left to a timed out solution to return its partial
+	// answer.
+	grace   time.Duration
+	maxBody int64
+	jobs    chan struct{}
+}
+
+// newServer returns the handler of the HTTP API: GET /solvers lists the
+// solvers, and POST /solve/{year}/{day}/{part} solves a part of the input
+// sent as the body, the query values being the parameters of the solution.
+func newServer(timeout time.Duration, maxBody int64, jobs int) http.Handler {
+	s := &server{timeout: timeout, grace: partialGrace, maxBody: maxBody, jobs: make(chan struct{}, jobs)}
+	return s.mux()
+}
+
+func (s *server) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solvers", s.handleSolvers)
+	mux.HandleFunc("/solve/", s.handleSolve)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, errorResponse{Error: fmt.Sprintf(format, args...)})
+}
+
+func (s *server) handleSolvers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	infos := []SolverInfo{}
+	for _, solver := range aoc.Solvers() {
+		infos = append(infos, SolverInfo{Year: solver.Year, Day: solver.Day, Title: solver.Title, Parts: solver.Parts()})
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+// parseSolvePath returns the year, day and part of a /solve path.
+func parseSolvePath(path string) (year, day, part int, err error) {
+	segments := strings.Split(strings.TrimPrefix(path, "/solve/"), "/")
+	if len(segments) != 3 {
+		return 0, 0, 0, errors.New("expected /solve/{year}/{day}/{part}")
+	}
+	values := make([]int, 3)
+	for i, segment := range segments {
+		value, errParsing := strconv.Atoi(segment)
+		if errParsing != nil || value < 1 {
+			return 0, 0, 0, fmt.Errorf("invalid %s %q", []string{"year", "day", "part"}[i], segment)
+		}
+		values[i] = value
+	}
+	return values[0], values[1], values[2], nil
+}
+
+func parseErrorInfo(err *aoc.ParseError) ParseErrorInfo {
+	return ParseErrorInfo{Line: err.Line, Column: err.Column, Text: err.Text, Expected: err.Expected, Message: err.Error()}
+}
+
+func (s *server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	year, day, part, errPath := parseSolvePath(r.URL.Path)
+	if errPath != nil {
+		writeError(w, http.StatusNotFound, "%v", errPath)
+		return
+	}
+	solver, found := aoc.Lookup(year, day)
+	if !found || solver.Part(part) == nil {
+		writeError(w, http.StatusNotFound, "no solution of %d/%02d part %d", year, day, part)
+		return
+	}
+	content, errReading := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBody))
+	if errReading != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(errReading, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "input larger than %d bytes", s.maxBody)
+			return
+		}
+		writeError(w, http.StatusBadRequest, "unable to read the input: %v", errReading)
+		return
+	}
+	params := make(aoc.Params)
+	for name, values := range r.URL.Query() {
+		params[name] = values[len(values)-1]
+	}
+
+	response := SolveResponse{Year: year, Day: day, Part: part}
+	if solver.Input != nil {
+		start := time.Now()
+		violations, errChecking := aoc.CheckInput(solver.Input, bytes.NewReader(content))
+		response.SpecDuration = time.Since(start)
+		if errChecking != nil {
+			response.Error = errChecking.Error()
+			writeJSON(w, http.StatusUnprocessableEntity, response)
+			return
+		}
+		if len(violations) > 0 {
+			for _, violation := range violations {
+				response.ParseErrors = append(response.ParseErrors, parseErrorInfo(violation))
+			}
+			response.Error = invalidInputError(violations).Error()
+			writeJSON(w, http.StatusUnprocessableEntity, response)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(aoc.WithParams(r.Context(), params), s.timeout)
+	defer cancel()
+	status := s.solve(ctx, solver, part, content, &response)
+	writeJSON(w, status, response)
+}
+
+type solveResult struct {
+	answer   aoc.Answer
+	err      error
+	duration time.Duration
+}
+
+// solve fills the response with the answer of the part, once one of the jobs
+// is free, and returns the status of the response. A solution not checking
+// its context is left running past the timeout, the response being sent
+// without its answer while it keeps its job until it returns. A panicking
+// solution answers with an internal error.
+func (s *server) solve(ctx context.Context, solver aoc.Solver, part int, content []byte, response *SolveResponse) int {
+	select {
+	case s.jobs <- struct{}{}:
+	case <-ctx.Done():
+		response.TimedOut = true
+		response.Error = "no job free before the timeout"
+		return http.StatusServiceUnavailable
+	}
+	results := make(chan solveResult, 1)
+	start := time.Now()
+	go func() {
+		defer func() { <-s.jobs }()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				results <- solveResult{err: fmt.Errorf("solution panicked: %v", recovered), duration: time.Since(start)}
+			}
+		}()
+		answer, errSolving := solver.SolveContext(ctx, part, bytes.NewReader(content))
+		results <- solveResult{answer, errSolving, time.Since(start)}
+	}()
+
+	var result solveResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		select {
+		case result = <-results:
+		case <-time.After(s.grace):
+			response.TimedOut = true
+			response.SolveDuration = time.Since(start)
+			response.Error = ctx.Err().Error()
+			return http.StatusGatewayTimeout
+		}
+	}
+
+	response.SolveDuration = result.duration
+	var partialErr *aoc.PartialError
+	if errors.As(result.err, &partialErr) {
+		response.Partial = partialErr.Partial.String()
+	}
+	var parseErr *aoc.ParseError
+	switch {
+	case result.err == nil:
+		response.Answer = result.answer.String()
+		return http.StatusOK
+	case errors.Is(result.err, context.DeadlineExceeded):
+		response.TimedOut = true
+		response.Error = result.err.Error()
+		return http.StatusGatewayTimeout
+	case errors.As(result.err, &parseErr):
+		response.ParseErrors = []ParseErrorInfo{parseErrorInfo(parseErr)}
+		response.Error = result.err.Error()
+		return http.StatusUnprocessableEntity
+	}
+	response.Error = result.err.Error()
+	return http.StatusInternalServerError
+}
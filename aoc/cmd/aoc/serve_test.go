@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestServe(t *testing.T) {
+	sum := func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		scanner := aoc.NewScanner(input)
+		offset, errParam := aoc.ParamInt(ctx, "offset", 0)
+		if errParam != nil {
+			return aoc.Answer{}, errParam
+		}
+		total := offset
+		for scanner.Scan() {
+			value, errParsing := aoc.Atoi(scanner.Text())
+			if errParsing != nil {
+				return aoc.Answer{}, scanner.Wrap(errParsing)
+			}
+			total += value
+		}
+		return aoc.Int(total), scanner.Err()
+	}
+	aoc.Register(aoc.Solver{Year: 1987, Day: 1, Title: "Sum", Part1: sum, Input: aoc.LinesMatching(`\d+`, "an integer")})
+	aoc.Register(aoc.Solver{Year: 1987, Day: 2, Part2: sum})
+	aoc.Register(aoc.Solver{Year: 1987, Day: 3, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		<-ctx.Done()
+		return aoc.Answer{}, aoc.Canceled(ctx, aoc.Int(7))
+	}})
+	stuck := make(chan struct{})
+	defer close(stuck)
+	aoc.Register(aoc.Solver{Year: 1987, Day: 4, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		<-stuck
+		return aoc.Int(0), nil
+	}})
+
+	aoc.Register(aoc.Solver{Year: 1987, Day: 5, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		return aoc.Answer{}, errors.New("no answer")
+	}})
+	aoc.Register(aoc.Solver{Year: 1987, Day: 6, Part1: func(ctx context.Context, input io.Reader) (aoc.Answer, error) {
+		var values []int
+		return aoc.Int(values[1]), nil
+	}})
+
+	s := &server{timeout: 50 * time.Millisecond, grace: 10 * time.Millisecond, maxBody: 16, jobs: make(chan struct{}, 2)}
+	httpServer := httptest.NewServer(s.mux())
+	defer httpServer.Close()
+
+	post := func(path, body string) (int, SolveResponse) {
+		t.Helper()
+		resp, err := http.Post(httpServer.URL+path, "text/plain", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		var response SolveResponse
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return resp.StatusCode, response
+	}
+
+	status, response := post("/solve/1987/1/1?offset=10", "1\n2\n3\n")
+	if status != http.StatusOK || response.Answer != "16" || response.Year != 1987 || response.Part != 1 || response.Error != "" {
+		t.Errorf("Unexpected response %d %+v", status, response)
+	}
+
+	status, response = post("/solve/1987/1/1", "1\ntwo\n3\nfour\n")
+	if status != http.StatusUnprocessableEntity || len(response.ParseErrors) != 2 || response.Answer != "" {
+		t.Fatalf("Expected the violations of the input spec, got %d %+v", status, response)
+	}
+	if info := response.ParseErrors[1]; info.Line != 4 || info.Column != 1 || info.Text != "four" || info.Expected != "an integer" {
+		t.Errorf("Unexpected parse error %+v", info)
+	}
+
+	// Without an input spec, the parse error comes from the solution.
+	status, response = post("/solve/1987/2/2", "1\nx\n")
+	if status != http.StatusUnprocessableEntity || len(response.ParseErrors) != 1 || response.ParseErrors[0].Line != 2 {
+		t.Errorf("Expected the parse error of the solution, got %d %+v", status, response)
+	}
+
+	status, response = post("/solve/1987/3/1", "")
+	if status != http.StatusGatewayTimeout || !response.TimedOut || response.Partial != "7" {
+		t.Errorf("Expected a timeout with the partial answer, got %d %+v", status, response)
+	}
+	status, response = post("/solve/1987/6/1", "")
+	if status != http.StatusInternalServerError || !strings.Contains(response.Error, "panicked") || response.Answer != "" {
+		t.Errorf("Expected the panic to be reported as an error, got %d %+v", status, response)
+	}
+
+	for path, expected := range map[string]int{
+		"/solve/1987/5/1":   http.StatusInternalServerError,
+		"/solve/1987/2/1":   http.StatusNotFound,
+		"/solve/1987/7/1":   http.StatusNotFound,
+		"/solve/1987/one/1": http.StatusNotFound,
+		"/solve/1987/1":     http.StatusNotFound,
+	} {
+		if status, _ := post(path, "1\n"); status != expected {
+			t.Errorf("Expected %s to answer %d, got %d", path, expected, status)
+		}
+	}
+	if status, _ := post("/solve/1987/1/1", strings.Repeat("1\n", 9)); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected a too large input to be refused, got %d", status)
+	}
+
+	status, response = post("/solve/1987/4/1", "")
+	if status != http.StatusGatewayTimeout || !response.TimedOut || response.SolveDuration < s.timeout {
+		t.Errorf("Expected a solution not checking its context to time out, got %d %+v", status, response)
+	}
+	// The stuck solutions keep their jobs until they return.
+	post("/solve/1987/4/1", "")
+	if status, response := post("/solve/1987/1/1", "1\n"); status != http.StatusServiceUnavailable || !response.TimedOut {
+		t.Errorf("Expected no job to be free while the solutions are stuck, got %d %+v", status, response)
+	}
+
+	resp, err := http.Get(httpServer.URL + "/solve/1987/1/1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET to be refused, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(httpServer.URL + "/solvers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	var infos []SolverInfo
+	if err := json.NewDecoder(resp.Body).Decode(&infos); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	found := false
+	for _, info := range infos {
+		if info.Year == 1987 && info.Day == 1 {
+			found = info.Title == "Sum" && len(info.Parts) == 1 && info.Parts[0] == 1
+		}
+	}
+	if !found {
+		t.Errorf("Expected 1987/01 among the solvers, got %+v", infos)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/client"
+)
+
+// submitCommand solves a puzzle part and submits its answer, unless the
+// guess history of the day already rules it out. Checked answers are added to
+// the history and the correct ones to the answers file of the year.
+func submitCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	answerFlag := flags.String("answer", "", "answer to submit instead of solving the part")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	if len(positional) != 3 {
+		return usageError{errors.New("expected <year> <day> <part>")}
+	}
+	selection, errSelection := parseSelection(positional[:2])
+	if errSelection != nil {
+		return errSelection
+	}
+	part, errPart := strconv.Atoi(positional[2])
+	if errPart != nil || (part != 1 && part != 2) {
+		return usageError{fmt.Errorf("invalid part %q", positional[2])}
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+	session, errSession := client.LoadSession()
+	if errSession != nil {
+		return errSession
+	}
+	c := newClient(session)
+	ctx := context.Background()
+
+	year, day := selection.Year, selection.Day
+	historyPath := filepath.Join(dayDir(root, year, day), client.HistoryFile)
+	history, errHistory := loadHistory(historyPath)
+	if errHistory != nil {
+		return errHistory
+	}
+
+	answer := *answerFlag
+	if answer == "" {
+		solver, registered := aoc.Lookup(year, day)
+		if !registered {
+			return fmt.Errorf("no solver registered for %d/%02d", year, day)
+		}
+		input, errInput := c.Input(ctx, dayInputPath(root, solver), year, day)
+		if errInput != nil {
+			return errInput
+		}
+		solution, errSolving := solver.Solve(part, bytes.NewReader(input))
+		if errSolving != nil {
+			return fmt.Errorf("%s part %d: %w", solver, part, errSolving)
+		}
+		answer = solution.String()
+	}
+	if errChecking := history.Check(part, answer); errChecking != nil {
+		return errChecking
+	}
+
+	fmt.Fprintf(stdout, "%d/%02d part %d: submitting %s\n", year, day, part, answer)
+	result, errSubmitting := c.Submit(ctx, year, day, part, answer)
+	if errSubmitting != nil {
+		return errSubmitting
+	}
+	fmt.Fprintln(stdout, result.Message)
+
+	if result.Verdict.Checked() {
+		history = append(history, client.Guess{Part: part, Answer: answer, Verdict: result.Verdict, Time: time.Now()})
+		if errSaving := saveTo(historyPath, history); errSaving != nil {
+			return errSaving
+		}
+	}
+	switch result.Verdict {
+	case client.VerdictCorrect:
+		return recordAnswer(root, year, day, part, answer)
+	case client.VerdictTooRecent:
+		return fmt.Errorf("answer not checked, retry in %s", result.Wait)
+	case client.VerdictUnknown:
+		return errors.New("unable to understand the response of the website")
+	}
+	return fmt.Errorf("answer %s is %s", answer, result.Verdict)
+}
+
+// loadHistory reads the guess history of a day, a missing file meaning no
+// answer has been submitted yet.
+func loadHistory(path string) (client.History, error) {
+	file, errOpening := os.Open(path)
+	if errors.Is(errOpening, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if errOpening != nil {
+		return nil, errOpening
+	}
+	defer file.Close()
+	history, errReading := client.ReadHistory(file)
+	if errReading != nil {
+		return nil, fmt.Errorf("%s: %w", path, errReading)
+	}
+	return history, nil
+}
+
+// recordAnswer adds a correct answer to the answers file of its year.
+func recordAnswer(root string, year, day, part int, answer string) error {
+	answers, errLoading := loadAnswers(root, year)
+	if errLoading != nil {
+		return errLoading
+	}
+	answers[aoc.PartKey{Day: day, Part: part}] = aoc.String(answer)
+	return saveTo(filepath.Join(root, strconv.Itoa(year), aoc.AnswersFile), answers)
+}
+
+func saveTo(path string, content io.WriterTo) error {
+	var buffer bytes.Buffer
+	if _, errWriting := content.WriteTo(&buffer); errWriting != nil {
+		return errWriting
+	}
+	return os.WriteFile(path, buffer.Bytes(), 0o644)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+	"github.com/antitoine/advent-of-code/aoc/client"
+)
+
+func TestSubmitCommand(t *testing.T) {
+	aoc.Register(aoc.Solver{Year: 1996, Day: 3, Part1: func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		content, err := io.ReadAll(input)
+		return aoc.Int(len(content)), err
+	}})
+
+	var submitted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer := r.FormValue("answer")
+		submitted = append(submitted, answer)
+		switch answer {
+		case "42":
+			w.Write([]byte("<article><p>That's the right answer!</p></article>"))
+		default:
+			w.Write([]byte("<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>"))
+		}
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	t.Setenv("AOC_BASE_URL", server.URL)
+	t.Setenv("AOC_SESSION", "secret")
+	if err := os.MkdirAll(filepath.Join(root, "1996", "day03"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "1996", "day03", "input.txt"), []byte(strings.Repeat("x", 50)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := submitCommand([]string{"1996", "3", "1"}, io.Discard); err == nil || !strings.Contains(err.Error(), "too-high") {
+		t.Errorf("Expected the solved answer to be too high, got %v", err)
+	}
+	for _, answer := range []string{"50", "60"} {
+		if err := submitCommand([]string{"1996", "3", "1", "--answer", answer}, io.Discard); !errors.Is(err, client.ErrRuledOut) {
+			t.Errorf("Expected %s to be ruled out, got %v", answer, err)
+		}
+	}
+	if err := submitCommand([]string{"1996", "3", "1", "--answer", "42"}, io.Discard); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if strings.Join(submitted, " ") != "50 42" {
+		t.Errorf("Expected ruled out answers not to be submitted, got %v", submitted)
+	}
+
+	history, err := loadHistory(filepath.Join(root, "1996", "day03", client.HistoryFile))
+	if err != nil || len(history) != 2 || history[1].Verdict != client.VerdictCorrect {
+		t.Errorf("Expected both checked answers in the history, got %+v (%v)", history, err)
+	}
+	answers, err := loadAnswers(root, 1996)
+	if err != nil || answers[aoc.PartKey{Day: 3, Part: 1}].String() != "42" {
+		t.Errorf("Expected the correct answer to be recorded, got %v (%v)", answers, err)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// verifyCommand runs every selected solver against its real input and
+// compares the answers with the ones recorded in the answers file of its year.
+// Parts without a recorded answer or days without an input are skipped.
+func verifyCommand(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	part := flags.Int("part", 0, "part to verify, every implemented part if not set")
+	positional, errArgs := parseArgs(flags, args)
+	if errArgs != nil {
+		return errArgs
+	}
+	selection, errSelection := parseSelection(positional)
+	if errSelection != nil {
+		return errSelection
+	}
+	if *part != 0 && *part != 1 && *part != 2 {
+		return usageError{fmt.Errorf("invalid part %d", *part)}
+	}
+
+	solvers, errSolvers := selection.Solvers()
+	if errSolvers != nil {
+		return errSolvers
+	}
+	root, errRoot := findRoot()
+	if errRoot != nil {
+		return errRoot
+	}
+
+	answersByYear := make(map[int]aoc.Answers)
+	var nbOk, nbMismatches, nbFailures, nbSkipped int
+	for _, solver := range solvers {
+		answers, loaded := answersByYear[solver.Year]
+		if !loaded {
+			var errLoading error
+			if answers, errLoading = loadAnswers(root, solver.Year); errLoading != nil {
+				return errLoading
+			}
+			answersByYear[solver.Year] = answers
+		}
+
+		parts := solver.Parts()
+		if *part != 0 {
+			parts = []int{*part}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+
+		content, errReading := os.ReadFile(dayInputPath(root, solver))
+		if errors.Is(errReading, fs.ErrNotExist) {
+			fmt.Fprintf(stdout, "%s: no input, skipped\n", solver)
+			nbSkipped += len(parts)
+			continue
+		}
+		if errReading != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", solver, errReading)
+			nbFailures += len(parts)
+			continue
+		}
+
+		for _, p := range parts {
+			expected, recorded := answers[aoc.PartKey{Day: solver.Day, Part: p}]
+			if !recorded {
+				fmt.Fprintf(stdout, "%s part %d: no recorded answer, skipped\n", solver, p)
+				nbSkipped++
+				continue
+			}
+			start := time.Now()
+			answer, errSolving := solver.Solve(p, bytes.NewReader(content))
+			switch {
+			case errSolving != nil:
+				fmt.Fprintf(stdout, "%s part %d: %v\n", solver, p, errSolving)
+				nbFailures++
+			case !answer.Equal(expected):
+				fmt.Fprintf(stdout, "%s part %d: MISMATCH got %s, expected %s\n", solver, p, answer, expected)
+				nbMismatches++
+			default:
+				fmt.Fprintf(stdout, "%s part %d: ok (%s)\n", solver, p, time.Since(start))
+				nbOk++
+			}
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d ok, %d mismatch(es), %d failure(s), %d skipped\n", nbOk, nbMismatches, nbFailures, nbSkipped)
+	if nbMismatches > 0 || nbFailures > 0 {
+		return fmt.Errorf("%d part(s) not verified", nbMismatches+nbFailures)
+	}
+	return nil
+}
+
+// loadAnswers reads the answers file of a year, a missing file meaning no
+// answer has been recorded yet.
+func loadAnswers(root string, year int) (aoc.Answers, error) {
+	path := filepath.Join(root, strconv.Itoa(year), aoc.AnswersFile)
+	file, errOpening := os.Open(path)
+	if errors.Is(errOpening, fs.ErrNotExist) {
+		return aoc.Answers{}, nil
+	}
+	if errOpening != nil {
+		return nil, errOpening
+	}
+	defer file.Close()
+	answers, errReading := aoc.ReadAnswers(file)
+	if errReading != nil {
+		return nil, fmt.Errorf("%s: %w", path, errReading)
+	}
+	return answers, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	length := func(_ context.Context, input io.Reader) (aoc.Answer, error) {
+		content, err := io.ReadAll(input)
+		return aoc.Int(len(content)), err
+	}
+	aoc.Register(aoc.Solver{Year: 1998, Day: 1, Part1: length, Part2: length})
+	aoc.Register(aoc.Solver{Year: 1998, Day: 2, Part2: length})
+
+	root := t.TempDir()
+	t.Setenv("AOC_ROOT", root)
+	writeFile := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("1998/day01/input.txt", "abcd")
+
+	var stdout strings.Builder
+	if err := verifyCommand([]string{"1998"}, &stdout); err != nil {
+		t.Fatalf("Expected parts without answers to be skipped, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "0 ok, 0 mismatch(es), 0 failure(s), 3 skipped") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	writeFile("1998/answers.txt", "01 1 4\n01 2 5\n")
+	stdout.Reset()
+	if err := verifyCommand([]string{"1998", "1"}, &stdout); err == nil {
+		t.Errorf("Expected a mismatch to fail the verification")
+	}
+	if !strings.Contains(stdout.String(), "1998/01 part 2: MISMATCH got 4, expected 5") {
+		t.Errorf("Expected the mismatch to be reported, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := verifyCommand([]string{"1998", "1", "--part", "1"}, &stdout); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "1 ok, 0 mismatch(es), 0 failure(s), 0 skipped") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	writeFile("1998/answers.txt", "01 1\n")
+	if err := verifyCommand([]string{"1998"}, io.Discard); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected a located error for a malformed answers file, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package cycle detects when a deterministic simulation starts repeating
+// itself, to jump to any of its steps without simulating them all.
+package cycle
+
+import "fmt"
+
+// Cycle describes the states of a simulation, which repeat every Period
+// steps once the first Tail steps are done.
+type Cycle[S any] struct {
+	// Start is the state before the first step.
+	Start S
+	// Entry is the first state of the cycle, reached after Tail steps.
+	Entry  S
+	Tail   int
+	Period int
+
+	step func(S) S
+	// states are the states before the cycle and in the cycle, when they
+	// were recorded during the detection.
+	states []S
+}
+
+// Brent finds the cycle of the states produced by step from start, comparing
+// the states with equal. It only keeps two states in memory, but simulates
+// up to three times the steps before the end of the first cycle. The step
+// function must not modify the state it is given.
+func Brent[S any](start S, step func(S) S, equal func(a, b S) bool) Cycle[S] {
+	// Find the period, moving the tortoise to the hare at each power of two.
+	power, period := 1, 1
+	tortoise, hare := start, step(start)
+	for !equal(tortoise, hare) {
+		if power == period {
+			tortoise = hare
+			power *= 2
+			period = 0
+		}
+		hare = step(hare)
+		period++
+	}
+
+	// With the hare one period ahead, both meet at the start of the cycle.
+	tortoise, hare = start, start
+	for i := 0; i < period; i++ {
+		hare = step(hare)
+	}
+	tail := 0
+	for !equal(tortoise, hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		tail++
+	}
+
+	return Cycle[S]{Start: start, Entry: tortoise, Tail: tail, Period: period, step: step}
+}
+
+// Find finds the cycle of the states produced by step from start, recording
+// the key of every state until one is repeated. It simulates each step only
+// once and keeps all the states, so that At never simulates again. The step
+// function must not modify the state it is given.
+func Find[S any, K comparable](start S, step func(S) S, key func(S) K) Cycle[S] {
+	seen := make(map[K]int)
+	var states []S
+	for state := start; ; state = step(state) {
+		k := key(state)
+		if i, found := seen[k]; found {
+			return Cycle[S]{Start: start, Entry: states[i], Tail: i, Period: len(states) - i, step: step, states: states}
+		}
+		seen[k] = len(states)
+		states = append(states, state)
+	}
+}
+
+// Index returns the step before the end of the first cycle reaching the same
+// state as step n.
+func (c Cycle[S]) Index(n int) int {
+	if n < 0 {
+		panic(fmt.Sprintf("cycle: negative step %d", n))
+	}
+	if n < c.Tail {
+		return n
+	}
+	return c.Tail + (n-c.Tail)%c.Period
+}
+
+// At returns the state after n steps.
+func (c Cycle[S]) At(n int) S {
+	n = c.Index(n)
+	if c.states != nil {
+		return c.states[n]
+	}
+	state, steps := c.Start, n
+	if n >= c.Tail {
+		state, steps = c.Entry, n-c.Tail
+	}
+	for i := 0; i < steps; i++ {
+		state = c.step(state)
+	}
+	return state
+}
+
+// Advance returns the state after n steps from start, detecting the cycle
+// with Find.
+func Advance[S any, K comparable](start S, step func(S) S, key func(S) K, n int) S {
+	return Find(start, step, key).At(n)
+}
@@ -0,0 +1,56 @@
+package cycle
+
+import (
+	"testing"
+)
+
+// step is the pseudo random sequence x -> x*x+1 modulo 255, which starting
+// from 3 enters a cycle of 6 values after 2 steps.
+func step(x int) int {
+	return (x*x + 1) % 255
+}
+
+func identity(x int) int {
+	return x
+}
+
+func equal(a, b int) bool {
+	return a == b
+}
+
+func naive(start, n int) int {
+	for i := 0; i < n; i++ {
+		start = step(start)
+	}
+	return start
+}
+
+func TestFind(t *testing.T) {
+	for name, c := range map[string]Cycle[int]{
+		"brent": Brent(3, step, equal),
+		"find":  Find(3, step, identity),
+	} {
+		if c.Tail != 2 || c.Period != 6 || c.Entry != naive(3, 2) {
+			t.Errorf("Expected %s to find a tail of 2 and a period of 6 from %d, got %d and %d from %d", name, naive(3, 2), c.Tail, c.Period, c.Entry)
+		}
+		for n := 0; n < 50; n++ {
+			if expected, got := naive(3, n), c.At(n); expected != got {
+				t.Errorf("Expected %s to reach %d after %d steps, got %d", name, expected, n, got)
+			}
+		}
+	}
+}
+
+func TestFixedPoint(t *testing.T) {
+	c := Brent(7, identity, equal)
+	if c.Tail != 0 || c.Period != 1 || c.At(1000) != 7 {
+		t.Errorf("Expected a fixed point, got a tail of %d and a period of %d", c.Tail, c.Period)
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	const n = 1_000_000_000_000
+	if expected, got := naive(3, 2+(n-2)%6), Advance(3, step, identity, n); expected != got {
+		t.Errorf("Expected %d after %d steps, got %d", expected, n, got)
+	}
+}
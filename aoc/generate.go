@@ -0,0 +1,34 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// ErrNoGenerator is returned when generating the input of a solver which
+// has no generator.
+var ErrNoGenerator = errors.New("no input generator")
+
+// GenerateFunc writes a random valid input of the puzzle, drawing from rng
+// only so that a seed always gives the same input. The size tunes how large
+// the input is, its meaning depends on the puzzle.
+type GenerateFunc func(rng *rand.Rand, size int, w io.Writer) error
+
+// GenerateInput returns the input generated by the solver for the seed and
+// the size.
+func (s Solver) GenerateInput(seed int64, size int) ([]byte, error) {
+	if s.Generate == nil {
+		return nil, ErrNoGenerator
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+	var input bytes.Buffer
+	if errGenerating := s.Generate(rand.New(rand.NewSource(seed)), size, &input); errGenerating != nil {
+		return nil, errGenerating
+	}
+	return input.Bytes(), nil
+}
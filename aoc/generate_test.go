@@ -0,0 +1,40 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateInput(t *testing.T) {
+	solver := Solver{Year: 1999, Day: 3, Generate: func(rng *rand.Rand, size int, w io.Writer) error {
+		for i := 0; i < size; i++ {
+			fmt.Fprintln(w, rng.Intn(1000))
+		}
+		return nil
+	}}
+	first, err := solver.GenerateInput(42, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := solver.GenerateInput(42, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(first, second) || bytes.Count(first, []byte("\n")) != 5 {
+		t.Errorf("Expected the same 5 lines for the same seed, got %q and %q", first, second)
+	}
+	if other, _ := solver.GenerateInput(43, 5); bytes.Equal(first, other) {
+		t.Errorf("Expected another seed to give another input, got %q", other)
+	}
+
+	if _, err := solver.GenerateInput(42, 0); err == nil {
+		t.Errorf("Expected an invalid size to be refused")
+	}
+	if _, err := (Solver{Year: 1999, Day: 4}).GenerateInput(42, 5); !errors.Is(err, ErrNoGenerator) {
+		t.Errorf("Expected ErrNoGenerator, got %v", err)
+	}
+}
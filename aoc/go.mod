@@ -0,0 +1,135 @@
+module github.com/antitoine/advent-of-code/aoc
+
+go 1.25.4
+
+require (
+	github.com/antitoine/advent-of-code/2023/day01 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day02 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day03 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day04 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day05 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day06 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day07 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day08 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day09 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day10 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day11 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day12 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day13 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day14 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day15 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day16 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day17 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day18 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day19 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day20 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day21 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day22 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day23 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day24 v0.0.0
+	github.com/antitoine/advent-of-code/2023/day25 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day01 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day02 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day03 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day04 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day05 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day06 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day07 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day08 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day09 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day10 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day11 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day12 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day13 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day14 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day15 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day16 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day17 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day18 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day19 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day20 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day21 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day22 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day23 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day24 v0.0.0
+	github.com/antitoine/advent-of-code/2024/day25 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day01 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day02 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day03 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day04 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day05 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day06 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day07 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day08 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day09 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day10 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day11 v0.0.0
+	github.com/antitoine/advent-of-code/2025/day12 v0.0.0
+)
+
+require gonum.org/v1/gonum v0.14.0 // indirect
+
+replace (
+	github.com/antitoine/advent-of-code/2023/day01 => ../2023/day01
+	github.com/antitoine/advent-of-code/2023/day02 => ../2023/day02
+	github.com/antitoine/advent-of-code/2023/day03 => ../2023/day03
+	github.com/antitoine/advent-of-code/2023/day04 => ../2023/day04
+	github.com/antitoine/advent-of-code/2023/day05 => ../2023/day05
+	github.com/antitoine/advent-of-code/2023/day06 => ../2023/day06
+	github.com/antitoine/advent-of-code/2023/day07 => ../2023/day07
+	github.com/antitoine/advent-of-code/2023/day08 => ../2023/day08
+	github.com/antitoine/advent-of-code/2023/day09 => ../2023/day09
+	github.com/antitoine/advent-of-code/2023/day10 => ../2023/day10
+	github.com/antitoine/advent-of-code/2023/day11 => ../2023/day11
+	github.com/antitoine/advent-of-code/2023/day12 => ../2023/day12
+	github.com/antitoine/advent-of-code/2023/day13 => ../2023/day13
+	github.com/antitoine/advent-of-code/2023/day14 => ../2023/day14
+	github.com/antitoine/advent-of-code/2023/day15 => ../2023/day15
+	github.com/antitoine/advent-of-code/2023/day16 => ../2023/day16
+	github.com/antitoine/advent-of-code/2023/day17 => ../2023/day17
+	github.com/antitoine/advent-of-code/2023/day18 => ../2023/day18
+	github.com/antitoine/advent-of-code/2023/day19 => ../2023/day19
+	github.com/antitoine/advent-of-code/2023/day20 => ../2023/day20
+	github.com/antitoine/advent-of-code/2023/day21 => ../2023/day21
+	github.com/antitoine/advent-of-code/2023/day22 => ../2023/day22
+	github.com/antitoine/advent-of-code/2023/day23 => ../2023/day23
+	github.com/antitoine/advent-of-code/2023/day24 => ../2023/day24
+	github.com/antitoine/advent-of-code/2023/day25 => ../2023/day25
+	github.com/antitoine/advent-of-code/2024/day01 => ../2024/day01
+	github.com/antitoine/advent-of-code/2024/day02 => ../2024/day02
+	github.com/antitoine/advent-of-code/2024/day03 => ../2024/day03
+	github.com/antitoine/advent-of-code/2024/day04 => ../2024/day04
+	github.com/antitoine/advent-of-code/2024/day05 => ../2024/day05
+	github.com/antitoine/advent-of-code/2024/day06 => ../2024/day06
+	github.com/antitoine/advent-of-code/2024/day07 => ../2024/day07
+	github.com/antitoine/advent-of-code/2024/day08 => ../2024/day08
+	github.com/antitoine/advent-of-code/2024/day09 => ../2024/day09
+	github.com/antitoine/advent-of-code/2024/day10 => ../2024/day10
+	github.com/antitoine/advent-of-code/2024/day11 => ../2024/day11
+	github.com/antitoine/advent-of-code/2024/day12 => ../2024/day12
+	github.com/antitoine/advent-of-code/2024/day13 => ../2024/day13
+	github.com/antitoine/advent-of-code/2024/day14 => ../2024/day14
+	github.com/antitoine/advent-of-code/2024/day15 => ../2024/day15
+	github.com/antitoine/advent-of-code/2024/day16 => ../2024/day16
+	github.com/antitoine/advent-of-code/2024/day17 => ../2024/day17
+	github.com/antitoine/advent-of-code/2024/day18 => ../2024/day18
+	github.com/antitoine/advent-of-code/2024/day19 => ../2024/day19
+	github.com/antitoine/advent-of-code/2024/day20 => ../2024/day20
+	github.com/antitoine/advent-of-code/2024/day21 => ../2024/day21
+	github.com/antitoine/advent-of-code/2024/day22 => ../2024/day22
+	github.com/antitoine/advent-of-code/2024/day23 => ../2024/day23
+	github.com/antitoine/advent-of-code/2024/day24 => ../2024/day24
+	github.com/antitoine/advent-of-code/2024/day25 => ../2024/day25
+	github.com/antitoine/advent-of-code/2025/day01 => ../2025/day01
+	github.com/antitoine/advent-of-code/2025/day02 => ../2025/day02
+	github.com/antitoine/advent-of-code/2025/day03 => ../2025/day03
+	github.com/antitoine/advent-of-code/2025/day04 => ../2025/day04
+	github.com/antitoine/advent-of-code/2025/day05 => ../2025/day05
+	github.com/antitoine/advent-of-code/2025/day06 => ../2025/day06
+	github.com/antitoine/advent-of-code/2025/day07 => ../2025/day07
+	github.com/antitoine/advent-of-code/2025/day08 => ../2025/day08
+	github.com/antitoine/advent-of-code/2025/day09 => ../2025/day09
+	github.com/antitoine/advent-of-code/2025/day10 => ../2025/day10
+	github.com/antitoine/advent-of-code/2025/day11 => ../2025/day11
+	github.com/antitoine/advent-of-code/2025/day12 => ../2025/day12
+)
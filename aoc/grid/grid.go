@@ -0,0 +1,331 @@
+// Package grid provides a generic two dimensional grid, as found in most
+// puzzles, addressed by image.Point where X is the column and Y the row.
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+var (
+	// Directions4 are the offsets to the orthogonal neighbours, clockwise
+	// starting from the north.
+	Directions4 = []image.Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+	// Directions8 are the offsets to the orthogonal and diagonal neighbours,
+	// clockwise starting from the north.
+	Directions8 = []image.Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+)
+
+// Grid is a rectangle of cells. Sub-grids returned by Sub share the cells of
+// their parent, any other transformation returns a copy.
+type Grid[T any] struct {
+	cells  []T
+	offset int
+	stride int
+	width  int
+	height int
+}
+
+// New returns a grid of the given size filled with zero values.
+func New[T any](width, height int) Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("grid: invalid size %dx%d", width, height))
+	}
+	return Grid[T]{cells: make([]T, width*height), stride: width, width: width, height: height}
+}
+
+// FromRows returns a grid holding a copy of the given rows, which must all
+// have the same length.
+func FromRows[T any](rows [][]T) (Grid[T], error) {
+	if len(rows) == 0 {
+		return Grid[T]{}, nil
+	}
+	g := New[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.width {
+			return Grid[T]{}, fmt.Errorf("grid: row %d has %d cells, expected %d", y, len(row), g.width)
+		}
+		copy(g.Row(y), row)
+	}
+	return g, nil
+}
+
+// Mapper converts a character of the input at the given position to a cell.
+// A returned error is reported as a ParseError located at this character.
+type Mapper[T any] func(p image.Point, char rune) (T, error)
+
+// Parse reads a grid of one cell per character from the input, until its end
+// or an empty line. Every line must have the same number of characters.
+func Parse[T any](input io.Reader, mapper Mapper[T]) (Grid[T], error) {
+	scanner := aoc.NewScanner(input)
+	var g Grid[T]
+	for scanner.Scan() {
+		line := []rune(scanner.Text())
+		if len(line) == 0 {
+			break
+		}
+		if g.height == 0 {
+			g.width, g.stride = len(line), len(line)
+		} else if len(line) != g.width {
+			return Grid[T]{}, scanner.Unexpected(fmt.Sprintf("a line of %d characters", g.width))
+		}
+		for x, char := range line {
+			cell, errMapping := mapper(image.Pt(x, g.height), char)
+			if errMapping != nil {
+				return Grid[T]{}, located(scanner.Line(), x+1, string(char), errMapping)
+			}
+			g.cells = append(g.cells, cell)
+		}
+		g.height++
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return Grid[T]{}, errScanningFile
+	}
+	return g, nil
+}
+
+func located(line, column int, text string, err error) error {
+	var parseErr *aoc.ParseError
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.Line, located.Column = line, column
+		if located.Text == "" {
+			located.Text = text
+		}
+		return &located
+	}
+	return &aoc.ParseError{Line: line, Column: column, Text: text, Err: err}
+}
+
+// Runes is a Mapper keeping the characters as they are.
+func Runes(_ image.Point, char rune) (rune, error) {
+	return char, nil
+}
+
+// Digits is a Mapper reading one decimal digit per cell.
+func Digits(_ image.Point, char rune) (int, error) {
+	if char < '0' || char > '9' {
+		return 0, aoc.Unexpected(string(char), "a digit")
+	}
+	return int(char - '0'), nil
+}
+
+// OneOf returns a Mapper keeping the characters as they are, accepting only
+// the given ones.
+func OneOf(chars string) Mapper[rune] {
+	expected := make([]string, 0, len(chars))
+	for _, char := range chars {
+		expected = append(expected, fmt.Sprintf("%q", char))
+	}
+	return func(_ image.Point, char rune) (rune, error) {
+		if !strings.ContainsRune(chars, char) {
+			return 0, aoc.Unexpected(string(char), "one of "+strings.Join(expected, ", "))
+		}
+		return char, nil
+	}
+}
+
+func (g Grid[T]) Width() int {
+	return g.width
+}
+
+func (g Grid[T]) Height() int {
+	return g.height
+}
+
+// Bounds returns the rectangle of the points inside the grid.
+func (g Grid[T]) Bounds() image.Rectangle {
+	return image.Rect(0, 0, g.width, g.height)
+}
+
+// In reports whether the point is inside the grid.
+func (g Grid[T]) In(p image.Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+func (g Grid[T]) index(p image.Point) int {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: point %v out of bounds %v", p, g.Bounds()))
+	}
+	return g.offset + p.Y*g.stride + p.X
+}
+
+// At returns the cell at the given point, which must be inside the grid.
+func (g Grid[T]) At(p image.Point) T {
+	return g.cells[g.index(p)]
+}
+
+// Get returns the cell at the given point, and false when it is outside of
+// the grid.
+func (g Grid[T]) Get(p image.Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[g.offset+p.Y*g.stride+p.X], true
+}
+
+// Set replaces the cell at the given point, which must be inside the grid.
+func (g Grid[T]) Set(p image.Point, value T) {
+	g.cells[g.index(p)] = value
+}
+
+// Row returns the cells of a row. The slice shares the cells of the grid.
+func (g Grid[T]) Row(y int) []T {
+	start := g.index(image.Pt(0, y))
+	return g.cells[start : start+g.width : start+g.width]
+}
+
+// Each calls fn for every cell, row by row.
+func (g Grid[T]) Each(fn func(p image.Point, value T)) {
+	for y := 0; y < g.height; y++ {
+		for x, value := range g.Row(y) {
+			fn(image.Pt(x, y), value)
+		}
+	}
+}
+
+// FindAll returns the points of the cells matching the predicate, row by
+// row.
+func (g Grid[T]) FindAll(match func(value T) bool) []image.Point {
+	var points []image.Point
+	g.Each(func(p image.Point, value T) {
+		if match(value) {
+			points = append(points, p)
+		}
+	})
+	return points
+}
+
+// Find returns the first point, row by row, of a cell matching the predicate.
+func (g Grid[T]) Find(match func(value T) bool) (image.Point, bool) {
+	for y := 0; y < g.height; y++ {
+		for x, value := range g.Row(y) {
+			if match(value) {
+				return image.Pt(x, y), true
+			}
+		}
+	}
+	return image.Point{}, false
+}
+
+// Neighbours4 returns the orthogonal neighbours of a point inside the grid.
+func (g Grid[T]) Neighbours4(p image.Point) []image.Point {
+	return g.neighbours(p, Directions4)
+}
+
+// Neighbours8 returns the orthogonal and diagonal neighbours of a point
+// inside the grid.
+func (g Grid[T]) Neighbours8(p image.Point) []image.Point {
+	return g.neighbours(p, Directions8)
+}
+
+func (g Grid[T]) neighbours(p image.Point, directions []image.Point) []image.Point {
+	neighbours := make([]image.Point, 0, len(directions))
+	for _, direction := range directions {
+		if neighbour := p.Add(direction); g.In(neighbour) {
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+	return neighbours
+}
+
+// Sub returns the part of the grid inside the rectangle, as a grid sharing
+// its cells whose origin is the top left corner of the rectangle.
+func (g Grid[T]) Sub(r image.Rectangle) Grid[T] {
+	r = r.Intersect(g.Bounds())
+	if r.Empty() {
+		return Grid[T]{}
+	}
+	return Grid[T]{
+		cells:  g.cells,
+		offset: g.offset + r.Min.Y*g.stride + r.Min.X,
+		stride: g.stride,
+		width:  r.Dx(),
+		height: r.Dy(),
+	}
+}
+
+// Clone returns a copy of the grid not sharing its cells.
+func (g Grid[T]) Clone() Grid[T] {
+	return g.transform(g.width, g.height, func(p image.Point) image.Point { return p })
+}
+
+// transform returns a new grid of the given size whose cell at p is the cell
+// of g at source(p).
+func (g Grid[T]) transform(width, height int, source func(p image.Point) image.Point) Grid[T] {
+	result := New[T](width, height)
+	for y := 0; y < height; y++ {
+		row := result.Row(y)
+		for x := range row {
+			row[x] = g.At(source(image.Pt(x, y)))
+		}
+	}
+	return result
+}
+
+// Transpose returns a copy of the grid mirrored along its main diagonal.
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.transform(g.height, g.width, func(p image.Point) image.Point {
+		return image.Pt(p.Y, p.X)
+	})
+}
+
+// RotateClockwise returns a copy of the grid rotated by a quarter turn
+// clockwise.
+func (g Grid[T]) RotateClockwise() Grid[T] {
+	return g.transform(g.height, g.width, func(p image.Point) image.Point {
+		return image.Pt(p.Y, g.height-1-p.X)
+	})
+}
+
+// RotateCounterClockwise returns a copy of the grid rotated by a quarter turn
+// counterclockwise.
+func (g Grid[T]) RotateCounterClockwise() Grid[T] {
+	return g.transform(g.height, g.width, func(p image.Point) image.Point {
+		return image.Pt(g.width-1-p.Y, p.X)
+	})
+}
+
+// FlipHorizontal returns a copy of the grid mirrored left to right.
+func (g Grid[T]) FlipHorizontal() Grid[T] {
+	return g.transform(g.width, g.height, func(p image.Point) image.Point {
+		return image.Pt(g.width-1-p.X, p.Y)
+	})
+}
+
+// FlipVertical returns a copy of the grid mirrored top to bottom.
+func (g Grid[T]) FlipVertical() Grid[T] {
+	return g.transform(g.width, g.height, func(p image.Point) image.Point {
+		return image.Pt(p.X, g.height-1-p.Y)
+	})
+}
+
+// Format returns the grid as text, one line per row, each cell being
+// converted by the given function.
+func (g Grid[T]) Format(cell func(value T) string) string {
+	var sb strings.Builder
+	for y := 0; y < g.height; y++ {
+		for _, value := range g.Row(y) {
+			sb.WriteString(cell(value))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// String returns the grid as text, one line per row. Runes are written as
+// characters and any other cell as formatted by fmt.
+func (g Grid[T]) String() string {
+	return g.Format(func(value T) string {
+		if char, isRune := any(value).(rune); isRune {
+			return string(char)
+		}
+		return fmt.Sprint(value)
+	})
+}
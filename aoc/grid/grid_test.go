@@ -0,0 +1,115 @@
+package grid
+
+import (
+	"errors"
+	"image"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+const example = `#.S
+..#
+`
+
+func TestParse(t *testing.T) {
+	g, err := Parse(strings.NewReader(example+"\nignored\n"), OneOf(".#S"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.Width() != 3 || g.Height() != 2 {
+		t.Fatalf("Expected a 3x2 grid, got %dx%d", g.Width(), g.Height())
+	}
+	if g.At(image.Pt(2, 0)) != 'S' || g.At(image.Pt(2, 1)) != '#' {
+		t.Errorf("Unexpected cells:\n%s", g)
+	}
+	if g.String() != example {
+		t.Errorf("Expected the grid to be printed as %q, got %q", example, g.String())
+	}
+
+	tests := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"#.S\n..\n", 2, 1},
+		{"#.S\n.x#\n", 2, 2},
+	}
+	for _, test := range tests {
+		_, err := Parse(strings.NewReader(test.input), OneOf(".#S"))
+		var parseErr *aoc.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("Expected an error at %d:%d for %q, got %v", test.line, test.column, test.input, err)
+		}
+	}
+
+	digits, err := Parse(strings.NewReader("19\n28\n"), Digits)
+	if err != nil || digits.At(image.Pt(1, 0)) != 9 || digits.String() != "19\n28\n" {
+		t.Errorf("Unexpected digits %v (%v)", digits, err)
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 3)
+	if got := g.Neighbours4(image.Pt(0, 0)); !slices.Equal(got, []image.Point{{1, 0}, {0, 1}}) {
+		t.Errorf("Unexpected corner neighbours %v", got)
+	}
+	if got := len(g.Neighbours8(image.Pt(1, 1))); got != 8 {
+		t.Errorf("Expected 8 neighbours for the center, got %d", got)
+	}
+	if got := len(g.Neighbours8(image.Pt(2, 1))); got != 5 {
+		t.Errorf("Expected 5 neighbours for a side, got %d", got)
+	}
+	if _, ok := g.Get(image.Pt(3, 0)); ok {
+		t.Errorf("Expected a point outside of the grid")
+	}
+}
+
+func TestTransformations(t *testing.T) {
+	g, err := FromRows([][]rune{[]rune("abc"), []rune("def")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name     string
+		grid     Grid[rune]
+		expected string
+	}{
+		{"transpose", g.Transpose(), "ad\nbe\ncf\n"},
+		{"clockwise", g.RotateClockwise(), "da\neb\nfc\n"},
+		{"counterclockwise", g.RotateCounterClockwise(), "cf\nbe\nad\n"},
+		{"horizontal", g.FlipHorizontal(), "cba\nfed\n"},
+		{"vertical", g.FlipVertical(), "def\nabc\n"},
+		{"full turn", g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), "abc\ndef\n"},
+	}
+	for _, test := range tests {
+		if got := test.grid.String(); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestSub(t *testing.T) {
+	g, _ := FromRows([][]rune{[]rune("abcd"), []rune("efgh"), []rune("ijkl")})
+	sub := g.Sub(image.Rect(1, 1, 3, 5))
+	if sub.String() != "fg\njk\n" {
+		t.Fatalf("Unexpected sub-grid %q", sub.String())
+	}
+	sub.Set(image.Pt(0, 0), 'F')
+	if g.At(image.Pt(1, 1)) != 'F' {
+		t.Errorf("Expected the sub-grid to share the cells of its parent")
+	}
+	clone := sub.Clone()
+	clone.Set(image.Pt(1, 1), 'K')
+	if g.At(image.Pt(2, 2)) != 'k' {
+		t.Errorf("Expected a clone not to share the cells of its parent")
+	}
+	if got := g.FindAll(func(c rune) bool { return c >= 'j' }); !slices.Equal(got, []image.Point{{1, 2}, {2, 2}, {3, 2}}) {
+		t.Errorf("Unexpected points %v", got)
+	}
+	if p, ok := sub.Find(func(c rune) bool { return c == 'k' }); !ok || p != image.Pt(1, 1) {
+		t.Errorf("Expected k at (1,1) of the sub-grid, got %v", p)
+	}
+}
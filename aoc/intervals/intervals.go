@@ -0,0 +1,213 @@
+// Package intervals provides integer intervals, normalised sets of intervals
+// and piecewise translations of the integers, to handle the puzzles working
+// on ranges of numbers too large to be enumerated.
+package intervals
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Interval is the half-open range of integers [Start, End). It is empty when
+// End is not above Start.
+type Interval struct {
+	Start int64
+	End   int64
+}
+
+// New returns the half-open interval [start, end).
+func New(start, end int64) Interval {
+	return Interval{Start: start, End: end}
+}
+
+// Closed returns the closed interval [first, last].
+func Closed(first, last int64) Interval {
+	return Interval{Start: first, End: last + 1}
+}
+
+// WithLength returns the interval of length integers from start.
+func WithLength(start, length int64) Interval {
+	return Interval{Start: start, End: start + length}
+}
+
+func (i Interval) Empty() bool {
+	return i.End <= i.Start
+}
+
+// Len returns the number of integers in the interval.
+func (i Interval) Len() int64 {
+	if i.Empty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Last returns the greatest integer of a non empty interval.
+func (i Interval) Last() int64 {
+	return i.End - 1
+}
+
+func (i Interval) Contains(n int64) bool {
+	return i.Start <= n && n < i.End
+}
+
+// Intersect returns the integers in both intervals, which may be empty.
+func (i Interval) Intersect(j Interval) Interval {
+	return Interval{Start: max(i.Start, j.Start), End: min(i.End, j.End)}
+}
+
+func (i Interval) Overlaps(j Interval) bool {
+	return !i.Intersect(j).Empty()
+}
+
+// Shift returns the interval translated by delta.
+func (i Interval) Shift(delta int64) Interval {
+	return Interval{Start: i.Start + delta, End: i.End + delta}
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d, %d)", i.Start, i.End)
+}
+
+// IntervalSet is a set of integers made of intervals. It is always
+// normalised: its intervals are non empty, sorted, and neither overlap nor
+// touch each other, so that equal sets have equal intervals. The zero value
+// is the empty set.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewSet returns the union of the intervals.
+func NewSet(intervals ...Interval) IntervalSet {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, interval := range intervals {
+		if !interval.Empty() {
+			sorted = append(sorted, interval)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	var merged []Interval
+	for _, interval := range sorted {
+		if last := len(merged) - 1; last >= 0 && interval.Start <= merged[last].End {
+			merged[last].End = max(merged[last].End, interval.End)
+		} else {
+			merged = append(merged, interval)
+		}
+	}
+	return IntervalSet{intervals: merged}
+}
+
+// Intervals returns the intervals of the set, sorted.
+func (s IntervalSet) Intervals() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+func (s IntervalSet) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Cardinality returns the number of integers in the set.
+func (s IntervalSet) Cardinality() int64 {
+	var count int64
+	for _, interval := range s.intervals {
+		count += interval.Len()
+	}
+	return count
+}
+
+// Min returns the lowest integer of the set, and false when it is empty.
+func (s IntervalSet) Min() (int64, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.intervals[0].Start, true
+}
+
+// Max returns the greatest integer of the set, and false when it is empty.
+func (s IntervalSet) Max() (int64, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.intervals[len(s.intervals)-1].Last(), true
+}
+
+func (s IntervalSet) Contains(n int64) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > n })
+	return i < len(s.intervals) && s.intervals[i].Contains(n)
+}
+
+// Equal reports whether both sets hold the same integers.
+func (s IntervalSet) Equal(t IntervalSet) bool {
+	if len(s.intervals) != len(t.intervals) {
+		return false
+	}
+	for i := range s.intervals {
+		if s.intervals[i] != t.intervals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns the set with the interval added.
+func (s IntervalSet) Add(interval Interval) IntervalSet {
+	return NewSet(append(s.Intervals(), interval)...)
+}
+
+func (s IntervalSet) Union(t IntervalSet) IntervalSet {
+	return NewSet(append(s.Intervals(), t.intervals...)...)
+}
+
+func (s IntervalSet) Intersect(t IntervalSet) IntervalSet {
+	var result []Interval
+	for i, j := 0, 0; i < len(s.intervals) && j < len(t.intervals); {
+		if common := s.intervals[i].Intersect(t.intervals[j]); !common.Empty() {
+			result = append(result, common)
+		}
+		if s.intervals[i].End < t.intervals[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return IntervalSet{intervals: result}
+}
+
+// Subtract returns the integers of s which are not in t.
+func (s IntervalSet) Subtract(t IntervalSet) IntervalSet {
+	var result []Interval
+	j := 0
+	for _, interval := range s.intervals {
+		for j < len(t.intervals) && t.intervals[j].End <= interval.Start {
+			j++
+		}
+		for k := j; k < len(t.intervals) && t.intervals[k].Start < interval.End; k++ {
+			if t.intervals[k].Start > interval.Start {
+				result = append(result, Interval{Start: interval.Start, End: t.intervals[k].Start})
+			}
+			interval.Start = max(interval.Start, t.intervals[k].End)
+		}
+		if !interval.Empty() {
+			result = append(result, interval)
+		}
+	}
+	return IntervalSet{intervals: result}
+}
+
+// Shift returns the set translated by delta.
+func (s IntervalSet) Shift(delta int64) IntervalSet {
+	result := make([]Interval, len(s.intervals))
+	for i, interval := range s.intervals {
+		result[i] = interval.Shift(delta)
+	}
+	return IntervalSet{intervals: result}
+}
+
+func (s IntervalSet) String() string {
+	parts := make([]string, len(s.intervals))
+	for i, interval := range s.intervals {
+		parts[i] = interval.String()
+	}
+	return "{" + strings.Join(parts, " ∪ ") + "}"
+}
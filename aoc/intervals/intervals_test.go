@@ -0,0 +1,76 @@
+package intervals
+
+import (
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	i := Closed(3, 7)
+	if i != New(3, 8) || i != WithLength(3, 5) {
+		t.Errorf("Expected [3, 8), got %s", i)
+	}
+	if i.Len() != 5 || i.Last() != 7 {
+		t.Errorf("Expected 5 integers up to 7, got %d up to %d", i.Len(), i.Last())
+	}
+	if !i.Contains(3) || i.Contains(8) {
+		t.Errorf("Expected %s to contain 3 and not 8", i)
+	}
+	if common := i.Intersect(New(6, 10)); common != New(6, 8) {
+		t.Errorf("Expected [6, 8), got %s", common)
+	}
+	if i.Overlaps(New(8, 10)) {
+		t.Errorf("Expected %s not to overlap [8, 10)", i)
+	}
+	if empty := New(5, 2); !empty.Empty() || empty.Len() != 0 {
+		t.Errorf("Expected %s to be empty", empty)
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	s := NewSet(New(10, 14), Closed(3, 5), New(12, 20), New(6, 8), New(30, 30))
+	expected := "{[3, 8) ∪ [10, 20)}"
+	if s.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+	if s.Cardinality() != 15 {
+		t.Errorf("Expected 15 integers, got %d", s.Cardinality())
+	}
+	if minimum, _ := s.Min(); minimum != 3 {
+		t.Errorf("Expected a minimum of 3, got %d", minimum)
+	}
+	if maximum, _ := s.Max(); maximum != 19 {
+		t.Errorf("Expected a maximum of 19, got %d", maximum)
+	}
+	for n, expected := range map[int64]bool{2: false, 3: true, 7: true, 8: false, 9: false, 19: true, 20: false} {
+		if s.Contains(n) != expected {
+			t.Errorf("Expected Contains(%d) to be %t", n, expected)
+		}
+	}
+	if _, found := (IntervalSet{}).Min(); found {
+		t.Errorf("Expected no minimum for the empty set")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(New(0, 10), New(20, 30))
+	b := NewSet(New(5, 25), New(28, 40))
+	tests := []struct {
+		name     string
+		result   IntervalSet
+		expected IntervalSet
+	}{
+		{"union", a.Union(b), NewSet(New(0, 40))},
+		{"intersect", a.Intersect(b), NewSet(New(5, 10), New(20, 25), New(28, 30))},
+		{"subtract", a.Subtract(b), NewSet(New(0, 5), New(25, 28))},
+		{"subtract reversed", b.Subtract(a), NewSet(New(10, 20), New(30, 40))},
+		{"subtract inside", NewSet(New(0, 10)).Subtract(NewSet(New(2, 3), New(5, 7))), NewSet(New(0, 2), New(3, 5), New(7, 10))},
+		{"add", a.Add(New(10, 20)), NewSet(New(0, 30))},
+		{"shift", a.Shift(-5), NewSet(New(-5, 5), New(15, 25))},
+		{"empty", a.Intersect(IntervalSet{}), IntervalSet{}},
+	}
+	for _, test := range tests {
+		if !test.result.Equal(test.expected) {
+			t.Errorf("Expected %s to be %s, got %s", test.name, test.expected, test.result)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package intervals
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrOverlappingPieces is returned when a piecewise map would translate
+	// an integer by two different offsets.
+	ErrOverlappingPieces = errors.New("intervals: overlapping pieces")
+	// ErrNotInvertible is returned when two integers have the same image.
+	ErrNotInvertible = errors.New("intervals: map not invertible")
+)
+
+// Piece translates the integers of Source by Offset.
+type Piece struct {
+	Source Interval
+	Offset int64
+}
+
+func (p Piece) String() string {
+	return fmt.Sprintf("%s%+d", p.Source, p.Offset)
+}
+
+// PiecewiseMap maps each integer to itself translated by the offset of the
+// piece containing it, the integers outside of every piece being left
+// unchanged. It is always normalised: its pieces are non empty, sorted, have
+// an offset and the touching ones have different offsets.
+type PiecewiseMap struct {
+	pieces []Piece
+}
+
+// NewPiecewiseMap returns the map made of the pieces, which must not overlap.
+func NewPiecewiseMap(pieces ...Piece) (PiecewiseMap, error) {
+	m := normalise(pieces)
+	for i := 1; i < len(m.pieces); i++ {
+		if m.pieces[i-1].Source.Overlaps(m.pieces[i].Source) {
+			return PiecewiseMap{}, fmt.Errorf("%w: %s and %s", ErrOverlappingPieces, m.pieces[i-1], m.pieces[i])
+		}
+	}
+	return m, nil
+}
+
+// normalise sorts and merges pieces known not to overlap.
+func normalise(pieces []Piece) PiecewiseMap {
+	sorted := make([]Piece, 0, len(pieces))
+	for _, piece := range pieces {
+		if !piece.Source.Empty() && piece.Offset != 0 {
+			sorted = append(sorted, piece)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Source.Start < sorted[j].Source.Start })
+	var merged []Piece
+	for _, piece := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].Offset == piece.Offset && merged[last].Source.End == piece.Source.Start {
+			merged[last].Source.End = piece.Source.End
+		} else {
+			merged = append(merged, piece)
+		}
+	}
+	return PiecewiseMap{pieces: merged}
+}
+
+// Pieces returns the pieces of the map, sorted.
+func (m PiecewiseMap) Pieces() []Piece {
+	return append([]Piece(nil), m.pieces...)
+}
+
+// Domain returns the integers the map translates.
+func (m PiecewiseMap) Domain() IntervalSet {
+	sources := make([]Interval, len(m.pieces))
+	for i, piece := range m.pieces {
+		sources[i] = piece.Source
+	}
+	return NewSet(sources...)
+}
+
+// Apply returns the image of n.
+func (m PiecewiseMap) Apply(n int64) int64 {
+	i := sort.Search(len(m.pieces), func(i int) bool { return m.pieces[i].Source.End > n })
+	if i < len(m.pieces) && m.pieces[i].Source.Contains(n) {
+		return n + m.pieces[i].Offset
+	}
+	return n
+}
+
+// Image returns the images of the integers of the set.
+func (m PiecewiseMap) Image(s IntervalSet) IntervalSet {
+	images := s.Subtract(m.Domain()).Intervals()
+	for _, piece := range m.pieces {
+		for _, interval := range s.Intersect(NewSet(piece.Source)).intervals {
+			images = append(images, interval.Shift(piece.Offset))
+		}
+	}
+	return NewSet(images...)
+}
+
+// Preimage returns the integers whose image is in the set.
+func (m PiecewiseMap) Preimage(s IntervalSet) IntervalSet {
+	preimages := s.Subtract(m.Domain()).Intervals()
+	for _, piece := range m.pieces {
+		image := NewSet(piece.Source.Shift(piece.Offset))
+		for _, interval := range s.Intersect(image).intervals {
+			preimages = append(preimages, interval.Shift(-piece.Offset))
+		}
+	}
+	return NewSet(preimages...)
+}
+
+// Then returns the composition applying m first and next on its result.
+func (m PiecewiseMap) Then(next PiecewiseMap) PiecewiseMap {
+	var pieces []Piece
+	for _, piece := range m.pieces {
+		image := piece.Source.Shift(piece.Offset)
+		rest := NewSet(image)
+		for _, nextPiece := range next.pieces {
+			if common := image.Intersect(nextPiece.Source); !common.Empty() {
+				pieces = append(pieces, Piece{Source: common.Shift(-piece.Offset), Offset: piece.Offset + nextPiece.Offset})
+				rest = rest.Subtract(NewSet(common))
+			}
+		}
+		for _, interval := range rest.intervals {
+			pieces = append(pieces, Piece{Source: interval.Shift(-piece.Offset), Offset: piece.Offset})
+		}
+	}
+	// The integers left unchanged by m are only translated by next.
+	unchanged := next.Domain().Subtract(m.Domain())
+	for _, nextPiece := range next.pieces {
+		for _, interval := range unchanged.Intersect(NewSet(nextPiece.Source)).intervals {
+			pieces = append(pieces, Piece{Source: interval, Offset: nextPiece.Offset})
+		}
+	}
+	return normalise(pieces)
+}
+
+// Inverse returns the map undoing m, or ErrNotInvertible when two integers
+// have the same image.
+func (m PiecewiseMap) Inverse() (PiecewiseMap, error) {
+	inverse := make([]Piece, len(m.pieces))
+	for i, piece := range m.pieces {
+		inverse[i] = Piece{Source: piece.Source.Shift(piece.Offset), Offset: -piece.Offset}
+	}
+	result, errOverlapping := NewPiecewiseMap(inverse...)
+	// The integers outside of the domain are their own image, so the
+	// translated ones must exactly fill the domain.
+	if errOverlapping != nil || !result.Domain().Equal(m.Domain()) {
+		return PiecewiseMap{}, ErrNotInvertible
+	}
+	return result, nil
+}
+
+func (m PiecewiseMap) String() string {
+	parts := make([]string, len(m.pieces))
+	for i, piece := range m.pieces {
+		parts[i] = piece.String()
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,87 @@
+package intervals
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustMap(t *testing.T, pieces ...Piece) PiecewiseMap {
+	t.Helper()
+	m, err := NewPiecewiseMap(pieces...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return m
+}
+
+// The seed-to-soil map of the 2023 day 5 example: 98-99 go to 50-51 and
+// 50-97 to 52-99.
+func seedToSoil(t *testing.T) PiecewiseMap {
+	t.Helper()
+	return mustMap(t, Piece{WithLength(98, 2), -48}, Piece{WithLength(50, 48), 2})
+}
+
+func TestPiecewiseMapApply(t *testing.T) {
+	m := seedToSoil(t)
+	for n, expected := range map[int64]int64{0: 0, 49: 49, 50: 52, 97: 99, 98: 50, 99: 51, 100: 100} {
+		if image := m.Apply(n); image != expected {
+			t.Errorf("Expected %d to map to %d, got %d", n, expected, image)
+		}
+	}
+	if _, err := NewPiecewiseMap(Piece{New(0, 10), 1}, Piece{New(5, 15), 2}); !errors.Is(err, ErrOverlappingPieces) {
+		t.Errorf("Expected ErrOverlappingPieces, got %v", err)
+	}
+}
+
+func TestPiecewiseMapNormalised(t *testing.T) {
+	m := mustMap(t, Piece{New(5, 10), 3}, Piece{New(0, 5), 3}, Piece{New(20, 30), 0})
+	if s := m.String(); s != "{[0, 10)+3}" {
+		t.Errorf("Expected {[0, 10)+3}, got %s", s)
+	}
+}
+
+func TestPiecewiseMapImage(t *testing.T) {
+	m := seedToSoil(t)
+	image := m.Image(NewSet(WithLength(79, 14), WithLength(55, 13)))
+	if expected := NewSet(WithLength(81, 14), WithLength(57, 13)); !image.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, image)
+	}
+	image = m.Image(NewSet(New(40, 100)))
+	if expected := NewSet(New(40, 50), New(50, 52), New(52, 100)); !image.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, image)
+	}
+	preimage := m.Preimage(NewSet(New(50, 53)))
+	if expected := NewSet(New(98, 100), New(50, 51)); !preimage.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, preimage)
+	}
+}
+
+func TestPiecewiseMapThen(t *testing.T) {
+	first := mustMap(t, Piece{New(0, 10), 5})
+	second := mustMap(t, Piece{New(8, 12), 100}, Piece{New(20, 30), -20})
+	composed := first.Then(second)
+	for n := int64(-5); n < 40; n++ {
+		if expected, got := second.Apply(first.Apply(n)), composed.Apply(n); expected != got {
+			t.Errorf("Expected %d to map to %d, got %d with %s", n, expected, got, composed)
+		}
+	}
+}
+
+func TestPiecewiseMapInverse(t *testing.T) {
+	m := seedToSoil(t)
+	inverse, err := m.Inverse()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for n := int64(0); n < 110; n++ {
+		if back := inverse.Apply(m.Apply(n)); back != n {
+			t.Errorf("Expected %d to map back to itself, got %d", n, back)
+		}
+	}
+	if identity := m.Then(inverse); len(identity.Pieces()) != 0 {
+		t.Errorf("Expected the identity, got %s", identity)
+	}
+	if _, err := mustMap(t, Piece{New(0, 10), 5}).Inverse(); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Expected ErrNotInvertible, got %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc/numtheory"
+)
+
+var (
+	// ErrInconsistent is returned when a linear system has no solution.
+	ErrInconsistent = errors.New("linalg: inconsistent system")
+	// ErrNotInteger is returned when an integer matrix is required.
+	ErrNotInteger = errors.New("linalg: not an integer matrix")
+)
+
+// Matrix is a rectangle of rationals. Matrices returned by the operations
+// never share their cells with the operands.
+type Matrix struct {
+	cells []Rat
+	rows  int
+	cols  int
+}
+
+// New returns a zero matrix of the given size.
+func New(rows, cols int) Matrix {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("linalg: invalid size %dx%d", rows, cols))
+	}
+	return Matrix{cells: make([]Rat, rows*cols), rows: rows, cols: cols}
+}
+
+// Identity returns the identity matrix of the given size.
+func Identity(size int) Matrix {
+	m := New(size, size)
+	for i := 0; i < size; i++ {
+		m.Set(i, i, Int(1))
+	}
+	return m
+}
+
+// FromInts returns a matrix holding the given rows, which must all have the
+// same length.
+func FromInts(rows [][]int64) (Matrix, error) {
+	if len(rows) == 0 {
+		return Matrix{}, nil
+	}
+	m := New(len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix{}, fmt.Errorf("linalg: row %d has %d cells, expected %d", i, len(row), m.cols)
+		}
+		for j, value := range row {
+			m.Set(i, j, Int(value))
+		}
+	}
+	return m, nil
+}
+
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// At returns the cell at row i and column j.
+func (m Matrix) At(i, j int) Rat {
+	return m.cells[m.index(i, j)]
+}
+
+// Set replaces the cell at row i and column j.
+func (m Matrix) Set(i, j int, value Rat) {
+	m.cells[m.index(i, j)] = value
+}
+
+func (m Matrix) index(i, j int) int {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("linalg: cell (%d, %d) out of a %dx%d matrix", i, j, m.rows, m.cols))
+	}
+	return i*m.cols + j
+}
+
+// Row returns the cells of a row. The slice shares the cells of the matrix.
+func (m Matrix) Row(i int) []Rat {
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("linalg: row %d out of a %dx%d matrix", i, m.rows, m.cols))
+	}
+	start := i * m.cols
+	return m.cells[start : start+m.cols : start+m.cols]
+}
+
+// Clone returns a copy of the matrix not sharing its cells.
+func (m Matrix) Clone() Matrix {
+	return Matrix{cells: append([]Rat(nil), m.cells...), rows: m.rows, cols: m.cols}
+}
+
+// Augment returns the matrix with the column b appended.
+func (m Matrix) Augment(b []Rat) Matrix {
+	if len(b) != m.rows {
+		panic(fmt.Sprintf("linalg: column of %d cells for a matrix of %d rows", len(b), m.rows))
+	}
+	augmented := New(m.rows, m.cols+1)
+	for i := 0; i < m.rows; i++ {
+		copy(augmented.Row(i), m.Row(i))
+		augmented.Set(i, m.cols, b[i])
+	}
+	return augmented
+}
+
+// Mul returns the product m*n.
+func (m Matrix) Mul(n Matrix) Matrix {
+	if m.cols != n.rows {
+		panic(fmt.Sprintf("linalg: multiplying a %dx%d matrix by a %dx%d one", m.rows, m.cols, n.rows, n.cols))
+	}
+	product := New(m.rows, n.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < n.cols; j++ {
+			var sum Rat
+			for k := 0; k < m.cols; k++ {
+				sum = sum.Add(m.At(i, k).Mul(n.At(k, j)))
+			}
+			product.Set(i, j, sum)
+		}
+	}
+	return product
+}
+
+// addRow adds factor times the row src to the row dst.
+func (m Matrix) addRow(dst, src int, factor Rat) {
+	if factor.Sign() == 0 {
+		return
+	}
+	dstRow, srcRow := m.Row(dst), m.Row(src)
+	for j := range dstRow {
+		dstRow[j] = dstRow[j].Add(factor.Mul(srcRow[j]))
+	}
+}
+
+func (m Matrix) swapRows(i, j int) {
+	rowI, rowJ := m.Row(i), m.Row(j)
+	for k := range rowI {
+		rowI[k], rowJ[k] = rowJ[k], rowI[k]
+	}
+}
+
+func (m Matrix) scaleRow(i int, factor Rat) {
+	row := m.Row(i)
+	for j := range row {
+		row[j] = row[j].Mul(factor)
+	}
+}
+
+// RREF returns the reduced row echelon form of the matrix, and the column of
+// the pivot of each of its non zero rows.
+func (m Matrix) RREF() (Matrix, []int) {
+	return m.rref(m.cols)
+}
+
+// rref is RREF only looking for pivots in the first columns.
+func (m Matrix) rref(pivotCols int) (Matrix, []int) {
+	r := m.Clone()
+	var pivots []int
+	for col := 0; col < pivotCols && len(pivots) < r.rows; col++ {
+		row := len(pivots)
+		pivotRow := -1
+		for i := row; i < r.rows; i++ {
+			if r.At(i, col).Sign() != 0 {
+				pivotRow = i
+				break
+			}
+		}
+		if pivotRow < 0 {
+			continue
+		}
+		r.swapRows(row, pivotRow)
+		r.scaleRow(row, r.At(row, col).Inv())
+		for i := 0; i < r.rows; i++ {
+			if i != row {
+				r.addRow(i, row, r.At(i, col).Neg())
+			}
+		}
+		pivots = append(pivots, col)
+	}
+	return r, pivots
+}
+
+// Rank returns the rank of the matrix.
+func (m Matrix) Rank() int {
+	_, pivots := m.RREF()
+	return len(pivots)
+}
+
+// freeColumns returns the columns without pivot.
+func freeColumns(cols int, pivots []int) []int {
+	isPivot := make([]bool, cols)
+	for _, col := range pivots {
+		isPivot[col] = true
+	}
+	var free []int
+	for col := 0; col < cols; col++ {
+		if !isPivot[col] {
+			free = append(free, col)
+		}
+	}
+	return free
+}
+
+// nullSpace returns a basis of the null space from a reduced row echelon
+// form, one vector per free column having 1 for it and 0 for the others.
+func nullSpace(r Matrix, cols int, pivots []int) ([]int, [][]Rat) {
+	free := freeColumns(cols, pivots)
+	basis := make([][]Rat, len(free))
+	for k, col := range free {
+		vector := make([]Rat, cols)
+		vector[col] = Int(1)
+		for i, pivot := range pivots {
+			vector[pivot] = r.At(i, col).Neg()
+		}
+		basis[k] = vector
+	}
+	return free, basis
+}
+
+// NullSpace returns a basis of the vectors x such that m*x = 0.
+func (m Matrix) NullSpace() [][]Rat {
+	r, pivots := m.RREF()
+	_, basis := nullSpace(r, m.cols, pivots)
+	return basis
+}
+
+// Solution describes all the solutions of a linear system, which are the
+// particular solution plus any combination of the basis vectors.
+type Solution struct {
+	Particular []Rat
+	// Free are the variables which can take any value, the basis vector k
+	// having 1 for Free[k] and 0 for the other free variables.
+	Free  []int
+	Basis [][]Rat
+}
+
+// Unique reports whether the system has a single solution.
+func (s Solution) Unique() bool {
+	return len(s.Basis) == 0
+}
+
+// With returns the solution whose free variables have the given values.
+func (s Solution) With(values []Rat) []Rat {
+	if len(values) != len(s.Free) {
+		panic(fmt.Sprintf("linalg: %d values for %d free variables", len(values), len(s.Free)))
+	}
+	x := append([]Rat(nil), s.Particular...)
+	for k, value := range values {
+		for j, coefficient := range s.Basis[k] {
+			x[j] = x[j].Add(value.Mul(coefficient))
+		}
+	}
+	return x
+}
+
+// ScaledSolution is a Solution multiplied by the common denominator of its
+// cells, so that its solutions are computed with integers only: they are the
+// particular solution plus any combination of the basis vectors, divided by
+// the denominator.
+type ScaledSolution struct {
+	Denominator int64
+	Particular  []int64
+	Basis       [][]int64
+}
+
+// Scaled returns the solution with integer cells, or an error wrapping
+// numtheory.ErrOverflow when they don't fit in an int64.
+func (s Solution) Scaled() (ScaledSolution, error) {
+	vectors := append([][]Rat{s.Particular}, s.Basis...)
+	denominator := int64(1)
+	for _, vector := range vectors {
+		for _, cell := range vector {
+			if cell.big != nil {
+				return ScaledSolution{}, fmt.Errorf("%w: %s", numtheory.ErrOverflow, cell)
+			}
+			var errOverflow error
+			if denominator, errOverflow = numtheory.LCM(denominator, cell.denom()); errOverflow != nil {
+				return ScaledSolution{}, errOverflow
+			}
+		}
+	}
+	scaled := make([][]int64, len(vectors))
+	for k, vector := range vectors {
+		scaled[k] = make([]int64, len(vector))
+		for j, cell := range vector {
+			value, fits := cell.Mul(Int(denominator)).Int64()
+			if !fits {
+				return ScaledSolution{}, fmt.Errorf("%w: %s*%d", numtheory.ErrOverflow, cell, denominator)
+			}
+			scaled[k][j] = value
+		}
+	}
+	return ScaledSolution{Denominator: denominator, Particular: scaled[0], Basis: scaled[1:]}, nil
+}
+
+// Solve returns the solutions x of m*x = b, or ErrInconsistent when there are
+// none.
+func (m Matrix) Solve(b []Rat) (Solution, error) {
+	r, pivots := m.Augment(b).rref(m.cols)
+	for i := len(pivots); i < r.rows; i++ {
+		if r.At(i, m.cols).Sign() != 0 {
+			return Solution{}, ErrInconsistent
+		}
+	}
+	solution := Solution{Particular: make([]Rat, m.cols)}
+	for i, pivot := range pivots {
+		solution.Particular[pivot] = r.At(i, m.cols)
+	}
+	solution.Free, solution.Basis = nullSpace(r, m.cols, pivots)
+	return solution, nil
+}
+
+// HermiteNormalForm returns the Hermite normal form H of an integer matrix,
+// and the unimodular matrix U such that U*m = H. H is upper triangular, its
+// pivots are positive and the cells above them are non negative and lower
+// than them.
+func (m Matrix) HermiteNormalForm() (Matrix, Matrix, error) {
+	for _, cell := range m.cells {
+		if !cell.IsInt() {
+			return Matrix{}, Matrix{}, ErrNotInteger
+		}
+	}
+	h, u := m.Clone(), Identity(m.rows)
+	row := 0
+	for col := 0; col < h.cols && row < h.rows; col++ {
+		// Euclid's algorithm on the rows below, until only the pivot is left.
+		for {
+			pivotRow := -1
+			for i := row; i < h.rows; i++ {
+				cell := h.At(i, col)
+				if cell.Sign() != 0 && (pivotRow < 0 || cell.Abs().Cmp(h.At(pivotRow, col).Abs()) < 0) {
+					pivotRow = i
+				}
+			}
+			if pivotRow < 0 {
+				break
+			}
+			h.swapRows(row, pivotRow)
+			u.swapRows(row, pivotRow)
+			reduced := true
+			for i := row + 1; i < h.rows; i++ {
+				q := h.At(i, col).Quo(h.At(row, col)).Floor().Neg()
+				h.addRow(i, row, q)
+				u.addRow(i, row, q)
+				reduced = reduced && h.At(i, col).Sign() == 0
+			}
+			if reduced {
+				break
+			}
+		}
+		pivot := h.At(row, col)
+		if pivot.Sign() == 0 {
+			continue
+		}
+		if pivot.Sign() < 0 {
+			h.scaleRow(row, Int(-1))
+			u.scaleRow(row, Int(-1))
+			pivot = pivot.Neg()
+		}
+		for i := 0; i < row; i++ {
+			q := h.At(i, col).Quo(pivot).Floor().Neg()
+			h.addRow(i, row, q)
+			u.addRow(i, row, q)
+		}
+		row++
+	}
+	return h, u, nil
+}
+
+// String returns the matrix as text, one line per row.
+func (m Matrix) String() string {
+	var sb strings.Builder
+	for i := 0; i < m.rows; i++ {
+		for j, cell := range m.Row(i) {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(cell.String())
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
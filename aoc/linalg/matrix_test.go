@@ -0,0 +1,160 @@
+package linalg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustFromInts(t *testing.T, rows [][]int64) Matrix {
+	t.Helper()
+	m, err := FromInts(rows)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return m
+}
+
+func ints(values ...int64) []Rat {
+	rats := make([]Rat, len(values))
+	for i, value := range values {
+		rats[i] = Int(value)
+	}
+	return rats
+}
+
+func checkVector(t *testing.T, name string, vector []Rat, expected []Rat) {
+	t.Helper()
+	if len(vector) != len(expected) {
+		t.Fatalf("Expected %s to be %v, got %v", name, expected, vector)
+	}
+	for i := range vector {
+		if vector[i].Cmp(expected[i]) != 0 {
+			t.Errorf("Expected %s to be %v, got %v", name, expected, vector)
+			return
+		}
+	}
+}
+
+func TestFromInts(t *testing.T) {
+	if _, err := FromInts([][]int64{{1, 2}, {3}}); err == nil {
+		t.Errorf("Expected an error for rows of different lengths")
+	}
+}
+
+func TestRREF(t *testing.T) {
+	m := mustFromInts(t, [][]int64{
+		{1, 2, 1, 4},
+		{2, 4, 0, 2},
+		{3, 6, 1, 6},
+	})
+	r, pivots := m.RREF()
+	expected := "1 2 0 1\n0 0 1 3\n0 0 0 0\n"
+	if r.String() != expected {
+		t.Errorf("Expected the RREF to be\n%s\ngot\n%s", expected, r)
+	}
+	checkVector(t, "pivots", ints(int64(pivots[0]), int64(pivots[1])), ints(0, 2))
+	if rank := m.Rank(); rank != 2 {
+		t.Errorf("Expected rank 2, got %d", rank)
+	}
+	if m.At(0, 3).Cmp(Int(4)) != 0 {
+		t.Errorf("Expected RREF to leave the matrix unchanged, got\n%s", m)
+	}
+}
+
+func TestNullSpace(t *testing.T) {
+	m := mustFromInts(t, [][]int64{
+		{1, 2, 1, 4},
+		{2, 4, 0, 2},
+	})
+	basis := m.NullSpace()
+	if len(basis) != 2 {
+		t.Fatalf("Expected 2 basis vectors, got %v", basis)
+	}
+	for _, vector := range basis {
+		product := m.Mul(column(vector))
+		checkVector(t, "m*x", []Rat{product.At(0, 0), product.At(1, 0)}, ints(0, 0))
+	}
+}
+
+// column returns a matrix of a single column.
+func column(values []Rat) Matrix {
+	return New(len(values), 0).Augment(values)
+}
+
+func TestSolve(t *testing.T) {
+	t.Run("unique", func(t *testing.T) {
+		m := mustFromInts(t, [][]int64{{94, 22}, {34, 67}})
+		solution, err := m.Solve(ints(8400, 5400))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !solution.Unique() {
+			t.Errorf("Expected a unique solution, got %v", solution)
+		}
+		checkVector(t, "solution", solution.Particular, ints(80, 40))
+	})
+	t.Run("rational", func(t *testing.T) {
+		m := mustFromInts(t, [][]int64{{2, 0}, {0, 3}})
+		solution, err := m.Solve(ints(1, 1))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		checkVector(t, "solution", solution.Particular, []Rat{NewRat(1, 2), NewRat(1, 3)})
+	})
+	t.Run("free variables", func(t *testing.T) {
+		m := mustFromInts(t, [][]int64{{1, 1, 1}, {0, 1, 2}})
+		solution, err := m.Solve(ints(6, 5))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(solution.Free) != 1 || solution.Free[0] != 2 {
+			t.Fatalf("Expected the third variable to be free, got %v", solution.Free)
+		}
+		x := solution.With(ints(2))
+		checkVector(t, "solution", x, ints(3, 1, 2))
+	})
+	t.Run("scaled", func(t *testing.T) {
+		m := mustFromInts(t, [][]int64{{2, 0, 1}, {0, 3, 1}})
+		solution, err := m.Solve(ints(1, 1))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		scaled, err := solution.Scaled()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := ScaledSolution{Denominator: 6, Particular: []int64{3, 2, 0}, Basis: [][]int64{{-3, -2, 6}}}
+		if !reflect.DeepEqual(scaled, expected) {
+			t.Errorf("Expected %v, got %v", expected, scaled)
+		}
+	})
+	t.Run("inconsistent", func(t *testing.T) {
+		m := mustFromInts(t, [][]int64{{1, 1}, {2, 2}})
+		if _, err := m.Solve(ints(1, 3)); !errors.Is(err, ErrInconsistent) {
+			t.Errorf("Expected ErrInconsistent, got %v", err)
+		}
+	})
+}
+
+func TestHermiteNormalForm(t *testing.T) {
+	m := mustFromInts(t, [][]int64{
+		{2, 3, 6, 2},
+		{5, 6, 1, 6},
+		{8, 3, 1, 1},
+	})
+	h, u, err := m.HermiteNormalForm()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "1 0 50 -11\n0 3 28 -2\n0 0 61 -13\n"
+	if h.String() != expected {
+		t.Errorf("Expected the Hermite normal form to be\n%s\ngot\n%s", expected, h)
+	}
+	if product := u.Mul(m); product.String() != h.String() {
+		t.Errorf("Expected U*m to be\n%s\ngot\n%s", h, product)
+	}
+	if _, _, err := column([]Rat{Int(1), NewRat(1, 2)}).HermiteNormalForm(); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("Expected ErrNotInteger, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+// Package linalg provides exact linear algebra over the rationals: a rational
+// number type which never overflows, and matrices with Gaussian elimination,
+// linear system solving and the integer Hermite normal form.
+package linalg
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/antitoine/advent-of-code/aoc/numtheory"
+)
+
+// Rat is an exact rational number. It is held as an int64 fraction while it
+// fits, and as a big.Rat otherwise. The zero value is 0.
+type Rat struct {
+	// num and den are the reduced fraction when big is nil, den being
+	// positive, or 0 for the zero value.
+	num, den int64
+	big      *big.Rat
+}
+
+// NewRat returns the rational a/b, b being non zero.
+func NewRat(a, b int64) Rat {
+	if b == 0 {
+		panic("linalg: division by zero")
+	}
+	return fraction(a, b)
+}
+
+// Int returns the rational n.
+func Int(n int64) Rat {
+	return fraction(n, 1)
+}
+
+// FromBig returns the rational value of r.
+func FromBig(r *big.Rat) Rat {
+	// MinInt64 is kept big as it can't be negated.
+	if r.Num().IsInt64() && r.Denom().IsInt64() && r.Num().Int64() != math.MinInt64 {
+		return Rat{num: r.Num().Int64(), den: r.Denom().Int64()}
+	}
+	return Rat{big: new(big.Rat).Set(r)}
+}
+
+// fraction returns the reduced rational a/b, b being non zero.
+func fraction(a, b int64) Rat {
+	if a == math.MinInt64 || b == math.MinInt64 {
+		// They can't be negated nor reduced without overflowing.
+		return FromBig(big.NewRat(a, b))
+	}
+	if b < 0 {
+		a, b = -a, -b
+	}
+	if g := numtheory.GCD(a, b); g > 1 {
+		a, b = a/g, b/g
+	}
+	if a == 0 {
+		b = 1
+	}
+	return Rat{num: a, den: b}
+}
+
+func (r Rat) denom() int64 {
+	if r.den == 0 {
+		return 1
+	}
+	return r.den
+}
+
+// Big returns the value of r as a new big.Rat.
+func (r Rat) Big() *big.Rat {
+	if r.big != nil {
+		return new(big.Rat).Set(r.big)
+	}
+	return big.NewRat(r.num, r.denom())
+}
+
+// checked accumulates the overflows of a sequence of int64 operations.
+type checked struct {
+	overflow bool
+}
+
+func (c *checked) add(a, b int64) int64 {
+	sum, ok := numtheory.Add(a, b)
+	c.overflow = c.overflow || !ok
+	return sum
+}
+
+func (c *checked) mul(a, b int64) int64 {
+	product, ok := numtheory.Mul(a, b)
+	c.overflow = c.overflow || !ok
+	return product
+}
+
+// Add returns r+s.
+func (r Rat) Add(s Rat) Rat {
+	if r.big == nil && s.big == nil {
+		var c checked
+		num := c.add(c.mul(r.num, s.denom()), c.mul(s.num, r.denom()))
+		den := c.mul(r.denom(), s.denom())
+		if !c.overflow {
+			return fraction(num, den)
+		}
+	}
+	return FromBig(new(big.Rat).Add(r.Big(), s.Big()))
+}
+
+// Neg returns -r.
+func (r Rat) Neg() Rat {
+	if r.big == nil {
+		return Rat{num: -r.num, den: r.den}
+	}
+	return FromBig(new(big.Rat).Neg(r.Big()))
+}
+
+// Abs returns |r|.
+func (r Rat) Abs() Rat {
+	if r.Sign() < 0 {
+		return r.Neg()
+	}
+	return r
+}
+
+// Sub returns r-s.
+func (r Rat) Sub(s Rat) Rat {
+	return r.Add(s.Neg())
+}
+
+// Mul returns r*s.
+func (r Rat) Mul(s Rat) Rat {
+	if r.big == nil && s.big == nil {
+		// Cross reducing first keeps the products as small as possible.
+		g1, g2 := numtheory.GCD(r.num, s.denom()), numtheory.GCD(s.num, r.denom())
+		var c checked
+		num := c.mul(r.num/g1, s.num/g2)
+		den := c.mul(r.denom()/g2, s.denom()/g1)
+		if !c.overflow {
+			return fraction(num, den)
+		}
+	}
+	return FromBig(new(big.Rat).Mul(r.Big(), s.Big()))
+}
+
+// Inv returns 1/r, r being non zero.
+func (r Rat) Inv() Rat {
+	if r.Sign() == 0 {
+		panic("linalg: division by zero")
+	}
+	if r.big == nil {
+		return fraction(r.denom(), r.num)
+	}
+	return FromBig(new(big.Rat).Inv(r.big))
+}
+
+// Quo returns r/s, s being non zero.
+func (r Rat) Quo(s Rat) Rat {
+	return r.Mul(s.Inv())
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of r.
+func (r Rat) Sign() int {
+	switch {
+	case r.big != nil:
+		return r.big.Sign()
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	}
+	return 0
+}
+
+// Cmp returns -1, 0 or 1 when r is respectively lower, equal or greater than
+// s.
+func (r Rat) Cmp(s Rat) int {
+	if r.big == nil && s.big == nil {
+		var c checked
+		left, right := c.mul(r.num, s.denom()), c.mul(s.num, r.denom())
+		if !c.overflow {
+			switch {
+			case left < right:
+				return -1
+			case left > right:
+				return 1
+			}
+			return 0
+		}
+	}
+	return r.Big().Cmp(s.Big())
+}
+
+// IsInt reports whether r is an integer.
+func (r Rat) IsInt() bool {
+	if r.big != nil {
+		return r.big.IsInt()
+	}
+	return r.denom() == 1
+}
+
+// Int64 returns r as an int64, and false when it isn't an integer or doesn't
+// fit.
+func (r Rat) Int64() (int64, bool) {
+	if r.big != nil || r.denom() != 1 {
+		return 0, false
+	}
+	return r.num, true
+}
+
+// Floor returns the greatest integer not above r.
+func (r Rat) Floor() Rat {
+	if r.big == nil {
+		q := r.num / r.denom()
+		if r.num%r.denom() < 0 {
+			q--
+		}
+		return Int(q)
+	}
+	// The denominator is positive so the Euclidean division rounds down.
+	q := new(big.Int).Div(r.big.Num(), r.big.Denom())
+	return FromBig(new(big.Rat).SetInt(q))
+}
+
+func (r Rat) String() string {
+	return r.Big().RatString()
+}
@@ -0,0 +1,74 @@
+package linalg
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRatArithmetic(t *testing.T) {
+	half, third := NewRat(1, 2), NewRat(-2, -6)
+	tests := []struct {
+		name     string
+		result   Rat
+		expected string
+	}{
+		{"add", half.Add(third), "5/6"},
+		{"sub", third.Sub(half), "-1/6"},
+		{"mul", half.Mul(third), "1/6"},
+		{"quo", half.Quo(third), "3/2"},
+		{"neg", half.Neg(), "-1/2"},
+		{"inv", NewRat(-3, 4).Inv(), "-4/3"},
+		{"zero value", Rat{}.Add(Int(3)), "3"},
+		{"reduced", NewRat(6, -4), "-3/2"},
+		{"floor", NewRat(-7, 2).Floor(), "-4"},
+		{"floor integer", Int(5).Floor(), "5"},
+	}
+	for _, test := range tests {
+		if s := test.result.String(); s != test.expected {
+			t.Errorf("Expected %s to be %s, got %s", test.name, test.expected, s)
+		}
+	}
+}
+
+func TestRatOverflow(t *testing.T) {
+	large := Int(math.MaxInt64)
+	square := large.Mul(large)
+	expected := new(big.Rat).Mul(big.NewRat(math.MaxInt64, 1), big.NewRat(math.MaxInt64, 1))
+	if square.Big().Cmp(expected) != 0 {
+		t.Fatalf("Expected %s, got %s", expected.RatString(), square)
+	}
+	if _, fits := square.Int64(); fits {
+		t.Errorf("Expected %s not to fit in an int64", square)
+	}
+	if !square.IsInt() {
+		t.Errorf("Expected %s to be an integer", square)
+	}
+	// Going back to small values works from big ones.
+	back := square.Quo(large)
+	if value, fits := back.Int64(); !fits || value != math.MaxInt64 {
+		t.Errorf("Expected MaxInt64, got %s", back)
+	}
+	if sum := large.Add(Int(1)).Sub(Int(2)); sum.Cmp(Int(math.MaxInt64-1)) != 0 {
+		t.Errorf("Expected MaxInt64-1, got %s", sum)
+	}
+	if negated := Int(math.MinInt64).Neg(); negated.Big().Cmp(new(big.Rat).Neg(big.NewRat(math.MinInt64, 1))) != 0 {
+		t.Errorf("Expected 2^63, got %s", negated)
+	}
+	tiny := NewRat(1, math.MaxInt64).Mul(NewRat(1, math.MaxInt64-1))
+	if tiny.Sign() != 1 || tiny.Cmp(NewRat(1, math.MaxInt64)) != -1 {
+		t.Errorf("Expected a tiny positive number, got %s", tiny)
+	}
+}
+
+func TestRatCmp(t *testing.T) {
+	if NewRat(1, 3).Cmp(NewRat(2, 6)) != 0 {
+		t.Errorf("Expected 1/3 to equal 2/6")
+	}
+	if NewRat(-1, 2).Cmp(NewRat(1, 3)) != -1 {
+		t.Errorf("Expected -1/2 to be lower than 1/3")
+	}
+	if Int(math.MaxInt64).Cmp(NewRat(math.MaxInt64-1, 3)) != 1 {
+		t.Errorf("Expected MaxInt64 to be greater than (MaxInt64-1)/3")
+	}
+}
@@ -0,0 +1,249 @@
+// Package numtheory provides the number theory tools needed by the puzzles
+// aligning cycles: greatest common divisors, least common multiples, modular
+// arithmetic and the Chinese remainder theorem.
+//
+// The int64 functions never overflow silently: they return ErrOverflow when
+// a result doesn't fit, and the Big variants compute it with math/big instead.
+package numtheory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+var (
+	// ErrOverflow is returned when a result doesn't fit in an int64.
+	ErrOverflow = errors.New("numtheory: int64 overflow")
+	// ErrNoSolution is returned when a system of congruences has no solution.
+	ErrNoSolution = errors.New("numtheory: no solution")
+	// ErrNotInvertible is returned when a number has no inverse modulo
+	// another.
+	ErrNotInvertible = errors.New("numtheory: not invertible")
+)
+
+// Add returns a+b, and false when the sum overflows.
+func Add(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// Mul returns a*b, and false when the product overflows.
+func Mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+func abs(a int64) int64 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// GCD returns the greatest common divisor of a and b, which is never
+// negative. GCD(0, 0) is 0.
+func GCD(a, b int64) int64 {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// GCDOf returns the greatest common divisor of all the values, 0 when there
+// are none.
+func GCDOf(values ...int64) int64 {
+	var g int64
+	for _, value := range values {
+		g = GCD(g, value)
+	}
+	return g
+}
+
+// LCM returns the least common multiple of a and b, which is never negative.
+// LCM(a, 0) is 0.
+func LCM(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	lcm, ok := Mul(abs(a)/GCD(a, b), abs(b))
+	if !ok {
+		return 0, fmt.Errorf("%w: lcm(%d, %d)", ErrOverflow, a, b)
+	}
+	return lcm, nil
+}
+
+// LCMOf returns the least common multiple of all the values, 1 when there
+// are none.
+func LCMOf(values ...int64) (int64, error) {
+	lcm := int64(1)
+	for _, value := range values {
+		var errOverflow error
+		if lcm, errOverflow = LCM(lcm, value); errOverflow != nil {
+			return 0, errOverflow
+		}
+	}
+	return lcm, nil
+}
+
+// LCMBig returns the least common multiple of all the values, 1 when there
+// are none.
+func LCMBig(values ...int64) *big.Int {
+	lcm := big.NewInt(1)
+	var g, v big.Int
+	for _, value := range values {
+		if value == 0 {
+			return new(big.Int)
+		}
+		v.Abs(v.SetInt64(value))
+		g.GCD(nil, nil, lcm, &v)
+		lcm.Mul(lcm.Quo(lcm, &g), &v)
+	}
+	return lcm
+}
+
+// ExtendedGCD returns the greatest common divisor g of a and b, and the
+// coefficients x and y of Bézout's identity a*x + b*y = g.
+func ExtendedGCD(a, b int64) (g, x, y int64) {
+	oldR, r := a, b
+	oldX, x := int64(1), int64(0)
+	oldY, y := int64(0), int64(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// Mod returns a modulo m in [0, m), m being positive.
+func Mod(a, m int64) int64 {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// MulMod returns a*b modulo m in [0, m), m being positive, without
+// overflowing.
+func MulMod(a, b, m int64) int64 {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	_, r := bits.Div64(hi, lo, uint64(m))
+	return int64(r)
+}
+
+// PowMod returns base^exp modulo m in [0, m), m being positive and exp not
+// negative.
+func PowMod(base, exp, m int64) int64 {
+	if exp < 0 {
+		panic(fmt.Sprintf("numtheory: negative exponent %d", exp))
+	}
+	result := Mod(1, m)
+	base = Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = MulMod(result, base, m)
+		}
+		base = MulMod(base, base, m)
+	}
+	return result
+}
+
+// InverseMod returns the inverse of a modulo m in [0, m), m being positive.
+func InverseMod(a, m int64) (int64, error) {
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, fmt.Errorf("%w: %d modulo %d", ErrNotInvertible, a, m)
+	}
+	return Mod(x, m), nil
+}
+
+// Congruence is the equation x ≡ Residue (mod Modulus).
+type Congruence struct {
+	Residue int64
+	Modulus int64
+}
+
+// AtLeast returns the smallest solution of the congruence not below n.
+func (c Congruence) AtLeast(n int64) int64 {
+	return n + Mod(c.Residue-n, c.Modulus)
+}
+
+func (c Congruence) String() string {
+	return fmt.Sprintf("x ≡ %d (mod %d)", c.Residue, c.Modulus)
+}
+
+// CRT solves a system of congruences with the Chinese remainder theorem,
+// the moduli not having to be coprime. The solutions are the congruence
+// returned, whose residue is the smallest non negative one and modulus the
+// least common multiple of the moduli. An empty system is solved by any
+// number, as x ≡ 0 (mod 1).
+func CRT(congruences ...Congruence) (Congruence, error) {
+	solution := Congruence{Residue: 0, Modulus: 1}
+	for _, c := range congruences {
+		if c.Modulus <= 0 {
+			return Congruence{}, fmt.Errorf("numtheory: invalid modulus in %s", c)
+		}
+		residue := Mod(c.Residue, c.Modulus)
+		g, x, _ := ExtendedGCD(solution.Modulus, c.Modulus)
+		diff := residue - solution.Residue
+		if diff%g != 0 {
+			return Congruence{}, fmt.Errorf("%w: %s and %s", ErrNoSolution, solution, c)
+		}
+		step := c.Modulus / g
+		modulus, ok := Mul(solution.Modulus, step)
+		if !ok {
+			return Congruence{}, fmt.Errorf("%w: lcm(%d, %d)", ErrOverflow, solution.Modulus, c.Modulus)
+		}
+		// solution.Modulus * x ≡ g (mod c.Modulus), so adding k times the
+		// previous modulus to the previous residue solves both congruences.
+		k := MulMod(diff/g, x, step)
+		solution = Congruence{
+			Residue: Mod(solution.Residue+MulMod(solution.Modulus, k, modulus), modulus),
+			Modulus: modulus,
+		}
+	}
+	return solution, nil
+}
+
+// CRTBig is CRT computed with math/big, returning the residue and modulus of
+// the solutions.
+func CRTBig(congruences ...Congruence) (residue, modulus *big.Int, err error) {
+	residue, modulus = new(big.Int), big.NewInt(1)
+	var g, x, m, r, diff, k big.Int
+	for _, c := range congruences {
+		if c.Modulus <= 0 {
+			return nil, nil, fmt.Errorf("numtheory: invalid modulus in %s", c)
+		}
+		m.SetInt64(c.Modulus)
+		r.Mod(r.SetInt64(c.Residue), &m)
+		g.GCD(&x, nil, modulus, &m)
+		diff.Sub(&r, residue)
+		if k.Mod(&diff, &g).Sign() != 0 {
+			return nil, nil, fmt.Errorf("%w: x ≡ %s (mod %s) and %s", ErrNoSolution, residue, modulus, c)
+		}
+		step := new(big.Int).Quo(&m, &g)
+		k.Mod(k.Mul(k.Quo(&diff, &g), &x), step)
+		residue.Add(residue, k.Mul(&k, modulus))
+		modulus.Mul(modulus, step)
+		residue.Mod(residue, modulus)
+	}
+	return residue, modulus, nil
+}
@@ -0,0 +1,164 @@
+package numtheory
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	if sum, ok := Add(math.MaxInt64-1, 1); !ok || sum != math.MaxInt64 {
+		t.Errorf("Expected MaxInt64, got %d %t", sum, ok)
+	}
+	if _, ok := Add(math.MaxInt64, 1); ok {
+		t.Errorf("Expected an overflow adding 1 to MaxInt64")
+	}
+	if _, ok := Add(math.MinInt64, -1); ok {
+		t.Errorf("Expected an overflow subtracting 1 from MinInt64")
+	}
+	if product, ok := Mul(-3037000499, 3037000499); !ok || product != -9223372030926249001 {
+		t.Errorf("Expected -9223372030926249001, got %d %t", product, ok)
+	}
+	if _, ok := Mul(3037000500, 3037000500); ok {
+		t.Errorf("Expected an overflow multiplying 3037000500 by itself")
+	}
+	if _, ok := Mul(-1, math.MinInt64); ok {
+		t.Errorf("Expected an overflow negating MinInt64")
+	}
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	if g := GCD(-12, 18); g != 6 {
+		t.Errorf("Expected gcd 6, got %d", g)
+	}
+	if g := GCDOf(84, 126, 210); g != 42 {
+		t.Errorf("Expected gcd 42, got %d", g)
+	}
+	if g := GCDOf(); g != 0 {
+		t.Errorf("Expected gcd 0 of nothing, got %d", g)
+	}
+	lcm, err := LCMOf(4, 6, -10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lcm != 60 {
+		t.Errorf("Expected lcm 60, got %d", lcm)
+	}
+	if _, err := LCMOf(1<<40, 3<<30, 5<<30); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := LCMOf(1<<40-87, 1<<40-111); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	expected, _ := new(big.Int).SetString("1208925819396925872416185", 10)
+	if lcm := LCMBig(1<<40-87, 1<<40-111); lcm.Cmp(expected) != 0 {
+		t.Errorf("Expected lcm %s, got %s", expected, lcm)
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, test := range [][2]int64{{240, 46}, {46, 240}, {-240, 46}, {17, 0}, {0, 0}} {
+		a, b := test[0], test[1]
+		g, x, y := ExtendedGCD(a, b)
+		if g != GCD(a, b) || a*x+b*y != g {
+			t.Errorf("Expected %d*x+%d*y = %d, got g=%d x=%d y=%d", a, b, GCD(a, b), g, x, y)
+		}
+	}
+}
+
+func TestModular(t *testing.T) {
+	if r := Mod(-7, 3); r != 2 {
+		t.Errorf("Expected -7 mod 3 to be 2, got %d", r)
+	}
+	if r := MulMod(math.MaxInt64-1, math.MaxInt64-2, math.MaxInt64); r != 2 {
+		t.Errorf("Expected 2, got %d", r)
+	}
+	if r := PowMod(4, 13, 497); r != 445 {
+		t.Errorf("Expected 4^13 mod 497 to be 445, got %d", r)
+	}
+	if r := PowMod(3, 0, 1); r != 0 {
+		t.Errorf("Expected 3^0 mod 1 to be 0, got %d", r)
+	}
+	inverse, err := InverseMod(-3, 11)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if inverse != 7 {
+		t.Errorf("Expected the inverse of -3 mod 11 to be 7, got %d", inverse)
+	}
+	if _, err := InverseMod(6, 9); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Expected ErrNotInvertible, got %v", err)
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name        string
+		congruences []Congruence
+		expected    Congruence
+		expectedErr error
+	}{
+		{"empty", nil, Congruence{0, 1}, nil},
+		{"coprime", []Congruence{{2, 3}, {3, 5}, {2, 7}}, Congruence{23, 105}, nil},
+		{"not coprime", []Congruence{{3, 4}, {5, 6}}, Congruence{11, 12}, nil},
+		{"negative residue", []Congruence{{-1, 4}, {-1, 6}}, Congruence{11, 12}, nil},
+		{"aligned cycles", []Congruence{{0, 3}, {0, 4}, {0, 6}}, Congruence{0, 12}, nil},
+		{"no solution", []Congruence{{1, 4}, {2, 6}}, Congruence{}, ErrNoSolution},
+		{"overflow", []Congruence{{1, 1<<40 - 87}, {2, 1<<40 - 111}}, Congruence{}, ErrOverflow},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solution, err := CRT(test.congruences...)
+			if !errors.Is(err, test.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", test.expectedErr, err)
+			}
+			if solution != test.expected {
+				t.Errorf("Expected %s, got %s", test.expected, solution)
+			}
+			residue, modulus, errBig := CRTBig(test.congruences...)
+			if test.expectedErr == ErrNoSolution {
+				if !errors.Is(errBig, ErrNoSolution) {
+					t.Errorf("Expected ErrNoSolution with big numbers, got %v", errBig)
+				}
+				return
+			}
+			if errBig != nil {
+				t.Fatalf("Unexpected error: %v", errBig)
+			}
+			for _, c := range test.congruences {
+				r := new(big.Int).Mod(residue, big.NewInt(c.Modulus))
+				if r.Cmp(big.NewInt(Mod(c.Residue, c.Modulus))) != 0 {
+					t.Errorf("Expected %s to solve %s", residue, c)
+				}
+			}
+			if test.expectedErr == nil && (!residue.IsInt64() || residue.Int64() != solution.Residue || modulus.Int64() != solution.Modulus) {
+				t.Errorf("Expected x ≡ %s (mod %s) to be %s", residue, modulus, solution)
+			}
+		})
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	c := Congruence{Residue: 2, Modulus: 5}
+	for n, expected := range map[int64]int64{-4: -3, 0: 2, 2: 2, 3: 7, 12: 12} {
+		if solution := c.AtLeast(n); solution != expected {
+			t.Errorf("Expected the solution of %s from %d to be %d, got %d", c, n, expected, solution)
+		}
+	}
+}
+
+func TestCRTLargeModuli(t *testing.T) {
+	// The moduli are close to 2^31 so their product is close to 2^62, and
+	// the intermediate products would overflow without MulMod.
+	congruences := []Congruence{{123456789, 2147483647}, {987654321, 2147483629}}
+	solution, err := CRT(congruences...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, c := range congruences {
+		if solution.Residue%c.Modulus != c.Residue {
+			t.Errorf("Expected %s to solve %s", solution, c)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package aoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is something a solution reports about its internals while solving a
+// part, such as the intermediate values it finds.
+type Event interface {
+	// Kind names the type of the event in the JSON lines.
+	Kind() string
+	String() string
+}
+
+// PhaseStart is emitted when a solution starts one of its phases.
+type PhaseStart struct {
+	Phase string `json:"phase"`
+}
+
+// PhaseEnd is emitted when a solution ends one of its phases.
+type PhaseEnd struct {
+	Phase    string        `json:"phase"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Value is an intermediate value found by a solution.
+type Value struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// CycleDetected is emitted when a solution finds a repeating sequence, which
+// enters its cycle after Tail steps and repeats every Period steps.
+type CycleDetected struct {
+	Name   string `json:"name"`
+	Tail   int    `json:"tail"`
+	Period int    `json:"period"`
+}
+
+// GridSnapshot is the state of a grid at some point of a solution, one
+// string per row.
+type GridSnapshot struct {
+	Name string   `json:"name"`
+	Rows []string `json:"rows"`
+}
+
+func (PhaseStart) Kind() string    { return "phase_start" }
+func (PhaseEnd) Kind() string      { return "phase_end" }
+func (Value) Kind() string         { return "value" }
+func (CycleDetected) Kind() string { return "cycle" }
+func (GridSnapshot) Kind() string  { return "grid" }
+
+func (e PhaseStart) String() string {
+	return fmt.Sprintf("%s started", e.Phase)
+}
+
+func (e PhaseEnd) String() string {
+	return fmt.Sprintf("%s done in %s", e.Phase, e.Duration)
+}
+
+func (e Value) String() string {
+	return fmt.Sprintf("%s: %v", e.Name, e.Value)
+}
+
+func (e CycleDetected) String() string {
+	return fmt.Sprintf("%s cycles every %d after %d", e.Name, e.Period, e.Tail)
+}
+
+func (e GridSnapshot) String() string {
+	return e.Name + ":\n" + strings.Join(e.Rows, "\n")
+}
+
+// Observer receives the events of the solutions.
+type Observer interface {
+	Observe(Event)
+}
+
+// ObserverFunc is a function observing the events.
+type ObserverFunc func(Event)
+
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+// Quiet is an observer ignoring every event, the one used by default.
+var Quiet Observer = quietObserver{}
+
+// quietObserver is comparable, unlike the functions, for Quiet to be
+// recognized.
+type quietObserver struct{}
+
+func (quietObserver) Observe(Event) {}
+
+// Observers returns an observer passing the events to each observer, in
+// order. The quiet ones are left out, Quiet being returned when all of them
+// are.
+func Observers(observers ...Observer) Observer {
+	var kept multiObserver
+	for _, observer := range observers {
+		if observer != Quiet {
+			kept = append(kept, observer)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return Quiet
+	case 1:
+		return kept[0]
+	}
+	return kept
+}
+
+type multiObserver []Observer
+
+func (o multiObserver) Observe(event Event) {
+	for _, observer := range o {
+		observer.Observe(event)
+	}
+}
+
+type writerObserver struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	format func(Event) ([]byte, error)
+}
+
+func (o *writerObserver) Observe(event Event) {
+	line, errFormatting := o.format(event)
+	if errFormatting != nil {
+		line = []byte(fmt.Sprintf("unable to format %s event: %v", event.Kind(), errFormatting))
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.writer.Write(append(line, '\n'))
+}
+
+// NewTextObserver returns an observer writing the events for a human to read.
+func NewTextObserver(writer io.Writer) Observer {
+	return &writerObserver{writer: writer, format: func(event Event) ([]byte, error) {
+		return []byte(event.String()), nil
+	}}
+}
+
+// NewJSONObserver returns an observer writing the events as JSON lines, each
+// one holding the fields of the event along with its kind.
+func NewJSONObserver(writer io.Writer) Observer {
+	return &writerObserver{writer: writer, format: func(event Event) ([]byte, error) {
+		fields, errMarshaling := json.Marshal(event)
+		if errMarshaling != nil {
+			return nil, errMarshaling
+		}
+		line := fmt.Sprintf(`{"event":%q`, event.Kind())
+		if len(fields) > 2 {
+			line += "," + string(fields[1:len(fields)-1])
+		}
+		return []byte(line + "}"), nil
+	}}
+}
+
+type observerKey struct{}
+
+// WithObserver returns a context passing the events of the solutions to the
+// observer.
+func WithObserver(ctx context.Context, observer Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// ObserverFrom returns the observer of the context, Quiet if there is none.
+func ObserverFrom(ctx context.Context) Observer {
+	if observer, ok := ctx.Value(observerKey{}).(Observer); ok {
+		return observer
+	}
+	return Quiet
+}
+
+// Observing reports whether the context has an observer of the events, for
+// the solutions to skip building the costly ones, such as the snapshots of
+// every step of a simulation, when no one looks at them.
+func Observing(ctx context.Context) bool {
+	return ObserverFrom(ctx) != Quiet
+}
+
+// Emit passes an event to the observer of the context.
+func Emit(ctx context.Context, event Event) {
+	ObserverFrom(ctx).Observe(event)
+}
+
+// Phase emits the start of a phase, and returns the function emitting its
+// end.
+func Phase(ctx context.Context, phase string) func() {
+	start := time.Now()
+	Emit(ctx, PhaseStart{Phase: phase})
+	return func() {
+		Emit(ctx, PhaseEnd{Phase: phase, Duration: time.Since(start)})
+	}
+}
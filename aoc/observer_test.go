@@ -0,0 +1,75 @@
+package aoc
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestObservers(t *testing.T) {
+	events := []Event{
+		PhaseStart{Phase: "parse"},
+		Value{Name: "count", Value: 42},
+		CycleDetected{Name: "rx", Tail: 2, Period: 6},
+		GridSnapshot{Name: "platform", Rows: []string{"#.", ".#"}},
+	}
+
+	var text, jsonLines strings.Builder
+	for _, observer := range []Observer{NewTextObserver(&text), NewJSONObserver(&jsonLines)} {
+		ctx := WithObserver(context.Background(), observer)
+		for _, event := range events {
+			Emit(ctx, event)
+		}
+	}
+
+	expectedText := "parse started\ncount: 42\nrx cycles every 6 after 2\nplatform:\n#.\n.#\n"
+	if text.String() != expectedText {
+		t.Errorf("Expected the text events:\n%s\ngot:\n%s", expectedText, text.String())
+	}
+	expectedJSON := `{"event":"phase_start","phase":"parse"}
+{"event":"value","name":"count","value":42}
+{"event":"cycle","name":"rx","tail":2,"period":6}
+{"event":"grid","name":"platform","rows":["#.",".#"]}
+`
+	if jsonLines.String() != expectedJSON {
+		t.Errorf("Expected the JSON events:\n%s\ngot:\n%s", expectedJSON, jsonLines.String())
+	}
+}
+
+func TestPhase(t *testing.T) {
+	var kinds []string
+	ctx := WithObserver(context.Background(), ObserverFunc(func(event Event) {
+		kinds = append(kinds, event.Kind())
+	}))
+	end := Phase(ctx, "search")
+	end()
+	if strings.Join(kinds, " ") != "phase_start phase_end" {
+		t.Errorf("Expected a phase start and end, got %v", kinds)
+	}
+
+	// Without an observer, the events are dropped.
+	Emit(context.Background(), Value{Name: "ignored"})
+}
+
+func TestObserversCombined(t *testing.T) {
+	var first, second []string
+	record := func(events *[]string) Observer {
+		return ObserverFunc(func(event Event) { *events = append(*events, event.Kind()) })
+	}
+	if Observers(Quiet, Quiet) != Quiet {
+		t.Errorf("Expected quiet observers to be combined into Quiet")
+	}
+	if Observing(context.Background()) || Observing(WithObserver(context.Background(), Observers(Quiet))) {
+		t.Errorf("Expected no observer to be observing")
+	}
+
+	ctx := WithObserver(context.Background(), Observers(record(&first), Quiet, record(&second)))
+	if !Observing(ctx) {
+		t.Errorf("Expected the observers to be observing")
+	}
+	Emit(ctx, Value{Name: "count", Value: 1})
+	Emit(ctx, PhaseStart{Phase: "parse"})
+	if strings.Join(first, ",") != "value,phase_start" || strings.Join(second, ",") != "value,phase_start" {
+		t.Errorf("Expected both observers to get both events, got %v and %v", first, second)
+	}
+}
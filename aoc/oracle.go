@@ -0,0 +1,70 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoOracle is returned when comparing a part of a solver with its oracle
+// while it has none.
+var ErrNoOracle = errors.New("no oracle")
+
+// Oracle returns the reference solution of the given part, nil if there is
+// none.
+func (s Solver) Oracle(part int) PartFunc {
+	switch part {
+	case 1:
+		return s.Oracle1
+	case 2:
+		return s.Oracle2
+	}
+	return nil
+}
+
+// Mismatch is a disagreement between a solution and its oracle. A failure of
+// one of them is reported as its error message, an answer being expected from
+// both or from neither.
+type Mismatch struct {
+	Part     int
+	Answer   string
+	Expected string
+	// OracleErr is the failure of the oracle, nil if it answered.
+	OracleErr error
+}
+
+func (m *Mismatch) Error() string {
+	return fmt.Sprintf("part %d answered %s, the oracle answered %s", m.Part, m.Answer, m.Expected)
+}
+
+// CompareOracle solves the part with the solution and with its oracle, and
+// returns a Mismatch when they disagree. The context bounds the time of both,
+// a solution or an oracle stopped by it being reported as such rather than as
+// a disagreement.
+func (s Solver) CompareOracle(ctx context.Context, part int, input []byte) error {
+	oracle := s.Oracle(part)
+	if oracle == nil {
+		return ErrNoOracle
+	}
+	answer, errSolving := s.SolveContext(ctx, part, bytes.NewReader(input))
+	expected, errOracle := oracle(ctx, bytes.NewReader(input))
+	if errors.Is(errSolving, context.DeadlineExceeded) {
+		return fmt.Errorf("part %d: %w", part, errSolving)
+	}
+	if errors.Is(errOracle, context.DeadlineExceeded) {
+		return fmt.Errorf("oracle of part %d: %w", part, errOracle)
+	}
+	mismatch := &Mismatch{Part: part, Answer: answer.String(), Expected: expected.String()}
+	if errSolving != nil {
+		mismatch.Answer = fmt.Sprintf("error %q", errSolving)
+	}
+	if errOracle != nil {
+		mismatch.Expected = fmt.Sprintf("error %q", errOracle)
+		mismatch.OracleErr = errOracle
+	}
+	if (errSolving != nil) != (errOracle != nil) || errSolving == nil && mismatch.Answer != mismatch.Expected {
+		return mismatch
+	}
+	return nil
+}
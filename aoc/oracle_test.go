@@ -0,0 +1,54 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCompareOracle(t *testing.T) {
+	count := func(_ context.Context, input io.Reader) (Answer, error) {
+		content, err := io.ReadAll(input)
+		return Int(len(content)), err
+	}
+	wrong := func(_ context.Context, input io.Reader) (Answer, error) {
+		return Int(3), nil
+	}
+	failing := func(_ context.Context, input io.Reader) (Answer, error) {
+		return Answer{}, errors.New("no answer")
+	}
+	endless := func(ctx context.Context, input io.Reader) (Answer, error) {
+		<-ctx.Done()
+		return Answer{}, Canceled(ctx, Answer{})
+	}
+
+	solver := Solver{Year: 1999, Day: 5, Part1: wrong, Oracle1: count, Part2: failing, Oracle2: failing}
+	if err := solver.CompareOracle(context.Background(), 1, []byte("abc")); err != nil {
+		t.Errorf("Expected an agreement, got %v", err)
+	}
+	var mismatch *Mismatch
+	if err := solver.CompareOracle(context.Background(), 1, []byte("ab")); !errors.As(err, &mismatch) {
+		t.Fatalf("Expected a mismatch, got %v", err)
+	}
+	if mismatch.Answer != "3" || mismatch.Expected != "2" {
+		t.Errorf("Expected the answers 3 and 2, got %s and %s", mismatch.Answer, mismatch.Expected)
+	}
+	if err := solver.CompareOracle(context.Background(), 2, nil); err != nil {
+		t.Errorf("Expected two failures to agree, got %v", err)
+	}
+
+	solver = Solver{Year: 1999, Day: 5, Part1: failing, Oracle1: count, Part2: endless, Oracle2: count}
+	if err := solver.CompareOracle(context.Background(), 1, nil); !errors.As(err, &mismatch) {
+		t.Errorf("Expected a failure of the solution only to be a mismatch, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := solver.CompareOracle(ctx, 2, nil); !errors.Is(err, context.DeadlineExceeded) || errors.As(err, &mismatch) {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if err := (Solver{Year: 1999, Day: 6, Part1: count}).CompareOracle(context.Background(), 1, nil); !errors.Is(err, ErrNoOracle) {
+		t.Errorf("Expected ErrNoOracle, got %v", err)
+	}
+}
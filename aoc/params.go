@@ -0,0 +1,47 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+)
+
+// Params are named values tuning a solution, such as the size of the grid
+// which differs between the examples and the real inputs.
+type Params map[string]string
+
+type paramsKey struct{}
+
+// WithParams returns a context passing the parameters to the solutions.
+func WithParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+// ParamsFrom returns the parameters of the context, nil if there are none.
+func ParamsFrom(ctx context.Context) Params {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	return params
+}
+
+// ParamInt returns the integer parameter of the context with the given name,
+// or the fallback when it isn't set.
+func ParamInt(ctx context.Context, name string, fallback int) (int, error) {
+	value, ok := ParamsFrom(ctx)[name]
+	if !ok {
+		return fallback, nil
+	}
+	result, errParsing := Atoi(value)
+	if errParsing != nil {
+		return 0, fmt.Errorf("parameter %s: %w", name, errParsing)
+	}
+	return result, nil
+}
+
+// ParamPositiveInt returns the integer parameter of the context with the given
+// name like ParamInt, refusing the values below 1 such as a negative size.
+func ParamPositiveInt(ctx context.Context, name string, fallback int) (int, error) {
+	result, errParam := ParamInt(ctx, name, fallback)
+	if errParam == nil && result < 1 {
+		return 0, fmt.Errorf("parameter %s: %w", name, Unexpected(ParamsFrom(ctx)[name], "a positive integer"))
+	}
+	return result, errParam
+}
@@ -0,0 +1,39 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParamInt(t *testing.T) {
+	ctx := WithParams(context.Background(), Params{"width": "11", "height": "tall"})
+	if width, err := ParamInt(ctx, "width", 101); err != nil || width != 11 {
+		t.Errorf("Expected the width to be 11, got %d and %v", width, err)
+	}
+	if depth, err := ParamInt(ctx, "depth", 3); err != nil || depth != 3 {
+		t.Errorf("Expected the fallback depth 3, got %d and %v", depth, err)
+	}
+	if _, err := ParamInt(ctx, "height", 103); err == nil {
+		t.Errorf("Expected an invalid height to be refused")
+	}
+	if width, err := ParamInt(context.Background(), "width", 101); err != nil || width != 101 {
+		t.Errorf("Expected the fallback width 101 without parameters, got %d and %v", width, err)
+	}
+}
+
+func TestParamPositiveInt(t *testing.T) {
+	ctx := WithParams(context.Background(), Params{"width": "11", "height": "0", "size": "-3"})
+	if width, err := ParamPositiveInt(ctx, "width", 101); err != nil || width != 11 {
+		t.Errorf("Expected the width to be 11, got %d and %v", width, err)
+	}
+	if depth, err := ParamPositiveInt(ctx, "depth", 3); err != nil || depth != 3 {
+		t.Errorf("Expected the fallback depth 3, got %d and %v", depth, err)
+	}
+	for _, name := range []string{"height", "size"} {
+		var parseErr *ParseError
+		if _, err := ParamPositiveInt(ctx, name, 1); !errors.As(err, &parseErr) {
+			t.Errorf("Expected the %s to be refused, got %v", name, err)
+		}
+	}
+}
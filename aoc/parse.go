@@ -0,0 +1,152 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports malformed puzzle input. Line and Column are 1-based and
+// left to 0 when unknown.
+type ParseError struct {
+	Line     int
+	Column   int
+	Text     string
+	Expected string
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, ", column %d", e.Column)
+		}
+		sb.WriteString(": ")
+	}
+	if e.Err == io.ErrUnexpectedEOF {
+		sb.WriteString("unexpected end of input")
+	} else {
+		fmt.Fprintf(&sb, "unexpected %q", e.Text)
+	}
+	if e.Expected != "" {
+		sb.WriteString(", expected ")
+		sb.WriteString(e.Expected)
+	}
+	if e.Err != nil && e.Err != io.ErrUnexpectedEOF {
+		sb.WriteString(": ")
+		sb.WriteString(e.Err.Error())
+	}
+	return sb.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Unexpected returns a ParseError for the given text. Its position is filled
+// by Scanner.Wrap once it reaches the line being parsed.
+func Unexpected(text, expected string) *ParseError {
+	return &ParseError{Text: text, Expected: expected}
+}
+
+// Atoi is strconv.Atoi returning a ParseError.
+func Atoi(text string) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, &ParseError{Text: text, Expected: "an integer", Err: unwrapNumError(err)}
+	}
+	return value, nil
+}
+
+// ParseInt is strconv.ParseInt in base 10 returning a ParseError.
+func ParseInt(text string, bitSize int) (int64, error) {
+	value, err := strconv.ParseInt(text, 10, bitSize)
+	if err != nil {
+		return 0, &ParseError{Text: text, Expected: fmt.Sprintf("a %d-bit integer", bitSize), Err: unwrapNumError(err)}
+	}
+	return value, nil
+}
+
+func unwrapNumError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
+
+// Scanner reads the input line by line, like bufio.Scanner, keeping track of
+// the current line to locate parse errors.
+type Scanner struct {
+	scanner *bufio.Scanner
+	line    int
+	text    string
+}
+
+func NewScanner(input io.Reader) *Scanner {
+	return &Scanner{scanner: bufio.NewScanner(input)}
+}
+
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+	s.line++
+	s.text = s.scanner.Text()
+	return true
+}
+
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Line returns the 1-based number of the current line.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
+
+// Wrap locates err on the current line. A ParseError without position gets
+// the current line and the column of its text, any other error is wrapped in
+// a ParseError covering the whole line.
+func (s *Scanner) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return &ParseError{Line: s.line, Column: 1, Text: s.text, Err: err}
+	}
+	located := *parseErr
+	if located.Line == 0 {
+		located.Line = s.line
+	}
+	if located.Column == 0 && located.Line == s.line {
+		if idx := strings.Index(s.text, located.Text); idx >= 0 {
+			located.Column = idx + 1
+		}
+	}
+	return &located
+}
+
+// Missing returns the error to report when the input ends before an expected
+// line, or the scanning error which stopped it.
+func (s *Scanner) Missing(expected string) error {
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return &ParseError{Line: s.line + 1, Expected: expected, Err: io.ErrUnexpectedEOF}
+}
+
+// Unexpected returns a ParseError for the current line.
+func (s *Scanner) Unexpected(expected string) error {
+	return &ParseError{Line: s.line, Column: 1, Text: s.text, Expected: expected}
+}
@@ -0,0 +1,68 @@
+package aoc
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestScannerWrap(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("1 2\n3 x4\n"))
+	var values []int
+	var err error
+	for scanner.Scan() && err == nil {
+		for _, field := range strings.Fields(scanner.Text()) {
+			value, errParsing := Atoi(field)
+			if errParsing != nil {
+				err = scanner.Wrap(errParsing)
+				break
+			}
+			values = append(values, value)
+		}
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	if parseErr.Line != 2 || parseErr.Column != 3 || parseErr.Text != "x4" {
+		t.Errorf("Expected error at line 2, column 3 on \"x4\", got %+v", parseErr)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected error to wrap strconv.ErrSyntax, got %v", parseErr.Err)
+	}
+	const expected = `line 2, column 3: unexpected "x4", expected an integer: invalid syntax`
+	if err.Error() != expected {
+		t.Errorf("Expected message to be %q, got %q", expected, err.Error())
+	}
+	if len(values) != 3 {
+		t.Errorf("Expected 3 values before the error, got %v", values)
+	}
+}
+
+func TestScannerUnexpected(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("ok\nko\n"))
+	scanner.Scan()
+	scanner.Scan()
+	err := scanner.Wrap(errors.New("boom"))
+	const expected = `line 2, column 1: unexpected "ko": boom`
+	if err.Error() != expected {
+		t.Errorf("Expected message to be %q, got %q", expected, err.Error())
+	}
+	err = scanner.Unexpected("a pair of numbers")
+	const expectedShape = `line 2, column 1: unexpected "ko", expected a pair of numbers`
+	if err.Error() != expectedShape {
+		t.Errorf("Expected message to be %q, got %q", expectedShape, err.Error())
+	}
+	scanner.Scan()
+	err = scanner.Missing("a third line")
+	const expectedMissing = `line 3: unexpected end of input, expected a third line`
+	if err.Error() != expectedMissing {
+		t.Errorf("Expected message to be %q, got %q", expectedMissing, err.Error())
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected error to wrap io.ErrUnexpectedEOF")
+	}
+}
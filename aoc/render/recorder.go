@@ -0,0 +1,117 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// Recorder is an observer collecting the grid snapshots of the solutions into
+// an animation per snapshot name, the other events being ignored.
+type Recorder struct {
+	Palette *Palette
+	Scale   int
+
+	mutex      sync.Mutex
+	names      []string
+	animations map[string]*Animation
+}
+
+// NewRecorder returns a recorder drawing the grids with the palette, each
+// cell being a square of scale pixels.
+func NewRecorder(palette *Palette, scale int) *Recorder {
+	return &Recorder{Palette: palette, Scale: scale, animations: make(map[string]*Animation)}
+}
+
+func (r *Recorder) Observe(event aoc.Event) {
+	snapshot, ok := event.(aoc.GridSnapshot)
+	if !ok {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	animation, found := r.animations[snapshot.Name]
+	if !found {
+		animation = NewAnimation(r.Palette, r.Scale)
+		r.animations[snapshot.Name] = animation
+		r.names = append(r.names, snapshot.Name)
+	}
+	animation.Add(snapshot.Rows)
+}
+
+// Names returns the names of the snapshots recorded, in the order they were
+// first seen.
+func (r *Recorder) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+// Animation returns the animation of the snapshots of the given name, nil if
+// there is none.
+func (r *Recorder) Animation(name string) *Animation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.animations[name]
+}
+
+// WriteFiles writes each animation into the directory, created if needed, as
+// an animated GIF when it has several states and as a PNG image otherwise.
+// The files are named after the prefix and the snapshot names, and their
+// paths are returned.
+func (r *Recorder) WriteFiles(dir, prefix string) ([]string, error) {
+	names := r.Names()
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if errCreating := os.MkdirAll(dir, 0o755); errCreating != nil {
+		return nil, errCreating
+	}
+	var paths []string
+	for _, name := range names {
+		animation := r.Animation(name)
+		write, extension := animation.WriteGIF, ".gif"
+		if animation.States() == 1 {
+			write, extension = animation.WritePNG, ".png"
+		}
+		path := filepath.Join(dir, prefix+"-"+FileName(name)+extension)
+		if errWriting := writeFile(path, write); errWriting != nil {
+			return paths, fmt.Errorf("unable to render %s: %w", name, errWriting)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	file, errCreating := os.Create(path)
+	if errCreating != nil {
+		return errCreating
+	}
+	errWriting := write(file)
+	if errClosing := file.Close(); errWriting == nil {
+		errWriting = errClosing
+	}
+	return errWriting
+}
+
+// FileName returns the name of a snapshot fit for a file name, its characters
+// other than letters, digits, dashes and underscores being replaced by
+// dashes.
+func FileName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	if sanitized == "" {
+		return "grid"
+	}
+	return sanitized
+}
@@ -0,0 +1,222 @@
+// Package render draws the grids of the solutions as images, each cell being
+// a square of pixels colored by a palette, and collects the successive states
+// of a simulation into an animated GIF.
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// fallbackColors are given in turn to the cells a palette doesn't know.
+var fallbackColors = []color.Color{
+	color.RGBA{0x4e, 0x79, 0xa7, 0xff},
+	color.RGBA{0xf2, 0x8e, 0x2b, 0xff},
+	color.RGBA{0xe1, 0x57, 0x59, 0xff},
+	color.RGBA{0x76, 0xb7, 0xb2, 0xff},
+	color.RGBA{0x59, 0xa1, 0x4f, 0xff},
+	color.RGBA{0xed, 0xc9, 0x48, 0xff},
+	color.RGBA{0xb0, 0x7a, 0xa1, 0xff},
+	color.RGBA{0xff, 0x9d, 0xa7, 0xff},
+	color.RGBA{0x9c, 0x75, 0x5f, 0xff},
+	color.RGBA{0xba, 0xb0, 0xac, 0xff},
+}
+
+// Palette maps the cells of the grids to colors. A cell it doesn't know gets
+// the next fallback color the first time it is seen, so that any grid can be
+// drawn. A palette holds 256 colors at most, the cells seen past them sharing
+// the last one.
+type Palette struct {
+	mutex  sync.Mutex
+	colors color.Palette
+	index  map[rune]uint8
+}
+
+// NewPalette returns a palette giving their color to the cells, the other
+// ones getting a fallback color.
+func NewPalette(colors map[rune]color.Color) *Palette {
+	cells := make([]rune, 0, len(colors))
+	for cell := range colors {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+	p := &Palette{index: make(map[rune]uint8)}
+	for _, cell := range cells {
+		p.add(cell, colors[cell])
+	}
+	return p
+}
+
+// Default returns a palette of the cells found in most puzzles: empty
+// spaces, walls, boxes, robots, starts and ends.
+func Default() *Palette {
+	return NewPalette(map[rune]color.Color{
+		'.': color.RGBA{0x10, 0x10, 0x18, 0xff},
+		'#': color.RGBA{0x80, 0x80, 0x88, 0xff},
+		'O': color.RGBA{0xc8, 0x8a, 0x3c, 0xff},
+		'[': color.RGBA{0xc8, 0x8a, 0x3c, 0xff},
+		']': color.RGBA{0xa0, 0x6c, 0x2c, 0xff},
+		'@': color.RGBA{0xff, 0xe0, 0x40, 0xff},
+		'S': color.RGBA{0x40, 0xd0, 0x60, 0xff},
+		'E': color.RGBA{0xe0, 0x40, 0x40, 0xff},
+	})
+}
+
+func (p *Palette) add(cell rune, c color.Color) uint8 {
+	if len(p.colors) == 256 {
+		p.index[cell] = 255
+		return 255
+	}
+	p.colors = append(p.colors, c)
+	p.index[cell] = uint8(len(p.colors) - 1)
+	return p.index[cell]
+}
+
+// Index returns the index of the color of the cell in the palette.
+func (p *Palette) Index(cell rune) uint8 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.indexLocked(cell)
+}
+
+func (p *Palette) indexLocked(cell rune) uint8 {
+	if index, ok := p.index[cell]; ok {
+		return index
+	}
+	return p.add(cell, fallbackColors[len(p.index)%len(fallbackColors)])
+}
+
+// Image draws the rows of a grid, each cell as a square of scale pixels, the
+// cells missing from the shorter rows having the first color of the palette.
+// The image holds a copy of the colors of the palette.
+func (p *Palette) Image(rows []string, scale int) *image.Paletted {
+	scale = max(scale, 1)
+	var width int
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row))
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	indexes := make([][]uint8, len(rows))
+	for y, row := range rows {
+		for _, cell := range row {
+			indexes[y] = append(indexes[y], p.indexLocked(cell))
+		}
+	}
+	img := image.NewPaletted(image.Rect(0, 0, width*scale, len(rows)*scale), append(color.Palette(nil), p.colors...))
+	for y, row := range indexes {
+		for x, index := range row {
+			for dy := 0; dy < scale; dy++ {
+				start := img.PixOffset(x*scale, y*scale+dy)
+				for dx := 0; dx < scale; dx++ {
+					img.Pix[start+dx] = index
+				}
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG draws the rows of a grid as a PNG image.
+func WritePNG(w io.Writer, rows []string, palette *Palette, scale int) error {
+	return png.Encode(w, palette.Image(rows, scale))
+}
+
+// ErrNoState is returned when writing an animation no state was added to.
+var ErrNoState = errors.New("no state to render")
+
+// DefaultMaxFrames is the number of frames an animation keeps at most.
+const DefaultMaxFrames = 500
+
+// Animation collects the successive states of a grid into the frames of an
+// animated GIF. Past MaxFrames, it only keeps one frame every two, then every
+// four and so on, so that long simulations still fit, the last state always
+// being shown.
+type Animation struct {
+	Palette *Palette
+	// Scale is the size of the cells in pixels.
+	Scale int
+	// Delay is the time each frame is shown, in hundredths of a second.
+	Delay     int
+	MaxFrames int
+
+	frames []*image.Paletted
+	// stride is the number of states between two kept frames.
+	stride int
+	states int
+	// last is the last state, and lastKept whether it is the last frame.
+	last     []string
+	lastKept bool
+}
+
+// NewAnimation returns an animation drawn with the palette, each cell being
+// a square of scale pixels.
+func NewAnimation(palette *Palette, scale int) *Animation {
+	return &Animation{Palette: palette, Scale: scale, Delay: 10, MaxFrames: DefaultMaxFrames, stride: 1}
+}
+
+// Add records a state of the grid, one string per row.
+func (a *Animation) Add(rows []string) {
+	a.states++
+	a.last = append(a.last[:0], rows...)
+	a.lastKept = (a.states-1)%a.stride == 0
+	if !a.lastKept {
+		return
+	}
+	a.frames = append(a.frames, a.Palette.Image(rows, a.Scale))
+	if len(a.frames) > max(a.MaxFrames, 1) {
+		a.lastKept = (len(a.frames)-1)%2 == 0
+		kept := a.frames[:0]
+		for i := 0; i < len(a.frames); i += 2 {
+			kept = append(kept, a.frames[i])
+		}
+		a.frames = kept
+		a.stride *= 2
+	}
+}
+
+// States returns the number of states added to the animation.
+func (a *Animation) States() int {
+	return a.states
+}
+
+// images returns the frames, ending with the last state.
+func (a *Animation) images() []*image.Paletted {
+	if a.lastKept || a.last == nil {
+		return a.frames
+	}
+	return append(append([]*image.Paletted(nil), a.frames...), a.Palette.Image(a.last, a.Scale))
+}
+
+// WriteGIF writes the frames as an animated GIF looping forever, the last
+// one being shown longer. The frames of various sizes are drawn from the top
+// left corner.
+func (a *Animation) WriteGIF(w io.Writer) error {
+	images := a.images()
+	if len(images) == 0 {
+		return ErrNoState
+	}
+	animation := &gif.GIF{Image: images, Delay: make([]int, len(images))}
+	for i, img := range images {
+		animation.Delay[i] = a.Delay
+		animation.Config.Width = max(animation.Config.Width, img.Bounds().Dx())
+		animation.Config.Height = max(animation.Config.Height, img.Bounds().Dy())
+	}
+	animation.Delay[len(images)-1] = 10 * a.Delay
+	return gif.EncodeAll(w, animation)
+}
+
+// WritePNG writes the last state as a PNG image.
+func (a *Animation) WritePNG(w io.Writer) error {
+	if a.states == 0 {
+		return ErrNoState
+	}
+	return WritePNG(w, a.last, a.Palette, a.Scale)
+}
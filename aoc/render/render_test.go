@@ -0,0 +1,109 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+func TestPaletteImage(t *testing.T) {
+	wall := color.RGBA{0xff, 0, 0, 0xff}
+	palette := NewPalette(map[rune]color.Color{'.': color.Black, '#': wall})
+	img := palette.Image([]string{"#.", "x"}, 2)
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 4 {
+		t.Fatalf("Expected a 4x4 image, got %v", img.Bounds())
+	}
+	if img.At(0, 0) != wall || img.At(1, 1) != wall || img.At(2, 0) != color.Black {
+		t.Errorf("Unexpected colors of the first row: %v %v %v", img.At(0, 0), img.At(1, 1), img.At(2, 0))
+	}
+	unknown := img.At(0, 2)
+	if unknown == wall || unknown == color.Black {
+		t.Errorf("Expected an unknown cell to get a fallback color, got %v", unknown)
+	}
+	if palette.Index('x') != img.ColorIndexAt(1, 3) {
+		t.Errorf("Expected the fallback color to be kept by the palette")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WritePNG(&buffer, []string{"#..", ".@."}, Default(), 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 9 || img.Bounds().Dy() != 6 {
+		t.Errorf("Expected a 9x6 image, got %v", img.Bounds())
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	animation := NewAnimation(Default(), 1)
+	if err := animation.WriteGIF(&bytes.Buffer{}); err != ErrNoState {
+		t.Errorf("Expected an empty animation to be refused, got %v", err)
+	}
+	animation.MaxFrames = 4
+	for i := 0; i < 10; i++ {
+		animation.Add([]string{strings.Repeat(".", i) + "@"})
+	}
+	if animation.States() != 10 {
+		t.Errorf("Expected 10 states, got %d", animation.States())
+	}
+
+	var buffer bytes.Buffer
+	if err := animation.WriteGIF(&buffer); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded, err := gif.DecodeAll(&buffer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The states 0, 4 and 8 are kept, then the last one.
+	widths := make([]int, len(decoded.Image))
+	for i, img := range decoded.Image {
+		widths[i] = img.Bounds().Dx()
+	}
+	if len(widths) != 4 || widths[0] != 1 || widths[1] != 5 || widths[2] != 9 || widths[3] != 10 {
+		t.Errorf("Unexpected frame widths %v", widths)
+	}
+	if decoded.Config.Width != 10 || decoded.Delay[3] != 10*decoded.Delay[0] {
+		t.Errorf("Unexpected width %d or delays %v", decoded.Config.Width, decoded.Delay)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder(Default(), 2)
+	ctx := aoc.WithObserver(context.Background(), recorder)
+	aoc.Emit(ctx, aoc.GridSnapshot{Name: "warehouse", Rows: []string{"#@"}})
+	aoc.Emit(ctx, aoc.Value{Name: "ignored", Value: 1})
+	aoc.Emit(ctx, aoc.GridSnapshot{Name: "final map", Rows: []string{"#."}})
+	aoc.Emit(ctx, aoc.GridSnapshot{Name: "warehouse", Rows: []string{"@#"}})
+	if names := recorder.Names(); len(names) != 2 || names[0] != "warehouse" || names[1] != "final map" {
+		t.Fatalf("Unexpected names %v", names)
+	}
+
+	dir := filepath.Join(t.TempDir(), "frames")
+	paths, err := recorder.WriteFiles(dir, "2024-15-part1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{filepath.Join(dir, "2024-15-part1-warehouse.gif"), filepath.Join(dir, "2024-15-part1-final-map.png")}
+	if len(paths) != 2 || paths[0] != expected[0] || paths[1] != expected[1] {
+		t.Fatalf("Expected the files %v, got %v", expected, paths)
+	}
+	for _, path := range paths {
+		if info, errStat := os.Stat(path); errStat != nil || info.Size() == 0 {
+			t.Errorf("Expected %s to be written: %v", path, errStat)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package search finds shortest paths in state spaces described by a
+// neighbour function, with Dijkstra, A* or a breadth first search.
+package search
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// ErrNoPath is returned when no goal state can be reached from the starts.
+var ErrNoPath = errors.New("search: no path to a goal")
+
+// Problem describes a state space to search.
+type Problem[S comparable] struct {
+	// Starts are the states the paths can start from, at no cost.
+	Starts []S
+	// Neighbours calls visit for each state reachable from state, with the
+	// cost of the move. Costs must not be negative, and must be positive when
+	// all the paths are recorded.
+	Neighbours func(state S, visit func(next S, cost int))
+	// Goal reports whether a state ends the search.
+	Goal func(state S) bool
+	// AllPaths records every optimal predecessor of the states, instead of a
+	// single one, so that all the optimal paths can be walked.
+	AllPaths bool
+}
+
+// Result is the outcome of a successful search.
+type Result[S comparable] struct {
+	// Cost is the cost of the optimal paths.
+	Cost int
+	// Path is one optimal path, from a start to a goal state included.
+	Path []S
+	// Goals are the goal states reached at the optimal cost. Only the first
+	// one is known unless the problem asked for all paths.
+	Goals []S
+	// Predecessors maps each state to the states leading to it on an optimal
+	// path. It is only filled when the problem asked for all paths.
+	Predecessors map[S][]S
+}
+
+// OnOptimalPaths returns the states of every optimal path, walking the
+// predecessors back from the goals. Without all paths recorded, it returns
+// the states of Path.
+func (r Result[S]) OnOptimalPaths() []S {
+	if r.Predecessors == nil {
+		return r.Path
+	}
+	seen := make(map[S]bool, len(r.Goals))
+	var states []S
+	pending := append([]S(nil), r.Goals...)
+	for len(pending) > 0 {
+		state := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[state] {
+			continue
+		}
+		seen[state] = true
+		states = append(states, state)
+		pending = append(pending, r.Predecessors[state]...)
+	}
+	return states
+}
+
+// Dijkstra returns the cheapest path from a start to a goal state.
+func Dijkstra[S comparable](p Problem[S]) (Result[S], error) {
+	return AStar(p, nil)
+}
+
+// AStar returns the cheapest path from a start to a goal state, exploring
+// first the states with the lowest cost plus heuristic. The heuristic must
+// never overestimate the remaining cost nor decrease by more than the cost of
+// a move, a nil heuristic behaving as Dijkstra.
+func AStar[S comparable](p Problem[S], heuristic func(state S) int) (Result[S], error) {
+	s := newSearch(p)
+	estimate := func(state S) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(state)
+	}
+	queue := &priorityQueue[S]{}
+	for _, start := range p.Starts {
+		if _, seen := s.costs[start]; !seen {
+			s.costs[start] = 0
+			heap.Push(queue, item[S]{state: start, priority: estimate(start)})
+		}
+	}
+
+	done := make(map[S]bool)
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[S])
+		if done[current.state] || current.cost > s.costs[current.state] {
+			continue
+		}
+		if len(s.goals) > 0 && current.priority > s.best {
+			break
+		}
+		done[current.state] = true
+		if p.Goal(current.state) {
+			s.goals = append(s.goals, current.state)
+			s.best = current.cost
+			if !p.AllPaths {
+				break
+			}
+			continue
+		}
+		p.Neighbours(current.state, func(next S, cost int) {
+			nextCost := current.cost + cost
+			if s.relax(current.state, next, nextCost) {
+				heap.Push(queue, item[S]{state: next, cost: nextCost, priority: nextCost + estimate(next)})
+			}
+		})
+	}
+	return s.result()
+}
+
+// BFS returns the shortest path from a start to a goal state, all the moves
+// having a cost of 1. The Neighbours function of the problem is called with
+// a cost to ignore.
+func BFS[S comparable](p Problem[S]) (Result[S], error) {
+	s := newSearch(p)
+	var queue []S
+	for _, start := range p.Starts {
+		if _, seen := s.costs[start]; !seen {
+			s.costs[start] = 0
+			queue = append(queue, start)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		cost := s.costs[current]
+		if len(s.goals) > 0 && cost > s.best {
+			break
+		}
+		if p.Goal(current) {
+			s.goals = append(s.goals, current)
+			s.best = cost
+			if !p.AllPaths {
+				break
+			}
+			continue
+		}
+		p.Neighbours(current, func(next S, _ int) {
+			if s.relax(current, next, cost+1) {
+				queue = append(queue, next)
+			}
+		})
+	}
+	return s.result()
+}
+
+// search holds the state shared by the algorithms.
+type search[S comparable] struct {
+	allPaths     bool
+	costs        map[S]int
+	parents      map[S]S
+	predecessors map[S][]S
+	goals        []S
+	best         int
+}
+
+func newSearch[S comparable](p Problem[S]) *search[S] {
+	return &search[S]{
+		allPaths:     p.AllPaths,
+		costs:        make(map[S]int),
+		parents:      make(map[S]S),
+		predecessors: make(map[S][]S),
+	}
+}
+
+// relax records a move from a state to the next one at the given total cost,
+// and reports whether it is the cheapest way found to reach the next state.
+func (s *search[S]) relax(from, next S, cost int) bool {
+	known, seen := s.costs[next]
+	switch {
+	case !seen || cost < known:
+		s.costs[next] = cost
+		s.parents[next] = from
+		if s.allPaths {
+			s.predecessors[next] = append(s.predecessors[next][:0], from)
+		}
+		return true
+	case cost == known && s.allPaths:
+		s.predecessors[next] = append(s.predecessors[next], from)
+	}
+	return false
+}
+
+func (s *search[S]) result() (Result[S], error) {
+	if len(s.goals) == 0 {
+		return Result[S]{}, ErrNoPath
+	}
+	result := Result[S]{Cost: s.best, Goals: s.goals}
+	for state, hasParent := s.goals[0], true; hasParent; state, hasParent = s.parents[state] {
+		result.Path = append(result.Path, state)
+	}
+	for i, j := 0, len(result.Path)-1; i < j; i, j = i+1, j-1 {
+		result.Path[i], result.Path[j] = result.Path[j], result.Path[i]
+	}
+	if s.allPaths {
+		result.Predecessors = s.predecessors
+	}
+	return result, nil
+}
+
+type item[S comparable] struct {
+	state    S
+	cost     int
+	priority int
+}
+
+type priorityQueue[S comparable] []item[S]
+
+func (q priorityQueue[S]) Len() int           { return len(q) }
+func (q priorityQueue[S]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q priorityQueue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue[S]) Push(x any) {
+	*q = append(*q, x.(item[S]))
+}
+
+func (q *priorityQueue[S]) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[0 : n-1]
+	return x
+}
@@ -0,0 +1,164 @@
+package search
+
+import (
+	"errors"
+	"image"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// maze is a small grid where '#' are walls, 'S' the start and 'E' the end.
+var maze = []string{
+	"S..#",
+	".#..",
+	"...E",
+}
+
+func mazeProblem(allPaths bool) Problem[image.Point] {
+	bounds := image.Rect(0, 0, len(maze[0]), len(maze))
+	return Problem[image.Point]{
+		Starts: []image.Point{{}},
+		Neighbours: func(p image.Point, visit func(image.Point, int)) {
+			for _, d := range []image.Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+				next := p.Add(d)
+				if next.In(bounds) && maze[next.Y][next.X] != '#' {
+					visit(next, 1)
+				}
+			}
+		},
+		Goal:     func(p image.Point) bool { return maze[p.Y][p.X] == 'E' },
+		AllPaths: allPaths,
+	}
+}
+
+func length(d image.Point) int {
+	return max(d.X, -d.X) + max(d.Y, -d.Y)
+}
+
+func manhattan(p image.Point) int {
+	return length(image.Pt(3, 2).Sub(p))
+}
+
+func checkPath(t *testing.T, result Result[image.Point], cost int) {
+	t.Helper()
+	if result.Cost != cost {
+		t.Errorf("Expected cost %d, got %d", cost, result.Cost)
+	}
+	if len(result.Path) != cost+1 {
+		t.Fatalf("Expected a path of %d states, got %v", cost+1, result.Path)
+	}
+	if result.Path[0] != (image.Point{}) || result.Path[cost] != image.Pt(3, 2) {
+		t.Errorf("Expected a path from the start to the end, got %v", result.Path)
+	}
+	for i := 1; i < len(result.Path); i++ {
+		if length(result.Path[i].Sub(result.Path[i-1])) != 1 {
+			t.Errorf("Expected adjacent states, got %v then %v", result.Path[i-1], result.Path[i])
+		}
+	}
+}
+
+func TestAlgorithms(t *testing.T) {
+	algorithms := map[string]func(Problem[image.Point]) (Result[image.Point], error){
+		"dijkstra": Dijkstra[image.Point],
+		"astar": func(p Problem[image.Point]) (Result[image.Point], error) {
+			return AStar(p, manhattan)
+		},
+		"bfs": BFS[image.Point],
+	}
+	for name, algorithm := range algorithms {
+		t.Run(name, func(t *testing.T) {
+			result, err := algorithm(mazeProblem(false))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			checkPath(t, result, 5)
+			if result.Predecessors != nil {
+				t.Errorf("Expected no predecessors without all paths, got %v", result.Predecessors)
+			}
+		})
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	for name, algorithm := range map[string]func(Problem[image.Point]) (Result[image.Point], error){
+		"dijkstra": Dijkstra[image.Point],
+		"bfs":      BFS[image.Point],
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := algorithm(mazeProblem(true))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			checkPath(t, result, 5)
+			states := result.OnOptimalPaths()
+			sort.Slice(states, func(i, j int) bool {
+				return states[i].Y < states[j].Y || (states[i].Y == states[j].Y && states[i].X < states[j].X)
+			})
+			expected := []image.Point{
+				{0, 0}, {1, 0}, {2, 0},
+				{0, 1}, {2, 1}, {3, 1},
+				{0, 2}, {1, 2}, {2, 2}, {3, 2},
+			}
+			if !reflect.DeepEqual(states, expected) {
+				t.Errorf("Expected states %v, got %v", expected, states)
+			}
+		})
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	// The direct edge is more expensive than the detour.
+	edges := map[string]map[string]int{
+		"a": {"b": 1, "d": 10},
+		"b": {"c": 2},
+		"c": {"d": 3},
+	}
+	result, err := Dijkstra(Problem[string]{
+		Starts: []string{"a"},
+		Neighbours: func(s string, visit func(string, int)) {
+			for next, cost := range edges[s] {
+				visit(next, cost)
+			}
+		},
+		Goal: func(s string) bool { return s == "d" },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Cost != 6 || !reflect.DeepEqual(result.Path, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected a path of cost 6 through b and c, got %d %v", result.Cost, result.Path)
+	}
+}
+
+func TestStartIsGoal(t *testing.T) {
+	result, err := BFS(Problem[int]{
+		Starts:     []int{3},
+		Neighbours: func(int, func(int, int)) {},
+		Goal:       func(n int) bool { return n == 3 },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Cost != 0 || !reflect.DeepEqual(result.Path, []int{3}) {
+		t.Errorf("Expected an empty path, got %d %v", result.Cost, result.Path)
+	}
+}
+
+func TestNoPath(t *testing.T) {
+	problem := Problem[int]{
+		Starts: []int{0},
+		Neighbours: func(n int, visit func(int, int)) {
+			if n < 10 {
+				visit(n+1, 1)
+			}
+		},
+		Goal: func(n int) bool { return n < 0 },
+	}
+	if _, err := Dijkstra(problem); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath from Dijkstra, got %v", err)
+	}
+	if _, err := BFS(problem); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath from BFS, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Shrink returns a smaller input for which failing still holds, by delta
+// debugging: it removes chunks of lines, then columns of the inputs made of
+// lines of the same length, then characters of the lines, for as long as one
+// of them keeps the input failing. It stops early, with the smallest input
+// found, when the context is done. The input must be failing.
+func Shrink(ctx context.Context, input []byte, failing func([]byte) bool) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(input), "\n"), "\n")
+	join := func(lines []string) []byte {
+		return []byte(strings.Join(lines, "\n") + "\n")
+	}
+
+	for shrunk := true; shrunk && ctx.Err() == nil; {
+		shrunk = false
+
+		kept := minimize(ctx, len(lines), func(indexes []int) bool {
+			return failing(join(pick(lines, indexes)))
+		})
+		if len(kept) < len(lines) {
+			lines, shrunk = pick(lines, kept), true
+		}
+
+		if width, rectangular := rectangularWidth(lines); rectangular {
+			kept = minimize(ctx, width, func(indexes []int) bool {
+				return failing(join(pickColumns(lines, indexes)))
+			})
+			if len(kept) < width {
+				lines, shrunk = pickColumns(lines, kept), true
+			}
+		}
+
+		for i := range lines {
+			line := lines[i]
+			kept = minimize(ctx, len(line), func(indexes []int) bool {
+				candidate := append([]string(nil), lines...)
+				candidate[i] = pickChars(line, indexes)
+				return failing(join(candidate))
+			})
+			if len(kept) < len(line) {
+				lines[i], shrunk = pickChars(line, kept), true
+			}
+		}
+	}
+	return join(lines)
+}
+
+// minimize returns a minimal subset of the indexes from 0 to n for which
+// failing holds, removing chunks of them as long as it does, with smaller
+// and smaller chunks.
+func minimize(ctx context.Context, n int, failing func(indexes []int) bool) []int {
+	kept := make([]int, n)
+	for i := range kept {
+		kept[i] = i
+	}
+	for chunks := 2; len(kept) > 0 && ctx.Err() == nil; {
+		chunks = min(chunks, len(kept))
+		removed := false
+		for c := 0; c < chunks && ctx.Err() == nil; c++ {
+			start, end := c*len(kept)/chunks, (c+1)*len(kept)/chunks
+			candidate := append(append([]int(nil), kept[:start]...), kept[end:]...)
+			if failing(candidate) {
+				kept, removed = candidate, true
+				chunks = max(chunks-1, 2)
+				break
+			}
+		}
+		if !removed {
+			if chunks == len(kept) {
+				break
+			}
+			chunks *= 2
+		}
+	}
+	return kept
+}
+
+func pick(lines []string, indexes []int) []string {
+	picked := make([]string, len(indexes))
+	for i, index := range indexes {
+		picked[i] = lines[index]
+	}
+	return picked
+}
+
+func pickColumns(lines []string, indexes []int) []string {
+	picked := make([]string, len(lines))
+	for i, line := range lines {
+		picked[i] = pickChars(line, indexes)
+	}
+	return picked
+}
+
+func pickChars(line string, indexes []int) string {
+	var sb strings.Builder
+	for _, index := range indexes {
+		sb.WriteByte(line[index])
+	}
+	return sb.String()
+}
+
+// rectangularWidth returns the length of the lines when they all have the
+// same one, which the columns are removed from.
+func rectangularWidth(lines []string) (int, bool) {
+	if len(lines) < 2 || len(lines[0]) < 2 {
+		return 0, false
+	}
+	for _, line := range lines[1:] {
+		if len(line) != len(lines[0]) {
+			return 0, false
+		}
+	}
+	return len(lines[0]), true
+}
+
+// ShrinkMismatch shrinks an input on which the part disagrees with its
+// oracle, keeping it fitting the input spec of the solver and the
+// disagreement, and returns it along with its mismatch. The context bounds
+// the time spent shrinking and passes its values to the solutions, each
+// smaller input being compared within the timeout. The mismatch is nil when
+// the input isn't a counterexample.
+func (s Solver) ShrinkMismatch(ctx context.Context, part int, input []byte, timeout time.Duration) ([]byte, *Mismatch) {
+	var mismatch *Mismatch
+	failing := func(candidate []byte) bool {
+		if s.Input != nil {
+			violations, errChecking := CheckInput(s.Input, bytes.NewReader(candidate))
+			if errChecking != nil || len(violations) > 0 {
+				return false
+			}
+		}
+		candidateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		var candidateMismatch *Mismatch
+		if !errors.As(s.CompareOracle(candidateCtx, part, candidate), &candidateMismatch) {
+			return false
+		}
+		mismatch = candidateMismatch
+		return true
+	}
+	if !failing(input) {
+		return input, nil
+	}
+	shrunk := Shrink(ctx, input, failing)
+	// The last failing candidate may not be the one kept, compare it again.
+	failing(shrunk)
+	return shrunk, mismatch
+}
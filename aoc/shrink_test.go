@@ -0,0 +1,86 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShrink(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		var input strings.Builder
+		for i := 0; i < 40; i++ {
+			input.WriteString(strings.Repeat("a", i%7) + "\n")
+		}
+		input.WriteString("x\n")
+		// Fails with a line of 3 'a' somewhere before a 'x'.
+		failing := func(candidate []byte) bool {
+			before, _, found := strings.Cut(string(candidate), "x")
+			return found && strings.Contains("\n"+before, "\naaa\n")
+		}
+		shrunk := Shrink(context.Background(), []byte(input.String()), failing)
+		if string(shrunk) != "aaa\nx\n" {
+			t.Errorf("Expected the input to be shrunk to 2 lines, got %q", shrunk)
+		}
+	})
+
+	t.Run("cells", func(t *testing.T) {
+		input := "......\n..#...\n....#.\n......\n"
+		// Fails on a rectangular grid with two rocks.
+		failing := func(candidate []byte) bool {
+			lines := strings.Split(strings.TrimSuffix(string(candidate), "\n"), "\n")
+			for _, line := range lines {
+				if len(line) != len(lines[0]) {
+					return false
+				}
+			}
+			return strings.Count(string(candidate), "#") == 2
+		}
+		shrunk := Shrink(context.Background(), []byte(input), failing)
+		// Neither a line nor a column of the rocks can be removed.
+		if string(shrunk) != "#.\n.#\n" {
+			t.Errorf("Expected the grid to be shrunk around its 2 rocks, got %q", shrunk)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		input := "a\nb\nc\n"
+		if shrunk := Shrink(ctx, []byte(input), func([]byte) bool { return true }); string(shrunk) != input {
+			t.Errorf("Expected the input to be kept once canceled, got %q", shrunk)
+		}
+	})
+}
+
+func TestShrinkMismatch(t *testing.T) {
+	count := func(_ context.Context, input io.Reader) (Answer, error) {
+		content, err := io.ReadAll(input)
+		return Int(strings.Count(string(content), "b")), err
+	}
+	// The solution misses the 'b' following an 'a'.
+	wrong := func(_ context.Context, input io.Reader) (Answer, error) {
+		content, err := io.ReadAll(input)
+		return Int(strings.Count(string(content), "b") - strings.Count(string(content), "ab")), err
+	}
+	solver := Solver{Year: 1999, Day: 7, Part1: wrong, Oracle1: count, Input: LinesMatching(`[abc]+`, "letters")}
+
+	shrunk, mismatch := solver.ShrinkMismatch(context.Background(), 1, []byte("cab\nbca\nccc\nbabc\n"), time.Second)
+	if string(shrunk) != "ab\n" {
+		t.Errorf("Expected the input to be shrunk to \"ab\\n\", got %q", shrunk)
+	}
+	if mismatch == nil || mismatch.Answer != "0" || mismatch.Expected != "1" {
+		t.Errorf("Expected the mismatch of the shrunk input, got %v", mismatch)
+	}
+
+	if _, mismatch := solver.ShrinkMismatch(context.Background(), 1, []byte("bb\n"), time.Second); mismatch != nil {
+		t.Errorf("Expected no mismatch, got %v", mismatch)
+	}
+	var errMismatch *Mismatch
+	if errors.As(solver.CompareOracle(context.Background(), 1, shrunk), &errMismatch) != true {
+		t.Errorf("Expected the shrunk input to still be a counterexample")
+	}
+}
@@ -0,0 +1,51 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+)
+
+// ErrNoSimulation is returned when stepping through a part of a solver which
+// has no simulation of it.
+var ErrNoSimulation = errors.New("no simulation")
+
+// Simulation is a solution run one step at a time, for the debugger of the
+// aoc debug command to step through it.
+type Simulation interface {
+	// Step advances the simulation by one step, and returns false without
+	// changing it once it is over.
+	Step() bool
+	// Clone returns an independent copy of the simulation, kept by the
+	// debugger to step backward.
+	Clone() Simulation
+	// View returns the current state of the simulation.
+	View() View
+}
+
+// View is the state of a simulation shown by the debugger.
+type View struct {
+	// Grid is drawn cell by cell, each kind of cell with its color.
+	Grid []string
+	// Highlights are the cells of the grid standing out, such as the next
+	// instruction of a program.
+	Highlights []image.Point
+	// Lines are shown below the grid as they are.
+	Lines []string
+	// Values are the named values of the state, which the breakpoints are
+	// conditions on.
+	Values []Value
+}
+
+// SimulateFunc returns the simulation of a part on an input, tuned by the
+// parameters of the context.
+type SimulateFunc func(ctx context.Context, part int, input io.Reader) (Simulation, error)
+
+// StartSimulation returns the simulation of the given part on the input.
+func (s Solver) StartSimulation(ctx context.Context, part int, input io.Reader) (Simulation, error) {
+	if s.Simulate == nil {
+		return nil, ErrNoSimulation
+	}
+	return s.Simulate(ctx, part, input)
+}
@@ -0,0 +1,128 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// ErrNotImplemented is returned when solving a part a solver doesn't provide.
+var ErrNotImplemented = errors.New("part not implemented")
+
+// PartFunc computes the answer of one puzzle part from its input. Solutions
+// which may run for long check the context, and return a PartialError when it
+// is done before they are.
+type PartFunc func(ctx context.Context, input io.Reader) (Answer, error)
+
+// Solver gathers the solutions of a puzzle day.
+type Solver struct {
+	Year  int
+	Day   int
+	Title string
+	Part1 PartFunc
+	Part2 PartFunc
+	// Input is the shape of the inputs of the day, nil if it isn't described.
+	Input InputSpec
+	// Generate writes random inputs of the day, nil if it has no generator.
+	Generate GenerateFunc
+	// Oracle1 and Oracle2 are naive reference solutions of the parts, too
+	// slow for the real inputs but simple enough to be trusted on small
+	// generated ones, nil if there are none.
+	Oracle1 PartFunc
+	Oracle2 PartFunc
+	// Simulate runs the solution of a part step by step, nil if it can't be
+	// stepped through.
+	Simulate SimulateFunc
+}
+
+func (s Solver) String() string {
+	return fmt.Sprintf("%d/%02d", s.Year, s.Day)
+}
+
+// Part returns the solution of the given part, nil if it isn't implemented.
+func (s Solver) Part(part int) PartFunc {
+	switch part {
+	case 1:
+		return s.Part1
+	case 2:
+		return s.Part2
+	}
+	return nil
+}
+
+// Parts returns the implemented parts of the solver.
+func (s Solver) Parts() []int {
+	var parts []int
+	for part := 1; part <= 2; part++ {
+		if s.Part(part) != nil {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// Solve computes the answer of the given part.
+func (s Solver) Solve(part int, input io.Reader) (Answer, error) {
+	return s.SolveContext(context.Background(), part, input)
+}
+
+// SolveContext computes the answer of the given part, giving up when the
+// context is done if the solution checks it.
+func (s Solver) SolveContext(ctx context.Context, part int, input io.Reader) (Answer, error) {
+	solve := s.Part(part)
+	if solve == nil {
+		return Answer{}, ErrNotImplemented
+	}
+	return solve(ctx, input)
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[[2]int]Solver)
+)
+
+// Register makes a solver available through Lookup and Solvers. It is meant to
+// be called from the init function of each day package.
+func Register(solver Solver) {
+	if solver.Day < 1 || solver.Day > 25 {
+		panic(fmt.Sprintf("aoc: invalid day %d for %s", solver.Day, solver))
+	}
+	if solver.Part1 == nil && solver.Part2 == nil {
+		panic(fmt.Sprintf("aoc: %s has no part to solve", solver))
+	}
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	key := [2]int{solver.Year, solver.Day}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("aoc: %s registered twice", solver))
+	}
+	registry[key] = solver
+}
+
+// Lookup returns the solver registered for the given day.
+func Lookup(year, day int) (Solver, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	solver, ok := registry[[2]int{year, day}]
+	return solver, ok
+}
+
+// Solvers returns every registered solver sorted by year and day.
+func Solvers() []Solver {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	solvers := make([]Solver, 0, len(registry))
+	for _, solver := range registry {
+		solvers = append(solvers, solver)
+	}
+	sort.Slice(solvers, func(i, j int) bool {
+		if solvers[i].Year != solvers[j].Year {
+			return solvers[i].Year < solvers[j].Year
+		}
+		return solvers[i].Day < solvers[j].Day
+	})
+	return solvers
+}
@@ -0,0 +1,75 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	part := func(_ context.Context, input io.Reader) (Answer, error) {
+		content, err := io.ReadAll(input)
+		return String(string(content)), err
+	}
+	Register(Solver{Year: 1999, Day: 2, Part2: part})
+	Register(Solver{Year: 1999, Day: 1, Part1: part, Part2: part})
+
+	var got []string
+	for _, solver := range Solvers() {
+		if solver.Year == 1999 {
+			got = append(got, solver.String())
+		}
+	}
+	if strings.Join(got, " ") != "1999/01 1999/02" {
+		t.Errorf("Expected solvers to be sorted, got %v", got)
+	}
+
+	solver, ok := Lookup(1999, 2)
+	if !ok {
+		t.Fatalf("Expected 1999/02 to be registered")
+	}
+	if _, err := solver.Solve(1, strings.NewReader("")); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected part 1 to be not implemented, got %v", err)
+	}
+	if answer, err := solver.Solve(2, strings.NewReader("42")); err != nil || answer.String() != "42" {
+		t.Errorf("Expected part 2 answer to be 42, got %v (%v)", answer, err)
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected duplicate registration to panic")
+			}
+		}()
+		Register(Solver{Year: 1999, Day: 1, Part1: part})
+	})
+}
+
+func TestAnswer(t *testing.T) {
+	tests := []struct {
+		answer   Answer
+		expected string
+	}{
+		{Int(-12), "-12"},
+		{Int(int8(7)), "7"},
+		{Uint(18446744073709551615), "18446744073709551615"},
+		{String("6,1"), "6,1"},
+		{Answer{}, ""},
+	}
+	for _, test := range tests {
+		if test.answer.String() != test.expected {
+			t.Errorf("Expected answer to be %q, got %q", test.expected, test.answer.String())
+		}
+	}
+	if !Int(42).Equal(Uint(42)) || !Int(42).Equal(String("42")) {
+		t.Errorf("Expected answers of different kinds to be equal")
+	}
+	if Int(0).Equal(Answer{}) {
+		t.Errorf("Expected empty answer to never be equal")
+	}
+	if _, ok := Uint(1 << 63).Int64(); ok {
+		t.Errorf("Expected 1<<63 to overflow int64")
+	}
+}
@@ -0,0 +1,212 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// InputSpec describes the shape of the inputs of a solver, so that a
+// truncated or mis-pasted input is diagnosed before being solved.
+type InputSpec interface {
+	// Check returns every violation of the spec by the lines, the first one
+	// being at the given 1-based line number.
+	Check(lines []string, first int) []*ParseError
+}
+
+// InputSpecFunc is a function checking lines as an InputSpec.
+type InputSpecFunc func(lines []string, first int) []*ParseError
+
+func (f InputSpecFunc) Check(lines []string, first int) []*ParseError {
+	return f(lines, first)
+}
+
+// CheckInput returns every violation of the spec by the input, sorted by
+// position.
+func CheckInput(spec InputSpec, input io.Reader) ([]*ParseError, error) {
+	var lines []string
+	scanner := NewScanner(input)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if errScanningFile := scanner.Err(); errScanningFile != nil {
+		return nil, errScanningFile
+	}
+	violations := spec.Check(lines, 1)
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Line != violations[j].Line {
+			return violations[i].Line < violations[j].Line
+		}
+		return violations[i].Column < violations[j].Column
+	})
+	return violations, nil
+}
+
+// linesSpec requires at least one line, each one matching a pattern.
+type linesSpec struct {
+	pattern  *regexp.Regexp
+	expected string
+}
+
+// LinesMatching returns the spec of non-empty inputs whose lines all match
+// the whole pattern, described by expected in the violations.
+func LinesMatching(pattern, expected string) InputSpec {
+	return linesSpec{pattern: regexp.MustCompile(`^(?:` + pattern + `)$`), expected: expected}
+}
+
+func (s linesSpec) Check(lines []string, first int) []*ParseError {
+	if len(lines) == 0 {
+		return []*ParseError{{Line: first, Expected: s.expected, Err: io.ErrUnexpectedEOF}}
+	}
+	var violations []*ParseError
+	for i, line := range lines {
+		if !s.pattern.MatchString(line) {
+			violations = append(violations, &ParseError{Line: first + i, Column: 1, Text: line, Expected: s.expected})
+		}
+	}
+	return violations
+}
+
+// Grid is the spec of a rectangular grid of characters.
+type Grid struct {
+	// Alphabet holds the allowed characters.
+	Alphabet string
+	// Width and Height are the required size of the grid, any size if 0.
+	Width  int
+	Height int
+	// Counts are the required number of occurrences of some characters, such
+	// as a single starting position.
+	Counts map[rune]int
+}
+
+func (g Grid) Check(lines []string, first int) []*ParseError {
+	if len(lines) == 0 {
+		return []*ParseError{{Line: first, Expected: "a grid", Err: io.ErrUnexpectedEOF}}
+	}
+	var violations []*ParseError
+	width := g.Width
+	if width == 0 {
+		width = len(lines[0])
+	}
+	if g.Height != 0 && len(lines) != g.Height {
+		violations = append(violations, &ParseError{
+			Line:     first + len(lines) - 1,
+			Text:     lines[len(lines)-1],
+			Expected: fmt.Sprintf("a grid of %d lines, got %d", g.Height, len(lines)),
+		})
+	}
+	counts := make(map[rune]int)
+	for i, line := range lines {
+		if len(line) != width {
+			violations = append(violations, &ParseError{
+				Line:     first + i,
+				Column:   1,
+				Text:     line,
+				Expected: fmt.Sprintf("a line of %d characters", width),
+			})
+		}
+		for j, char := range line {
+			counts[char]++
+			if !strings.ContainsRune(g.Alphabet, char) {
+				violations = append(violations, &ParseError{
+					Line:     first + i,
+					Column:   j + 1,
+					Text:     string(char),
+					Expected: fmt.Sprintf("a character among %q", g.Alphabet),
+				})
+			}
+		}
+	}
+	chars := make([]rune, 0, len(g.Counts))
+	for char := range g.Counts {
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+	for _, char := range chars {
+		if counts[char] != g.Counts[char] {
+			violations = append(violations, &ParseError{
+				Line:     first,
+				Text:     fmt.Sprintf("%d %q", counts[char], char),
+				Expected: fmt.Sprintf("%d %q in the grid", g.Counts[char], char),
+			})
+		}
+	}
+	return violations
+}
+
+// splitBlocks splits lines on the empty lines, returning the blocks along
+// with the line number of their first line.
+func splitBlocks(lines []string, first int) ([][]string, []int) {
+	var blocks [][]string
+	var starts []int
+	start := 0
+	for i := 0; i <= len(lines); i++ {
+		if i < len(lines) && lines[i] != "" {
+			continue
+		}
+		if i > start {
+			blocks = append(blocks, lines[start:i])
+			starts = append(starts, first+start)
+		}
+		start = i + 1
+	}
+	return blocks, starts
+}
+
+// Sections returns the spec of an input made of sections separated by empty
+// lines, each one checked by its own spec.
+func Sections(specs ...InputSpec) InputSpec {
+	return InputSpecFunc(func(lines []string, first int) []*ParseError {
+		blocks, starts := splitBlocks(lines, first)
+		var violations []*ParseError
+		for i, spec := range specs {
+			if i >= len(blocks) {
+				violations = append(violations, &ParseError{
+					Line:     first + len(lines),
+					Expected: fmt.Sprintf("%d sections separated by empty lines", len(specs)),
+					Err:      io.ErrUnexpectedEOF,
+				})
+				break
+			}
+			violations = append(violations, spec.Check(blocks[i], starts[i])...)
+		}
+		if len(blocks) > len(specs) {
+			violations = append(violations, &ParseError{
+				Line:     starts[len(specs)],
+				Column:   1,
+				Text:     blocks[len(specs)][0],
+				Expected: fmt.Sprintf("only %d sections", len(specs)),
+			})
+		}
+		return violations
+	})
+}
+
+// Blocks returns the spec of an input made of blocks separated by empty
+// lines, each one checked by the same spec.
+func Blocks(spec InputSpec) InputSpec {
+	return InputSpecFunc(func(lines []string, first int) []*ParseError {
+		blocks, starts := splitBlocks(lines, first)
+		if len(blocks) == 0 {
+			return []*ParseError{{Line: first, Expected: "a block", Err: io.ErrUnexpectedEOF}}
+		}
+		var violations []*ParseError
+		for i, block := range blocks {
+			violations = append(violations, spec.Check(block, starts[i])...)
+		}
+		return violations
+	})
+}
+
+// AllOf returns the spec of the inputs satisfying every spec.
+func AllOf(specs ...InputSpec) InputSpec {
+	return InputSpecFunc(func(lines []string, first int) []*ParseError {
+		var violations []*ParseError
+		for _, spec := range specs {
+			violations = append(violations, spec.Check(lines, first)...)
+		}
+		return violations
+	})
+}
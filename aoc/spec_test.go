@@ -0,0 +1,81 @@
+package aoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func checkInput(t *testing.T, spec InputSpec, input string) []string {
+	t.Helper()
+	violations, err := CheckInput(spec, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var messages []string
+	for _, violation := range violations {
+		messages = append(messages, violation.Error())
+	}
+	return messages
+}
+
+func TestLinesMatching(t *testing.T) {
+	spec := LinesMatching(`\d+,\d+,\d+`, "three comma-separated integers")
+	if violations := checkInput(t, spec, "1,2,3\n4,5,6\n"); len(violations) != 0 {
+		t.Errorf("Expected no violation, got %v", violations)
+	}
+	violations := checkInput(t, spec, "1,2,3\n4,5\n6,7,8,9\n")
+	expected := []string{
+		`line 2, column 1: unexpected "4,5", expected three comma-separated integers`,
+		`line 3, column 1: unexpected "6,7,8,9", expected three comma-separated integers`,
+	}
+	if strings.Join(violations, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the violations:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(violations, "\n"))
+	}
+	if violations := checkInput(t, spec, ""); len(violations) != 1 {
+		t.Errorf("Expected an empty input to be refused, got %v", violations)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	spec := Grid{Alphabet: ".#S", Counts: map[rune]int{'S': 1}}
+	if violations := checkInput(t, spec, "S.#\n..#\n"); len(violations) != 0 {
+		t.Errorf("Expected no violation, got %v", violations)
+	}
+	violations := checkInput(t, spec, "S.#\n.S\n.x#\n")
+	expected := []string{
+		`line 1: unexpected "2 'S'", expected 1 'S' in the grid`,
+		`line 2, column 1: unexpected ".S", expected a line of 3 characters`,
+		`line 3, column 2: unexpected "x", expected a character among ".#S"`,
+	}
+	if strings.Join(violations, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the violations:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(violations, "\n"))
+	}
+
+	sized := Grid{Alphabet: ".#", Width: 2, Height: 3}
+	if violations := checkInput(t, sized, "..\n##\n"); len(violations) != 1 || !strings.Contains(violations[0], "a grid of 3 lines") {
+		t.Errorf("Expected a missing line to be reported, got %v", violations)
+	}
+}
+
+func TestSectionsAndBlocks(t *testing.T) {
+	spec := Sections(Grid{Alphabet: "#.@"}, LinesMatching(`[<>^v]+`, "moves"))
+	if violations := checkInput(t, spec, "#@\n#.\n\n<>\n^v\n"); len(violations) != 0 {
+		t.Errorf("Expected no violation, got %v", violations)
+	}
+	violations := checkInput(t, spec, "#@\n#.\n\n<>\n^x\n")
+	if len(violations) != 1 || !strings.HasPrefix(violations[0], "line 5, column 1:") {
+		t.Errorf("Expected the fifth line to be reported, got %v", violations)
+	}
+	if violations := checkInput(t, spec, "#@\n#.\n"); len(violations) != 1 || !strings.Contains(violations[0], "2 sections") {
+		t.Errorf("Expected a missing section to be reported, got %v", violations)
+	}
+	if violations := checkInput(t, spec, "#@\n\n<\n\n>\n"); len(violations) != 1 || !strings.HasPrefix(violations[0], "line 5, column 1:") {
+		t.Errorf("Expected an extra section to be reported, got %v", violations)
+	}
+
+	blocks := Blocks(Grid{Alphabet: "#.", Width: 2, Height: 2})
+	violations = checkInput(t, blocks, "#.\n.#\n\n##\n#\n\n..\n..\n")
+	if len(violations) != 1 || !strings.HasPrefix(violations[0], "line 5, column 1:") {
+		t.Errorf("Expected the fifth line to be reported, got %v", violations)
+	}
+}
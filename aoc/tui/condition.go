@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// Condition is a breakpoint comparing a named value of the state of a
+// simulation with a constant, such as "robot.x >= 10". The value named step
+// is the number of steps made.
+type Condition struct {
+	Name     string
+	Operator string
+	Value    string
+}
+
+// operators are the comparisons of the conditions, the longer ones first for
+// "<=" not to be read as "<".
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// ParseCondition parses a condition written as <name> <operator> <value>,
+// the operator being one of ==, !=, <, <=, > or >=. The value may be empty.
+func ParseCondition(text string) (Condition, error) {
+	for i := range text {
+		for _, operator := range operators {
+			if !strings.HasPrefix(text[i:], operator) {
+				continue
+			}
+			condition := Condition{
+				Name:     strings.TrimSpace(text[:i]),
+				Operator: operator,
+				Value:    strings.TrimSpace(text[i+len(operator):]),
+			}
+			if condition.Name == "" {
+				return Condition{}, fmt.Errorf("missing value name in condition %q", text)
+			}
+			return condition, nil
+		}
+	}
+	return Condition{}, fmt.Errorf("missing operator in condition %q, expected one of %s", text, strings.Join(operators, " "))
+}
+
+func (c Condition) String() string {
+	return c.Name + " " + c.Operator + " " + c.Value
+}
+
+// Holds reports whether the condition holds for the view at the given step.
+// The integers are compared as such, the other values only for equality.
+func (c Condition) Holds(view aoc.View, step int) (bool, error) {
+	actual, found := strconv.Itoa(step), c.Name == "step"
+	for _, value := range view.Values {
+		if value.Name == c.Name {
+			actual, found = fmt.Sprint(value.Value), true
+			break
+		}
+	}
+	if !found {
+		return false, fmt.Errorf("unknown value %q", c.Name)
+	}
+
+	left, errLeft := strconv.ParseInt(actual, 10, 64)
+	right, errRight := strconv.ParseInt(c.Value, 10, 64)
+	if errLeft != nil || errRight != nil {
+		switch c.Operator {
+		case "==":
+			return actual == c.Value, nil
+		case "!=":
+			return actual != c.Value, nil
+		}
+		return false, fmt.Errorf("unable to compare %q with %q, %s being only for integers", actual, c.Value, c.Operator)
+	}
+	switch c.Operator {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	}
+	return left >= right, nil
+}
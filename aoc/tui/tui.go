@@ -0,0 +1,324 @@
+// Package tui steps through the simulations of the solutions in a terminal,
+// forward and backward or up to a breakpoint, showing each state with ANSI
+// colors. The commands are read line by line, so that any terminal works.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// checkpointPeriod is the number of steps between two copies of the
+// simulation kept to step backward, by replaying the steps from the last copy
+// before the wanted one.
+const checkpointPeriod = 64
+
+// DefaultRunLimit is the number of steps made at most when running up to a
+// breakpoint, for a breakpoint never reached not to hang the debugger.
+const DefaultRunLimit = 10_000_000
+
+// Debugger steps through a simulation.
+type Debugger struct {
+	// Color enables the ANSI colors, and clears the screen before each
+	// state.
+	Color bool
+	// RunLimit is the number of steps made at most when continuing.
+	RunLimit int
+	// Breakpoint stops the runs when it holds, nil if there is none.
+	Breakpoint *Condition
+
+	current aoc.Simulation
+	step    int
+	over    bool
+	// checkpoints are copies of the simulation every checkpointPeriod steps.
+	checkpoints []aoc.Simulation
+}
+
+// New returns a debugger at the first step of the simulation.
+func New(simulation aoc.Simulation) *Debugger {
+	return &Debugger{
+		Color:       true,
+		RunLimit:    DefaultRunLimit,
+		current:     simulation,
+		checkpoints: []aoc.Simulation{simulation.Clone()},
+	}
+}
+
+// Step returns the number of steps made.
+func (d *Debugger) Step() int {
+	return d.step
+}
+
+// Over reports whether the simulation is known to be over, a step having
+// been refused.
+func (d *Debugger) Over() bool {
+	return d.over
+}
+
+// View returns the current state of the simulation.
+func (d *Debugger) View() aoc.View {
+	return d.current.View()
+}
+
+// Forward makes n steps at most, stopping when the simulation is over, and
+// returns the number of steps made.
+func (d *Debugger) Forward(n int) int {
+	for i := 0; i < n; i++ {
+		if !d.forward() {
+			return i
+		}
+	}
+	return n
+}
+
+func (d *Debugger) forward() bool {
+	if d.over || !d.current.Step() {
+		d.over = true
+		return false
+	}
+	d.step++
+	if d.step%checkpointPeriod == 0 && d.step/checkpointPeriod == len(d.checkpoints) {
+		d.checkpoints = append(d.checkpoints, d.current.Clone())
+	}
+	return true
+}
+
+// Backward goes back n steps at most, stopping at the first one, and
+// returns the number of steps undone.
+func (d *Debugger) Backward(n int) int {
+	n = min(n, d.step)
+	d.Goto(d.step - n)
+	return n
+}
+
+// Goto moves to the given step, or to the last one when the simulation is
+// over before it.
+func (d *Debugger) Goto(step int) {
+	step = max(step, 0)
+	if step < d.step {
+		checkpoint := min(step/checkpointPeriod, len(d.checkpoints)-1)
+		d.current = d.checkpoints[checkpoint].Clone()
+		d.step, d.over = checkpoint*checkpointPeriod, false
+	}
+	d.Forward(step - d.step)
+}
+
+// Continue steps forward until the breakpoint holds, the simulation is over
+// or RunLimit steps are made, and returns the number of steps made along
+// with whether the breakpoint holds.
+func (d *Debugger) Continue() (int, bool, error) {
+	for n := 0; n < d.RunLimit; n++ {
+		if !d.forward() {
+			return n, false, nil
+		}
+		if d.Breakpoint == nil {
+			continue
+		}
+		holds, errChecking := d.Breakpoint.Holds(d.current.View(), d.step)
+		if errChecking != nil || holds {
+			return n + 1, holds, errChecking
+		}
+	}
+	return d.RunLimit, false, nil
+}
+
+// cellColors are the ANSI graphic renditions of the cells found in most
+// puzzles, the other cells keeping the default one.
+var cellColors = map[rune]string{
+	'#': "90",
+	'.': "2",
+	'O': "33",
+	'[': "33",
+	']': "33",
+	'@': "1;31",
+	'^': "1;32",
+	'>': "1;32",
+	'v': "1;32",
+	'<': "1;32",
+	'X': "36",
+	'S': "1;35",
+	'E': "1;35",
+}
+
+// highlight is the ANSI graphic rendition of the highlighted cells.
+const highlight = "7"
+
+// Render writes the current state: the step, the grid, the lines and the
+// values.
+func (d *Debugger) Render(w io.Writer) {
+	var sb strings.Builder
+	style := func(text, rendition string) string {
+		if !d.Color || rendition == "" {
+			return text
+		}
+		return "\x1b[" + rendition + "m" + text + "\x1b[0m"
+	}
+	if d.Color {
+		sb.WriteString("\x1b[H\x1b[2J")
+	}
+
+	status := fmt.Sprintf("step %d", d.step)
+	if d.over {
+		status += " (over)"
+	}
+	if d.Breakpoint != nil {
+		status += ", breakpoint " + d.Breakpoint.String()
+	}
+	sb.WriteString(style(status, "1") + "\n")
+
+	view := d.current.View()
+	highlighted := make(map[image.Point]bool, len(view.Highlights))
+	for _, p := range view.Highlights {
+		highlighted[p] = true
+	}
+	for y, row := range view.Grid {
+		x := 0
+		for _, cell := range row {
+			rendition := cellColors[cell]
+			if highlighted[image.Pt(x, y)] {
+				rendition = highlight
+			}
+			sb.WriteString(style(string(cell), rendition))
+			x++
+		}
+		sb.WriteByte('\n')
+	}
+	for _, line := range view.Lines {
+		sb.WriteString(line + "\n")
+	}
+	for _, value := range view.Values {
+		fmt.Fprintf(&sb, "%s = %v\n", style(value.Name, "36"), value.Value)
+	}
+	io.WriteString(w, sb.String())
+}
+
+const help = `Commands, an empty line repeating the last one:
+  n, next [count]      step forward
+  b, back [count]      step backward
+  g, goto <step>       go to a step
+  c, continue          run up to the breakpoint or the end
+  break [condition]    set the breakpoint, as <name> <operator> <value>, or show it
+  clear                remove the breakpoint
+  h, help              show the commands
+  q, quit              leave`
+
+// errQuit is returned by the command leaving the debugger.
+var errQuit = errors.New("quit")
+
+// Run shows the state and executes the commands read from in, one per line,
+// until the quit command or the end of in.
+func (d *Debugger) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	var last, message string
+	for {
+		d.Render(out)
+		if message != "" {
+			fmt.Fprintln(out, message)
+		}
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		command := strings.TrimSpace(scanner.Text())
+		if command == "" {
+			command = last
+		}
+		last = command
+		var errExecuting error
+		message, errExecuting = d.Execute(command)
+		if errors.Is(errExecuting, errQuit) {
+			return nil
+		}
+		if errExecuting != nil {
+			message = errExecuting.Error()
+		}
+	}
+}
+
+// Execute runs a command of the debugger, and returns the message telling
+// what it did.
+func (d *Debugger) Execute(command string) (string, error) {
+	name, argument, _ := strings.Cut(command, " ")
+	argument = strings.TrimSpace(argument)
+	count := func() (int, error) {
+		if argument == "" {
+			return 1, nil
+		}
+		n, errParsing := strconv.Atoi(argument)
+		if errParsing != nil || n < 0 {
+			return 0, fmt.Errorf("invalid count %q", argument)
+		}
+		return n, nil
+	}
+
+	switch name {
+	case "", "h", "help":
+		return help, nil
+	case "q", "quit":
+		return "", errQuit
+	case "n", "next":
+		n, errCount := count()
+		if errCount != nil {
+			return "", errCount
+		}
+		if d.Forward(n) < n {
+			return "simulation over", nil
+		}
+		return "", nil
+	case "b", "back":
+		n, errCount := count()
+		if errCount != nil {
+			return "", errCount
+		}
+		if d.Backward(n) < n {
+			return "first step", nil
+		}
+		return "", nil
+	case "g", "goto":
+		step, errParsing := strconv.Atoi(argument)
+		if errParsing != nil || step < 0 {
+			return "", fmt.Errorf("invalid step %q", argument)
+		}
+		d.Goto(step)
+		if d.step < step {
+			return "simulation over", nil
+		}
+		return "", nil
+	case "c", "continue":
+		steps, holds, errContinuing := d.Continue()
+		switch {
+		case errContinuing != nil:
+			return "", fmt.Errorf("breakpoint %s: %w", d.Breakpoint, errContinuing)
+		case holds:
+			return fmt.Sprintf("breakpoint %s hit after %d step(s)", d.Breakpoint, steps), nil
+		case d.over:
+			return fmt.Sprintf("simulation over after %d step(s)", steps), nil
+		}
+		return fmt.Sprintf("stopped after %d step(s), the run limit", steps), nil
+	case "break":
+		if argument == "" {
+			if d.Breakpoint == nil {
+				return "no breakpoint", nil
+			}
+			return "breakpoint " + d.Breakpoint.String(), nil
+		}
+		condition, errParsing := ParseCondition(argument)
+		if errParsing != nil {
+			return "", errParsing
+		}
+		d.Breakpoint = &condition
+		return "", nil
+	case "clear":
+		d.Breakpoint = nil
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown command %q, help lists them", name)
+}
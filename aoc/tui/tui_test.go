@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antitoine/advent-of-code/aoc"
+)
+
+// counter counts up to its end, one by one.
+type counter struct {
+	count, end int
+}
+
+func (c *counter) Step() bool {
+	if c.count == c.end {
+		return false
+	}
+	c.count++
+	return true
+}
+
+func (c *counter) Clone() aoc.Simulation {
+	clone := *c
+	return &clone
+}
+
+func (c *counter) View() aoc.View {
+	return aoc.View{
+		Grid:   []string{strings.Repeat("#", c.count%10) + "@"},
+		Values: []aoc.Value{{Name: "count", Value: c.count}, {Name: "parity", Value: []string{"even", "odd"}[c.count%2]}},
+	}
+}
+
+func count(d *Debugger) int {
+	return d.View().Values[0].Value.(int)
+}
+
+func TestDebuggerSteps(t *testing.T) {
+	debugger := New(&counter{end: 200})
+	if debugger.Forward(150) != 150 || count(debugger) != 150 {
+		t.Fatalf("Expected 150 steps, got %d", count(debugger))
+	}
+	if debugger.Backward(100) != 100 || debugger.Step() != 50 || count(debugger) != 50 {
+		t.Errorf("Expected to go back to 50, got step %d and count %d", debugger.Step(), count(debugger))
+	}
+	debugger.Goto(130)
+	if debugger.Step() != 130 || count(debugger) != 130 {
+		t.Errorf("Expected to go to 130, got step %d and count %d", debugger.Step(), count(debugger))
+	}
+	if debugger.Forward(100) != 70 || !debugger.Over() || count(debugger) != 200 {
+		t.Errorf("Expected the simulation to be over at 200, got %d", count(debugger))
+	}
+	if debugger.Backward(300) != 200 || debugger.Over() || count(debugger) != 0 {
+		t.Errorf("Expected to go back to the start, got %d", count(debugger))
+	}
+}
+
+func TestDebuggerContinue(t *testing.T) {
+	debugger := New(&counter{end: 200})
+	debugger.Breakpoint = &Condition{Name: "count", Operator: ">=", Value: "42"}
+	if steps, holds, err := debugger.Continue(); err != nil || !holds || steps != 42 {
+		t.Errorf("Expected the breakpoint to hold after 42 steps, got %d, %t, %v", steps, holds, err)
+	}
+	debugger.Breakpoint = &Condition{Name: "parity", Operator: "==", Value: "odd"}
+	if steps, holds, err := debugger.Continue(); err != nil || !holds || steps != 1 {
+		t.Errorf("Expected the breakpoint to hold after a step, got %d, %t, %v", steps, holds, err)
+	}
+	debugger.Breakpoint = &Condition{Name: "step", Operator: "==", Value: "1000"}
+	if steps, holds, err := debugger.Continue(); err != nil || holds || !debugger.Over() || steps != 157 {
+		t.Errorf("Expected the simulation to end after 157 steps, got %d, %t, %v", steps, holds, err)
+	}
+	debugger.Goto(0)
+	debugger.Breakpoint = &Condition{Name: "parity", Operator: "<", Value: "odd"}
+	if _, _, err := debugger.Continue(); err == nil {
+		t.Errorf("Expected strings not to be ordered")
+	}
+	debugger.Breakpoint = &Condition{Name: "missing", Operator: "==", Value: "1"}
+	if _, _, err := debugger.Continue(); err == nil {
+		t.Errorf("Expected an unknown value to be refused")
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	for text, expected := range map[string]Condition{
+		"robot.x>=10":  {Name: "robot.x", Operator: ">=", Value: "10"},
+		"count < 3":    {Name: "count", Operator: "<", Value: "3"},
+		" out != ":     {Name: "out", Operator: "!=", Value: ""},
+		"state == on ": {Name: "state", Operator: "==", Value: "on"},
+	} {
+		condition, err := ParseCondition(text)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if condition != expected {
+			t.Errorf("Expected %q to be parsed as %+v, got %+v", text, expected, condition)
+		}
+	}
+	for _, text := range []string{"count", "== 3", ""} {
+		if _, err := ParseCondition(text); err == nil {
+			t.Errorf("Expected %q to be refused", text)
+		}
+	}
+}
+
+func TestDebuggerRun(t *testing.T) {
+	debugger := New(&counter{end: 20})
+	debugger.Color = false
+	var out strings.Builder
+	commands := "n 3\n\nb\nbreak count == 12\nc\ngoto 100\nfly\nq\nn\n"
+	if err := debugger.Run(strings.NewReader(commands), &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"step 3\n###@\ncount = 3\nparity = odd\n",
+		"step 6\n",
+		"step 5\n",
+		"step 12, breakpoint count == 12\n",
+		"breakpoint count == 12 hit after 7 step(s)\n",
+		"step 20 (over), breakpoint count == 12\n",
+		"simulation over\n",
+		`unknown command "fly"`,
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out.String())
+		}
+	}
+	if debugger.Step() != 20 {
+		t.Errorf("Expected the commands after quitting to be ignored, got step %d", debugger.Step())
+	}
+}
+
+func TestRenderColors(t *testing.T) {
+	debugger := New(&counter{end: 1})
+	var out strings.Builder
+	debugger.Render(&out)
+	if !strings.HasPrefix(out.String(), "\x1b[H\x1b[2J") || !strings.Contains(out.String(), "\x1b[1;31m@\x1b[0m") {
+		t.Errorf("Expected the screen to be cleared and the cells colored, got %q", out.String())
+	}
+}